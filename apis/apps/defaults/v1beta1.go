@@ -28,6 +28,13 @@ import (
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 )
 
+const (
+	// DefaultAdvancedCronJobSuccessfulJobsHistoryLimit is the default successfulJobsHistoryLimit of AdvancedCronJob, the same as CronJob.
+	DefaultAdvancedCronJobSuccessfulJobsHistoryLimit int32 = 3
+	// DefaultAdvancedCronJobFailedJobsHistoryLimit is the default failedJobsHistoryLimit of AdvancedCronJob, the same as CronJob.
+	DefaultAdvancedCronJobFailedJobsHistoryLimit int32 = 1
+)
+
 // SetDefaultsStatefulSet set default values for StatefulSet.
 func SetDefaultsStatefulSet(obj *v1beta1.StatefulSet, injectTemplateDefaults bool) {
 	if len(obj.Spec.PodManagementPolicy) == 0 {
@@ -154,12 +161,13 @@ func SetDefaultsAdvancedCronJob(obj *v1beta1.AdvancedCronJob, injectTemplateDefa
 	}
 
 	if obj.Spec.SuccessfulJobsHistoryLimit == nil {
-		obj.Spec.SuccessfulJobsHistoryLimit = new(int32)
-		*obj.Spec.SuccessfulJobsHistoryLimit = 3
+		obj.Spec.SuccessfulJobsHistoryLimit = ptr.To(DefaultAdvancedCronJobSuccessfulJobsHistoryLimit)
 	}
 	if obj.Spec.FailedJobsHistoryLimit == nil {
-		obj.Spec.FailedJobsHistoryLimit = new(int32)
-		*obj.Spec.FailedJobsHistoryLimit = 1
+		obj.Spec.FailedJobsHistoryLimit = ptr.To(DefaultAdvancedCronJobFailedJobsHistoryLimit)
+	}
+	if obj.Spec.DeadlinePolicy == "" {
+		obj.Spec.DeadlinePolicy = v1beta1.SkipDeadlinePolicy
 	}
 }
 
@@ -24,6 +24,20 @@ import (
 
 const AdvancedCronJobKind = "AdvancedCronJob"
 
+const (
+	// AdvancedCronJobTriggerAnnotation is reserved for manually triggering a one-off run of the AdvancedCronJob,
+	// its value is the RFC3339 time of the request. It is not allowed on a paused AdvancedCronJob.
+	AdvancedCronJobTriggerAnnotation = "advancedcronjob.kruise.io/trigger"
+
+	// AdvancedCronJobTZDataVersionAnnotation records the version of the time zone database with which
+	// the AdvancedCronJob was validated, e.g. 2025b. It is set by the webhook.
+	AdvancedCronJobTZDataVersionAnnotation = "advancedcronjob.kruise.io/tzdata-version"
+
+	// AdvancedCronJobScheduleModifiedAnnotation records the RFC3339 time the schedule of the AdvancedCronJob was
+	// last modified. It is set by the webhook with the AdvancedCronJobScheduleEditCooldown feature-gate.
+	AdvancedCronJobScheduleModifiedAnnotation = "advancedcronjob.kruise.io/schedule-modified"
+)
+
 // AdvancedCronJobSpec defines the desired state of AdvancedCronJob
 type AdvancedCronJobSpec struct {
 	// +kubebuilder:validation:MinLength=0
@@ -36,11 +50,42 @@ type AdvancedCronJobSpec struct {
 	// +optional
 	TimeZone *string `json:"timeZone,omitempty" protobuf:"bytes,8,opt,name=timeZone"`
 
+	// ScheduleExpression is a standard cron schedule followed by exclusion clauses, for the fire
+	// times that can not be expressed by cron, e.g. "0 9 * * MON-FRI except last FRI".
+	// Supported clauses are "first <day-of-week>", "last <day-of-week>", "day <day-of-month>",
+	// "lastday" and "date <YYYY-MM-DD>", joined by "or".
+	// It is mutually exclusive with schedule, which must be empty when this is set.
+	// +optional
+	ScheduleExpression *string `json:"scheduleExpression,omitempty" protobuf:"bytes,9,opt,name=scheduleExpression"`
+
+	// RunAt is the time of the only run of a one-time AdvancedCronJob.
+	// It is mutually exclusive with schedule and scheduleExpression, which must be empty when this is set.
+	// +optional
+	RunAt *metav1.Time `json:"runAt,omitempty" protobuf:"bytes,10,opt,name=runAt"`
+
+	// SkipDates are the dates in YYYY-MM-DD on which the runs are skipped, e.g. public holidays.
+	// The dates are in the time zone of the schedule.
+	// +optional
+	SkipDates []string `json:"skipDates,omitempty" protobuf:"bytes,12,rep,name=skipDates"`
+
+	// SolarSchedule fires the AdvancedCronJob daily at a sunrise or sunset of a location, e.g. 30 minutes
+	// after sunset in Berlin. It is mutually exclusive with schedule, scheduleExpression and runAt, which
+	// must be empty when this is set.
+	// +optional
+	SolarSchedule *SolarSchedule `json:"solarSchedule,omitempty" protobuf:"bytes,11,opt,name=solarSchedule"`
+
 	// Optional deadline in seconds for starting the job if it misses scheduled
 	// time for any reason.  Missed jobs executions will be counted as failed ones.
 	// +optional
 	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty" protobuf:"varint,2,opt,name=startingDeadlineSeconds"`
 
+	// Specifies how to treat a run that is not started within startingDeadlineSeconds.
+	// Valid values are:
+	// - "Skip" (default): skips the run, and waits for the next one;
+	// - "RunImmediately": starts the most recent missed run as soon as possible, regardless of how late it is
+	// +optional
+	DeadlinePolicy DeadlinePolicy `json:"deadlinePolicy,omitempty" protobuf:"bytes,13,opt,name=deadlinePolicy"`
+
 	// Specifies how to treat concurrent executions of a Job.
 	// Valid values are:
 	// - "Allow" (default): allows CronJobs to run concurrently;
@@ -67,6 +112,35 @@ type AdvancedCronJobSpec struct {
 	Template CronJobTemplate `json:"template" protobuf:"bytes,7,opt,name=template"`
 }
 
+// SolarEvent is the event of the sun a SolarSchedule fires at.
+// +kubebuilder:validation:Enum=sunrise;sunset
+type SolarEvent string
+
+const (
+	// SunriseSolarEvent is the time the upper edge of the sun appears over the horizon.
+	SunriseSolarEvent SolarEvent = "sunrise"
+
+	// SunsetSolarEvent is the time the upper edge of the sun disappears below the horizon.
+	SunsetSolarEvent SolarEvent = "sunset"
+)
+
+// SolarSchedule is a daily schedule relative to a sunrise or sunset of a location. No run is scheduled
+// on the days the event does not happen, e.g. during the polar night.
+type SolarSchedule struct {
+	// Event is the event of the sun to fire at, sunrise or sunset.
+	Event SolarEvent `json:"event" protobuf:"bytes,1,opt,name=event"`
+
+	// Offset is the duration to fire after the event, negative for before the event, e.g. "30m" or "-1h".
+	// +optional
+	Offset *metav1.Duration `json:"offset,omitempty" protobuf:"bytes,2,opt,name=offset"`
+
+	// Latitude of the location in decimal degrees, in [-90, 90] and positive for north, e.g. "52.52".
+	Latitude string `json:"latitude" protobuf:"bytes,3,opt,name=latitude"`
+
+	// Longitude of the location in decimal degrees, in [-180, 180] and positive for east, e.g. "13.405".
+	Longitude string `json:"longitude" protobuf:"bytes,4,opt,name=longitude"`
+}
+
 type CronJobTemplate struct {
 	// Specifies the job that will be created when executing a CronJob.
 	// +optional
@@ -134,6 +208,19 @@ const (
 	ReplaceConcurrent ConcurrencyPolicy = "Replace"
 )
 
+// DeadlinePolicy describes how a run missing the startingDeadlineSeconds will be handled.
+// If none of the following policies is specified, the default one is SkipDeadlinePolicy.
+// +kubebuilder:validation:Enum=Skip;RunImmediately
+type DeadlinePolicy string
+
+const (
+	// SkipDeadlinePolicy skips the runs not started within startingDeadlineSeconds.
+	SkipDeadlinePolicy DeadlinePolicy = "Skip"
+
+	// RunImmediatelyDeadlinePolicy starts the most recent run missing startingDeadlineSeconds as soon as possible.
+	RunImmediatelyDeadlinePolicy DeadlinePolicy = "RunImmediately"
+)
+
 // AdvancedCronJobStatus defines the observed state of AdvancedCronJob
 type AdvancedCronJobStatus struct {
 	Type TemplateKind `json:"type,omitempty"`
@@ -142,9 +229,65 @@ type AdvancedCronJobStatus struct {
 	// +optional
 	Active []corev1.ObjectReference `json:"active,omitempty"`
 
+	// A list of pointers to the jobs created for the most recent run, no matter they are running or finished.
+	// +optional
+	LastRunChildren []corev1.ObjectReference `json:"lastRunChildren,omitempty"`
+
 	// Information when was the last time the job was successfully scheduled.
 	// +optional
 	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// The number of consecutive failed runs since the most recent successful run.
+	// It is reset to zero once a run succeeds.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// The scheduled time of the most recent finished run counted in ConsecutiveFailures, the runs scheduled
+	// before it are not counted again.
+	// +optional
+	ConsecutiveFailuresCountedTime *metav1.Time `json:"consecutiveFailuresCountedTime,omitempty"`
+
+	// Conditions represents the latest available observations of an AdvancedCronJob's current state.
+	// +optional
+	Conditions []AdvancedCronJobCondition `json:"conditions,omitempty"`
+
+	// Whether the next run is within an hour of a daylight saving time transition of the time zone
+	// the AdvancedCronJob is scheduled in, so that a run may be skipped or run twice.
+	// +optional
+	NextRunDSTWarning bool `json:"nextRunDSTWarning,omitempty"`
+
+	// The message of NextRunDSTWarning, telling the next run time and the transition.
+	// +optional
+	NextRunDSTMessage string `json:"nextRunDSTMessage,omitempty"`
+}
+
+// AdvancedCronJobConditionType is type for AdvancedCronJob conditions.
+type AdvancedCronJobConditionType string
+
+const (
+	// AdvancedCronJobConditionChildCreationFailing indicates advancedcronjob controller failed to create the child
+	// of the most recent run, and is backing off before retrying.
+	AdvancedCronJobConditionChildCreationFailing AdvancedCronJobConditionType = "ChildCreationFailing"
+	// AdvancedCronJobConditionComplete indicates the run of a one-time AdvancedCronJob at spec.runAt has finished,
+	// and no more children are created until runAt is moved.
+	AdvancedCronJobConditionComplete AdvancedCronJobConditionType = "Complete"
+	// AdvancedCronJobConditionInvalidTimeZone indicates the time zone the AdvancedCronJob is scheduled in can not be
+	// loaded, and no more children are created until it is fixed.
+	AdvancedCronJobConditionInvalidTimeZone AdvancedCronJobConditionType = "InvalidTimeZone"
+)
+
+// AdvancedCronJobCondition describes the state of an AdvancedCronJob at a certain point.
+type AdvancedCronJobCondition struct {
+	// Type of AdvancedCronJob condition.
+	Type AdvancedCronJobConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// The reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
@@ -57,6 +57,22 @@ func (in *AdvancedCronJob) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdvancedCronJobCondition) DeepCopyInto(out *AdvancedCronJobCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedCronJobCondition.
+func (in *AdvancedCronJobCondition) DeepCopy() *AdvancedCronJobCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(AdvancedCronJobCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdvancedCronJobList) DeepCopyInto(out *AdvancedCronJobList) {
 	*out = *in
@@ -97,6 +113,25 @@ func (in *AdvancedCronJobSpec) DeepCopyInto(out *AdvancedCronJobSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ScheduleExpression != nil {
+		in, out := &in.ScheduleExpression, &out.ScheduleExpression
+		*out = new(string)
+		**out = **in
+	}
+	if in.RunAt != nil {
+		in, out := &in.RunAt, &out.RunAt
+		*out = (*in).DeepCopy()
+	}
+	if in.SkipDates != nil {
+		in, out := &in.SkipDates, &out.SkipDates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SolarSchedule != nil {
+		in, out := &in.SolarSchedule, &out.SolarSchedule
+		*out = new(SolarSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StartingDeadlineSeconds != nil {
 		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
 		*out = new(int64)
@@ -138,10 +173,26 @@ func (in *AdvancedCronJobStatus) DeepCopyInto(out *AdvancedCronJobStatus) {
 		*out = make([]corev1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastRunChildren != nil {
+		in, out := &in.LastRunChildren, &out.LastRunChildren
+		*out = make([]corev1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastScheduleTime != nil {
 		in, out := &in.LastScheduleTime, &out.LastScheduleTime
 		*out = (*in).DeepCopy()
 	}
+	if in.ConsecutiveFailuresCountedTime != nil {
+		in, out := &in.ConsecutiveFailuresCountedTime, &out.ConsecutiveFailuresCountedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]AdvancedCronJobCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedCronJobStatus.
@@ -942,6 +993,26 @@ func (in *SandboxConfig) DeepCopy() *SandboxConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolarSchedule) DeepCopyInto(out *SolarSchedule) {
+	*out = *in
+	if in.Offset != nil {
+		in, out := &in.Offset, &out.Offset
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolarSchedule.
+func (in *SolarSchedule) DeepCopy() *SolarSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(SolarSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatefulSet) DeepCopyInto(out *StatefulSet) {
 	*out = *in
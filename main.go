@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"math/rand"
 	"net/http"
@@ -50,6 +51,7 @@ import (
 	extclient "github.com/openkruise/kruise/pkg/client"
 	"github.com/openkruise/kruise/pkg/control/pubcontrol"
 	"github.com/openkruise/kruise/pkg/controller"
+	"github.com/openkruise/kruise/pkg/controller/advancedcronjob"
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
 	utilclient "github.com/openkruise/kruise/pkg/util/client"
@@ -166,12 +168,22 @@ func main() {
 	}
 
 	ctx := ctrl.SetupSignalHandler()
+	shutdownTracerProvider, err := advancedcronjob.SetupTracerProvider(ctx)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracer provider")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracerProvider(context.Background()); err != nil {
+			setupLog.Error(err, "unable to shut down tracer provider")
+		}
+	}()
 	cfg := ctrl.GetConfigOrDie()
 	setRestConfig(cfg)
 	cfg.UserAgent = "kruise-manager"
 
 	setupLog.Info("new clientset registry")
-	err := extclient.NewRegistry(cfg)
+	err = extclient.NewRegistry(cfg)
 	if err != nil {
 		setupLog.Error(err, "unable to init kruise clientset and informer")
 		os.Exit(1)
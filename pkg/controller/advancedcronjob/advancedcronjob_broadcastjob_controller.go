@@ -20,8 +20,9 @@ import (
 	"sort"
 	"time"
 
-	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ref "k8s.io/client-go/tools/reference"
 	"k8s.io/klog/v2"
@@ -35,6 +36,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 )
 
 func watchBroadcastJob(mgr manager.Manager, c controller.Controller) error {
@@ -56,6 +59,8 @@ func watchBroadcastJob(mgr manager.Manager, c controller.Controller) error {
 	return nil
 }
 
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
 func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, req ctrl.Request, advancedCronJob appsv1beta1.AdvancedCronJob) (ctrl.Result, error) {
 	advancedCronJob.Status.Type = appsv1beta1.BroadcastJobTemplate
 
@@ -69,6 +74,9 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 	var successfulJobs []*appsv1beta1.BroadcastJob
 	var failedJobs []*appsv1beta1.BroadcastJob
 	var mostRecentTime *time.Time
+	var runs []childRun
+	// the children of the most recent run
+	var lastRunJobs []*appsv1beta1.BroadcastJob
 	isJobFinished := func(job *appsv1beta1.BroadcastJob) (bool, appsv1beta1.JobConditionType) {
 		for _, c := range job.Status.Conditions {
 			if (c.Type == appsv1beta1.JobComplete || c.Type == appsv1beta1.JobFailed) && c.Status == corev1.ConditionTrue {
@@ -114,10 +122,13 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 			continue
 		}
 		if scheduledTimeForJob != nil {
-			if mostRecentTime == nil {
-				mostRecentTime = scheduledTimeForJob
-			} else if mostRecentTime.Before(*scheduledTimeForJob) {
+			runs = append(runs, childRun{scheduledTime: *scheduledTimeForJob, active: finishedType == "", failed: finishedType == appsv1beta1.JobFailed})
+			if mostRecentTime == nil || mostRecentTime.Before(*scheduledTimeForJob) {
 				mostRecentTime = scheduledTimeForJob
+				lastRunJobs = nil
+			}
+			if mostRecentTime.Equal(*scheduledTimeForJob) {
+				lastRunJobs = append(lastRunJobs, &childJobs.Items[i])
 			}
 		}
 	}
@@ -127,6 +138,8 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 	} else {
 		advancedCronJob.Status.LastScheduleTime = nil
 	}
+	advancedCronJob.Status.ConsecutiveFailures, advancedCronJob.Status.ConsecutiveFailuresCountedTime = countConsecutiveFailures(
+		runs, advancedCronJob.Status.ConsecutiveFailures, advancedCronJob.Status.ConsecutiveFailuresCountedTime)
 
 	advancedCronJob.Status.Active = nil
 	for _, activeJob := range activeJobs {
@@ -138,7 +151,20 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 		advancedCronJob.Status.Active = append(advancedCronJob.Status.Active, *jobRef)
 	}
 
+	advancedCronJob.Status.LastRunChildren = nil
+	for _, lastRunJob := range lastRunJobs {
+		jobRef, err := ref.GetReference(r.scheme, lastRunJob)
+		if err != nil {
+			klog.ErrorS(err, "Unable to make reference to last run BroadcastJob", "broadcastJob", klog.KObj(lastRunJob), "advancedCronJob", req)
+			continue
+		}
+		advancedCronJob.Status.LastRunChildren = append(advancedCronJob.Status.LastRunChildren, *jobRef)
+	}
+
 	klog.V(1).InfoS("AdvancedCronJob count", "activeJobCount", len(activeJobs), "successfulJobCount", len(successfulJobs), "failedJobCount", len(failedJobs), "advancedCronJob", req)
+	complete := updateCompleteCondition(&advancedCronJob, r.Now())
+	invalidTimeZone := updateInvalidTimeZoneCondition(&advancedCronJob, r.Now())
+	updateNextRunDSTWarning(&advancedCronJob, r.Now())
 	if err := r.updateAdvancedJobStatus(req, &advancedCronJob); err != nil {
 		klog.ErrorS(err, "Unable to update AdvancedCronJob status", "advancedCronJob", req)
 		return ctrl.Result{}, err
@@ -200,6 +226,16 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 
 	if advancedCronJob.Spec.Paused != nil && *advancedCronJob.Spec.Paused {
 		klog.V(1).InfoS("AdvancedCronJob paused, skipping", "advancedCronJob", req)
+		return r.reportPausedRun(&advancedCronJob), nil
+	}
+
+	if complete {
+		klog.V(1).InfoS("One-time AdvancedCronJob complete, skipping", "advancedCronJob", req)
+		return ctrl.Result{}, nil
+	}
+
+	if invalidTimeZone {
+		klog.InfoS("AdvancedCronJob time zone can not be loaded, skipping until it is fixed", "advancedCronJob", req)
 		return ctrl.Result{}, nil
 	}
 
@@ -219,8 +255,11 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 		and the next run, so that we can know when it's time to reconcile again.
 	*/
 	getNextSchedule := func(cronJob *appsv1beta1.AdvancedCronJob, now time.Time) (lastMissed time.Time, next time.Time, err error) {
-		sched, err := cron.ParseStandard(formatSchedule(cronJob))
+		sched, err := getCachedSchedule(cronJob)
 		if err != nil {
+			if cronJob.Spec.ScheduleExpression != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("unparsable schedule expression %q: %v", *cronJob.Spec.ScheduleExpression, err)
+			}
 			return time.Time{}, time.Time{}, fmt.Errorf("unparsable schedule %q: %v", cronJob.Spec.Schedule, err)
 		}
 
@@ -233,20 +272,26 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 		} else {
 			earliestTime = cronJob.ObjectMeta.CreationTimestamp.Time
 		}
-		if cronJob.Spec.StartingDeadlineSeconds != nil {
+		if skipsLateRuns(cronJob) {
 			// controller is not going to schedule anything below this point
 			schedulingDeadline := now.Add(-time.Second * time.Duration(*cronJob.Spec.StartingDeadlineSeconds))
 
 			if schedulingDeadline.After(earliestTime) {
+				// the runs scheduled before the deadline can not be started any more
+				r.reportMissedDeadlineRuns(cronJob, sched, earliestTime, schedulingDeadline)
 				earliestTime = schedulingDeadline
 			}
+		} else {
+			// without a deadline, or starting the late runs under deadlinePolicy RunImmediately, don't try to catch up
+			// with the runs missed long ago
+			earliestTime = missedSchedulesLookbackStart(sched, earliestTime, now)
 		}
 		if earliestTime.After(now) {
 			return time.Time{}, sched.Next(now), nil
 		}
 
 		starts := 0
-		for t := sched.Next(earliestTime); !t.After(now); t = sched.Next(t) {
+		for t := sched.Next(earliestTime); !t.IsZero() && !t.After(now); t = sched.Next(t) {
 			lastMissed = t
 			// An object might miss several starts. For example, if
 			// controller gets wedged on Friday at 5:01pm when everyone has
@@ -274,8 +319,10 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 
 	// figure out the next times that we need to create
 	// jobs at (or anything we missed).
-	now := realClock{}.Now()
+	now := r.Now()
+	_, scheduleSpan := r.startSpan(ctx, "GetNextSchedule", &advancedCronJob)
 	missedRun, nextRun, err := getNextSchedule(&advancedCronJob, now)
+	endSpan(scheduleSpan, err)
 	if err != nil {
 		klog.ErrorS(err, "Unable to figure out CronJob schedule", "advancedCronJob", req)
 		// we don't really care about requeuing until we get an update that
@@ -298,9 +345,18 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 		return scheduledResult, nil
 	}
 
+	_, suppressionSpan := r.startSpan(ctx, "IsSuppressed", &advancedCronJob)
+	suppressed, reason := IsSuppressed(&advancedCronJob, missedRun)
+	endSpan(suppressionSpan, nil, attribute.Bool("suppressed", suppressed), attribute.String("reason", reason))
+	if suppressed {
+		klog.V(1).InfoS("Run suppressed, sleeping till next run", "missedRun", missedRun, "reason", reason, "advancedCronJob", req)
+		r.reportSkippedRun(&advancedCronJob, missedRun, reason)
+		return scheduledResult, nil
+	}
+
 	// make sure we're not too late to start the run
 	tooLate := false
-	if advancedCronJob.Spec.StartingDeadlineSeconds != nil {
+	if skipsLateRuns(&advancedCronJob) {
 		tooLate = missedRun.Add(time.Duration(*advancedCronJob.Spec.StartingDeadlineSeconds) * time.Second).Before(now)
 	}
 	if tooLate {
@@ -308,6 +364,22 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 		return scheduledResult, nil
 	}
 
+	// the run is a no-op if its child was already created by a previous reconciliation
+	childName := getChildName(&advancedCronJob, missedRun)
+	if exists, err := r.childExists(ctx, &appsv1beta1.BroadcastJob{}, advancedCronJob.Namespace, childName); err != nil {
+		klog.ErrorS(err, "Unable to get BroadcastJob for AdvancedCronJob run", "broadcastJob", klog.KRef(advancedCronJob.Namespace, childName), "advancedCronJob", req)
+		return ctrl.Result{}, err
+	} else if exists {
+		klog.V(1).InfoS("BroadcastJob of AdvancedCronJob run already exists, skipping", "broadcastJob", klog.KRef(advancedCronJob.Namespace, childName), "advancedCronJob", req)
+		return scheduledResult, nil
+	}
+
+	// a failure to create the child backs off until it expires, no matter what triggers the reconciliation
+	if backoff := childCreationBackoffRemaining(req.NamespacedName, advancedCronJob.Generation, now); backoff > 0 {
+		klog.V(1).InfoS("Backing off creating BroadcastJob of AdvancedCronJob run", "backoff", backoff, "advancedCronJob", req)
+		return ctrl.Result{RequeueAfter: backoff}, nil
+	}
+
 	/*
 		If we actually have to run a job, we'll need to either wait till existing ones finish,
 		replace the existing ones, or just add new ones.  If our information is out of date due
@@ -315,13 +387,18 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 	*/
 	// figure out how to run this job -- concurrency policy might forbid us from running
 	// multiple at the same time...
-	if advancedCronJob.Spec.ConcurrencyPolicy == appsv1beta1.ForbidConcurrent && len(activeJobs) > 0 {
+	concurrencyPolicy, err := r.broadcastJobConcurrencyPolicy(ctx, &advancedCronJob, len(activeJobs))
+	if err != nil {
+		klog.ErrorS(err, "Unable to list nodes for the concurrency policy", "advancedCronJob", req)
+		return ctrl.Result{}, err
+	}
+	if concurrencyPolicy == appsv1beta1.ForbidConcurrent && len(activeJobs) > 0 {
 		klog.V(1).InfoS("Concurrency policy blocks concurrent runs, skipping", "activeBroadcastJobCount", len(activeJobs), "advancedCronJob", req)
 		return scheduledResult, nil
 	}
 
 	// ...or instruct us to replace existing ones...
-	if advancedCronJob.Spec.ConcurrencyPolicy == appsv1beta1.ReplaceConcurrent {
+	if concurrencyPolicy == appsv1beta1.ReplaceConcurrent {
 		for _, activeJob := range activeJobs {
 			// we don't care if the job was already deleted
 			if err := r.Delete(ctx, activeJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
@@ -341,9 +418,10 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 		to clean up jobs when we delete the CronJob, and allows controller-runtime to figure out
 		which cronjob needs to be reconciled when a given job changes (is added, deleted, completes, etc).
 	*/
-	constructBrJobForCronJob := func(advancedCronJob *appsv1beta1.AdvancedCronJob, scheduledTime time.Time) (*appsv1beta1.BroadcastJob, error) {
+	constructBrJobForCronJob := func(ctx context.Context, advancedCronJob *appsv1beta1.AdvancedCronJob, scheduledTime time.Time) (*appsv1beta1.BroadcastJob, error) {
+		_, span := r.startSpan(ctx, "ConstructChild", advancedCronJob)
 		// We want job names for a given nominal start time to have a deterministic name to avoid the same job being created twice
-		name := fmt.Sprintf("%s-%d", advancedCronJob.Name, scheduledTime.Unix())
+		name := getChildName(advancedCronJob, scheduledTime)
 
 		job := &appsv1beta1.BroadcastJob{
 			ObjectMeta: metav1.ObjectMeta{
@@ -362,28 +440,42 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 			job.Labels[k] = v
 		}
 		if err := ctrl.SetControllerReference(advancedCronJob, job, r.scheme); err != nil {
+			endSpan(span, err)
 			return nil, err
 		}
 
+		endSpan(span, nil, attribute.String("child", job.Name))
 		return job, nil
 	}
 	// +kubebuilder:docs-gen:collapse=constructJobForCronJob
 
 	// actually make the job...
-	job, err := constructBrJobForCronJob(&advancedCronJob, missedRun)
+	createCtx, createSpan := r.startSpan(ctx, "CreateChild", &advancedCronJob)
+	job, err := constructBrJobForCronJob(createCtx, &advancedCronJob, missedRun)
 	if err != nil {
+		endSpan(createSpan, err)
 		klog.ErrorS(err, "Unable to construct broadcastjob from template", "advancedCronJob", req)
 		// don't bother requeuing until we get a change to the spec
 		return scheduledResult, nil
 	}
 
 	// ...and create it on the cluster
-	if err := r.Create(ctx, job); err != nil {
+	err = r.Create(createCtx, job)
+	endSpan(createSpan, err, attribute.String("child", job.Name))
+	if errors.IsAlreadyExists(err) {
+		// created by a previous reconciliation of the same run, which childExists missed in the stale cache
+		klog.V(1).InfoS("BroadcastJob of AdvancedCronJob run already exists, skipping", "broadcastJob", klog.KObj(job), "advancedCronJob", req)
+		return scheduledResult, nil
+	}
+	if err != nil {
 		klog.ErrorS(err, "Unable to create BroadcastJob for CronJob", "broadcastJob", klog.KObj(job), "advancedCronJob", req)
-		return ctrl.Result{}, err
+		return r.childCreationFailed(req, &advancedCronJob, err)
 	}
 
 	klog.V(1).InfoS("Created BroadcastJob for CronJob run", "broadcastJob", klog.KObj(job), "advancedCronJob", req)
+	recordScheduleDrift(req.Namespace, missedRun, job.CreationTimestamp, now)
+	logSchedulingDecision(&advancedCronJob, decisionFired, "", missedRun, job.Name, now)
+	r.childCreationSucceeded(req, &advancedCronJob)
 
 	/*
 		### 7: Requeue when we either see a running job or it's time for the next scheduled run
@@ -395,3 +487,24 @@ func (r *ReconcileAdvancedCronJob) reconcileBroadcastJob(ctx context.Context, re
 	// we'll requeue once we see the running job, and update our status
 	return scheduledResult, nil
 }
+
+// broadcastJobConcurrencyPolicy returns the concurrencyPolicy the runs of the AdvancedCronJob are started by, which
+// is Forbid instead of Allow if Allow is restricted in the cluster, see RestrictsBroadcastJobAllowConcurrent, e.g.
+// for the AdvancedCronJobs created before the restriction. The nodes are only listed if there are active runs.
+func (r *ReconcileAdvancedCronJob) broadcastJobConcurrencyPolicy(ctx context.Context, acj *appsv1beta1.AdvancedCronJob, activeJobs int) (appsv1beta1.ConcurrencyPolicy, error) {
+	policy := acj.Spec.ConcurrencyPolicy
+	if policy != appsv1beta1.AllowConcurrent || activeJobs == 0 ||
+		!utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobLimitBroadcastConcurrency) {
+		return policy, nil
+	}
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return policy, err
+	}
+	if !RestrictsBroadcastJobAllowConcurrent(len(nodes.Items), BroadcastJobAllowConcurrentMaxNodes) {
+		return policy, nil
+	}
+	r.recorder.Eventf(acj, corev1.EventTypeWarning, "ConcurrencyRestricted",
+		"concurrencyPolicy Allow is run as Forbid, since the BroadcastJobs run on all the %d nodes of the cluster", len(nodes.Items))
+	return appsv1beta1.ForbidConcurrent, nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+const (
+	// childCreationBackoffBase is the backoff after the first failure to create a child, doubled by each
+	// consecutive failure up to childCreationBackoffCap.
+	childCreationBackoffBase = 5 * time.Second
+	childCreationBackoffCap  = 5 * time.Minute
+)
+
+// childCreationBackoff is the backoff of an AdvancedCronJob failing to create its children.
+type childCreationBackoff struct {
+	// failures is the number of consecutive failures to create a child.
+	failures int
+	// generation is the generation of the AdvancedCronJob failing to create the child, a change of the spec may
+	// fix the failure, so it lifts the backoff.
+	generation int64
+	// notBefore is the time before which no child is created.
+	notBefore time.Time
+}
+
+// childCreationBackoffs is the backoff of each AdvancedCronJob failing to create its children, which holds off the
+// creation across the reconciliations triggered by the watches until it expires, not only the requeue.
+var childCreationBackoffs = struct {
+	sync.Mutex
+	backoffs map[types.NamespacedName]*childCreationBackoff
+}{backoffs: map[types.NamespacedName]*childCreationBackoff{}}
+
+// nextChildCreationBackoff records a failure to create a child of the AdvancedCronJob at the generation, and
+// returns the backoff before retrying from now.
+func nextChildCreationBackoff(key types.NamespacedName, generation int64, now time.Time) time.Duration {
+	childCreationBackoffs.Lock()
+	defer childCreationBackoffs.Unlock()
+	b, ok := childCreationBackoffs.backoffs[key]
+	if !ok {
+		b = &childCreationBackoff{}
+		childCreationBackoffs.backoffs[key] = b
+	}
+	b.failures++
+	backoff := childCreationBackoffBase
+	for i := 1; i < b.failures && backoff < childCreationBackoffCap; i++ {
+		backoff *= 2
+	}
+	if backoff > childCreationBackoffCap {
+		backoff = childCreationBackoffCap
+	}
+	b.generation, b.notBefore = generation, now.Add(backoff)
+	return backoff
+}
+
+// holdChildCreation holds off creating the children of the AdvancedCronJob at the generation for the backoff from
+// now, without counting a failure.
+func holdChildCreation(key types.NamespacedName, generation int64, now time.Time, backoff time.Duration) {
+	childCreationBackoffs.Lock()
+	defer childCreationBackoffs.Unlock()
+	b, ok := childCreationBackoffs.backoffs[key]
+	if !ok {
+		b = &childCreationBackoff{}
+		childCreationBackoffs.backoffs[key] = b
+	}
+	b.generation, b.notBefore = generation, now.Add(backoff)
+}
+
+// childCreationBackoffRemaining returns the remaining backoff of the AdvancedCronJob at the generation from now, or
+// zero if a child can be created.
+func childCreationBackoffRemaining(key types.NamespacedName, generation int64, now time.Time) time.Duration {
+	childCreationBackoffs.Lock()
+	defer childCreationBackoffs.Unlock()
+	b, ok := childCreationBackoffs.backoffs[key]
+	if !ok || b.generation != generation || !now.Before(b.notBefore) {
+		return 0
+	}
+	return b.notBefore.Sub(now)
+}
+
+// forgetChildCreationFailures resets the backoff of the AdvancedCronJob once a child is created or it is deleted.
+func forgetChildCreationFailures(key types.NamespacedName) {
+	childCreationBackoffs.Lock()
+	defer childCreationBackoffs.Unlock()
+	delete(childCreationBackoffs.backoffs, key)
+}
+
+// isChildCreationRejected reports whether the failure to create a child is a rejection of the child itself, e.g. it
+// is invalid, which retrying does not fix until the template is changed. A Forbidden failure is not, since an
+// exceeded quota may succeed once the resources are released, and the status does not tell it apart from a denial
+// of an admission webhook, which is backed off up to childCreationBackoffCap instead.
+func isChildCreationRejected(err error) bool {
+	switch errors.ReasonForError(err) {
+	case metav1.StatusReasonInvalid, metav1.StatusReasonBadRequest:
+		return true
+	}
+	return false
+}
+
+// childCreationFailed backs off the AdvancedCronJob after a failure to create a child, e.g. the quota is exceeded,
+// instead of returning the error to retry it at once, and reports the failure by the ChildCreationFailing condition.
+// The children are not created until the backoff expires, see childCreationBackoffRemaining, even if it is
+// reconciled earlier by a watch event, e.g. the update of the status here. The child rejected by the API server is
+// retried after childCreationBackoffCap, or at once if the spec is changed to fix it, and is reported with the
+// reason CreateRejected. An AlreadyExists error is not a failure, the callers skip it before calling this.
+func (r *ReconcileAdvancedCronJob) childCreationFailed(req ctrl.Request, acj *appsv1beta1.AdvancedCronJob, createErr error) (ctrl.Result, error) {
+	now := r.Now()
+	reason := "CreateFailed"
+	var backoff time.Duration
+	if isChildCreationRejected(createErr) {
+		reason, backoff = "CreateRejected", childCreationBackoffCap
+		holdChildCreation(req.NamespacedName, acj.Generation, now, backoff)
+	} else {
+		backoff = nextChildCreationBackoff(req.NamespacedName, acj.Generation, now)
+	}
+	setAdvancedCronJobCondition(&acj.Status, appsv1beta1.AdvancedCronJobCondition{
+		Type:    appsv1beta1.AdvancedCronJobConditionChildCreationFailing,
+		Status:  v1.ConditionTrue,
+		Reason:  reason,
+		Message: createErr.Error(),
+	}, now)
+	if err := r.updateAdvancedJobStatus(req, acj); err != nil {
+		klog.ErrorS(err, "Unable to update AdvancedCronJob status", "advancedCronJob", req)
+		return ctrl.Result{}, err
+	}
+	klog.InfoS("Backing off AdvancedCronJob after failing to create child", "advancedCronJob", req, "backoff", backoff)
+	return ctrl.Result{RequeueAfter: backoff}, nil
+}
+
+// childCreationSucceeded resets the backoff of the AdvancedCronJob after a child is created, and clears the
+// ChildCreationFailing condition if it is set.
+func (r *ReconcileAdvancedCronJob) childCreationSucceeded(req ctrl.Request, acj *appsv1beta1.AdvancedCronJob) {
+	forgetChildCreationFailures(req.NamespacedName)
+	condition := getAdvancedCronJobCondition(acj.Status, appsv1beta1.AdvancedCronJobConditionChildCreationFailing)
+	if condition == nil || condition.Status != v1.ConditionTrue {
+		return
+	}
+	setAdvancedCronJobCondition(&acj.Status, appsv1beta1.AdvancedCronJobCondition{
+		Type:   appsv1beta1.AdvancedCronJobConditionChildCreationFailing,
+		Status: v1.ConditionFalse,
+		Reason: "Created",
+	}, r.Now())
+	if err := r.updateAdvancedJobStatus(req, acj); err != nil {
+		klog.ErrorS(err, "Unable to update AdvancedCronJob status", "advancedCronJob", req)
+	}
+}
+
+func getAdvancedCronJobCondition(status appsv1beta1.AdvancedCronJobStatus, condType appsv1beta1.AdvancedCronJobConditionType) *appsv1beta1.AdvancedCronJobCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// setAdvancedCronJobCondition sets the condition in the status, transitioned at now, keeping its lastTransitionTime
+// if the status of the condition is not changed.
+func setAdvancedCronJobCondition(status *appsv1beta1.AdvancedCronJobStatus, condition appsv1beta1.AdvancedCronJobCondition, now time.Time) {
+	existing := getAdvancedCronJobCondition(*status, condition.Type)
+	if existing == nil {
+		condition.LastTransitionTime = metav1.NewTime(now)
+		status.Conditions = append(status.Conditions, condition)
+		return
+	}
+	if existing.Status != condition.Status {
+		existing.LastTransitionTime = metav1.NewTime(now)
+	}
+	existing.Status = condition.Status
+	existing.Reason = condition.Reason
+	existing.Message = condition.Message
+}
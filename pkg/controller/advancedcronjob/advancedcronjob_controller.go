@@ -20,8 +20,14 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -40,18 +46,26 @@ import (
 	"github.com/openkruise/kruise/pkg/util"
 	utilclient "github.com/openkruise/kruise/pkg/util/client"
 	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 )
 
 type IndexerFunc func(manager.Manager) error
 
 func init() {
 	flag.IntVar(&concurrentReconciles, "advancedcronjob-workers", concurrentReconciles, "Max concurrent workers for AdvancedCronJob controller.")
+	flag.IntVar(&missedSchedulesLookbackIntervals, "advancedcronjob-missed-schedules-lookback-intervals", missedSchedulesLookbackIntervals,
+		"How many schedule intervals to look back for missed runs of AdvancedCronJobs without startingDeadlineSeconds, 0 means no limit.")
+	flag.BoolVar(&enableTracing, "advancedcronjob-tracing", false, "If true, AdvancedCronJob controller exports OpenTelemetry spans of reconciling and creating the child jobs over OTLP gRPC, "+
+		"configured by the OTEL_EXPORTER_OTLP_* environment variables.")
+	flag.BoolVar(&enableDecisionLog, "advancedcronjob-decision-log", false, "If true, AdvancedCronJob controller writes a JSON line to stdout for every run it fires, suppresses or skips, with the reason, scheduled time and time zone.")
 }
 
 var (
 	concurrentReconciles = 3
-	jobOwnerKey          = ".metadata.controller"
-	controllerKind       = appsv1beta1.SchemeGroupVersion.WithKind("AdvancedCronJob")
+	// missedSchedulesLookbackIntervals bounds how far back missed runs are considered, in the intervals of the schedule.
+	missedSchedulesLookbackIntervals = 100
+	jobOwnerKey                      = ".metadata.controller"
+	controllerKind                   = appsv1beta1.SchemeGroupVersion.WithKind("AdvancedCronJob")
 )
 
 // Add creates a new AdvancedCronJob Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -69,6 +83,7 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 		Client:   utilclient.NewClientFromManager(mgr, "advancedcronjob-controller"),
 		scheme:   mgr.GetScheme(),
 		recorder: recorder,
+		tracer:   newTracer(),
 		Clock:    realClock{},
 	}
 }
@@ -120,6 +135,16 @@ type Clock interface {
 
 var (
 	scheduledTimeAnnotation = "apps.kruise.io/scheduled-at"
+
+	// localTZDataVersion returns the version of the time zone database the controller schedules with.
+	localTZDataVersion = webhookutil.TZDataVersion
+
+	// warnedTZDataVersions is the validated and local tzdata versions of the last mismatch warned of each
+	// AdvancedCronJob, so that a mismatch is warned only once no matter how many times it is reconciled.
+	warnedTZDataVersions = struct {
+		sync.Mutex
+		versions map[types.NamespacedName]string
+	}{versions: map[types.NamespacedName]string{}}
 )
 
 var _ reconcile.Reconciler = &ReconcileAdvancedCronJob{}
@@ -129,6 +154,7 @@ type ReconcileAdvancedCronJob struct {
 	client.Client
 	scheme   *runtime.Scheme
 	recorder record.EventRecorder
+	tracer   oteltrace.Tracer
 	Clock
 }
 
@@ -137,7 +163,11 @@ type ReconcileAdvancedCronJob struct {
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=advancedcronjobs/finalizers,verbs=update
 
 func (r *ReconcileAdvancedCronJob) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
+	ctx, span := r.getTracer().Start(context.Background(), "Reconcile", oteltrace.WithAttributes(
+		attribute.String("namespace", req.Namespace),
+		attribute.String("name", req.Name),
+	))
+	defer span.End()
 	klog.InfoS("Running AdvancedCronJob job", "advancedCronJob", req)
 
 	namespacedName := types.NamespacedName{
@@ -152,29 +182,140 @@ func (r *ReconcileAdvancedCronJob) Reconcile(_ context.Context, req ctrl.Request
 		// we'll ignore not-found errors, since they can't be fixed by an immediate
 		// requeue (we'll need to wait for a new notification), and we can get them
 		// on deleted requests.
+		if errors.IsNotFound(err) {
+			forgetSkippedRuns(req.NamespacedName)
+			forgetParsedSchedule(req.NamespacedName)
+			forgetChildCreationFailures(req.NamespacedName)
+			forgetTZDataVersionMismatch(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	switch FindTemplateKind(advancedCronJob.Spec) {
+	r.warnTZDataVersionMismatch(&advancedCronJob)
+
+	kind, err := ResolveTemplateKind(advancedCronJob.Spec)
+	if err != nil {
+		// the invalid template can not be fixed by a requeue, but by an update of the AdvancedCronJob
+		klog.ErrorS(err, "Invalid template of AdvancedCronJob", "advancedCronJob", req)
+		r.recorder.Event(&advancedCronJob, corev1.EventTypeWarning, "InvalidTemplate", err.Error())
+		return ctrl.Result{}, nil
+	}
+	span.SetAttributes(attribute.String("templateKind", string(kind)))
+	switch kind {
 	case appsv1beta1.JobTemplate:
 		return r.reconcileJob(ctx, req, advancedCronJob)
 	case appsv1beta1.BroadcastJobTemplate:
 		return r.reconcileBroadcastJob(ctx, req, advancedCronJob)
 	case appsv1beta1.ImageListPullJobTemplate:
 		return r.reconcileImageListPullJob(ctx, req, advancedCronJob)
-	default:
-		klog.InfoS("No template found", "advancedCronJob", req)
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// warnTZDataVersionMismatch warns if the AdvancedCronJob was validated with a different version of the time zone
+// database from the controller, whose fire times may differ in the time zones changed between the versions.
+// Each mismatch is warned only once.
+func (r *ReconcileAdvancedCronJob) warnTZDataVersionMismatch(acj *appsv1beta1.AdvancedCronJob) {
+	validated := acj.Annotations[appsv1beta1.AdvancedCronJobTZDataVersionAnnotation]
+	local := localTZDataVersion()
+	if validated == "" || local == "" || validated == local {
+		return
+	}
+	key, versions := client.ObjectKeyFromObject(acj), validated+"/"+local
+	warnedTZDataVersions.Lock()
+	warned := warnedTZDataVersions.versions[key] == versions
+	warnedTZDataVersions.versions[key] = versions
+	warnedTZDataVersions.Unlock()
+	if warned {
+		return
+	}
+	klog.V(2).InfoS("AdvancedCronJob was validated with a different tzdata version", "advancedCronJob", klog.KObj(acj), "validated", validated, "local", local)
+	r.recorder.Eventf(acj, corev1.EventTypeWarning, "TZDataVersionMismatch",
+		"validated with tzdata %s but scheduled with tzdata %s, the fire times may differ if the time zone has changed", validated, local)
+}
+
+// forgetTZDataVersionMismatch drops the warned tzdata version mismatch of a deleted AdvancedCronJob.
+func forgetTZDataVersionMismatch(key types.NamespacedName) {
+	warnedTZDataVersions.Lock()
+	defer warnedTZDataVersions.Unlock()
+	delete(warnedTZDataVersions.versions, key)
+}
+
+// skippedRunEvents are the reasons and messages of the Events of the skipped runs, by the reasons of the
+// skipped-runs metric.
+var skippedRunEvents = map[string]struct{ reason, message string }{
+	SkippedRunReasonPaused:   {reason: "RunSkippedPaused", message: "the AdvancedCronJob is paused"},
+	SkippedRunReasonExcluded: {reason: "RunSkippedExcluded", message: "the run is excluded by spec.scheduleExpression"},
+	SkippedRunReasonSkipDate: {reason: "RunSkippedSkipDate", message: "the run is on one of spec.skipDates"},
+}
+
+// reportSkippedRun counts the run suppressed with the reason by the skipped-runs metric, and emits an Event
+// with the reason the first time the run is suppressed, so that it is shown by kubectl describe.
+func (r *ReconcileAdvancedCronJob) reportSkippedRun(acj *appsv1beta1.AdvancedCronJob, scheduledTime time.Time, reason string) {
+	if !recordSkippedRun(client.ObjectKeyFromObject(acj), scheduledTime, reason) {
+		return
+	}
+	logSchedulingDecision(acj, decisionSuppressed, reason, scheduledTime, "", r.Now())
+	event := skippedRunEvents[reason]
+	r.recorder.Eventf(acj, corev1.EventTypeNormal, event.reason, "skipped the run scheduled at %s: %s",
+		scheduledTime.Format(time.RFC3339), event.message)
+}
+
+// reportPausedRun reports the last run of the paused AdvancedCronJob scheduled by now as skipped, see
+// reportSkippedRun, and returns the result requeuing it at the next run, so that each run skipped while it is
+// paused is reported.
+func (r *ReconcileAdvancedCronJob) reportPausedRun(acj *appsv1beta1.AdvancedCronJob) ctrl.Result {
+	sched, err := getCachedSchedule(acj)
+	if err != nil {
+		// the unparsable schedule is reported once the AdvancedCronJob is unpaused
+		return ctrl.Result{}
+	}
+	now := r.Now()
+	if lastRun := lastScheduledTime(acj, sched, now); !lastRun.IsZero() {
+		if suppressed, reason := IsSuppressed(acj, lastRun); suppressed {
+			r.reportSkippedRun(acj, lastRun, reason)
+		}
+	}
+	next := sched.Next(now)
+	if next.IsZero() {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}
+}
+
+// reportMissedDeadlineRuns counts the runs missing spec.startingDeadlineSeconds by the skipped-runs metric, see
+// recordMissedDeadlineRuns, and emits a single Event for the runs missed since the last reconciliation.
+func (r *ReconcileAdvancedCronJob) reportMissedDeadlineRuns(acj *appsv1beta1.AdvancedCronJob, sched cron.Schedule, earliestTime, schedulingDeadline time.Time) {
+	missed := recordMissedDeadlineRuns(client.ObjectKeyFromObject(acj), sched, earliestTime, schedulingDeadline)
+	if len(missed) == 0 {
+		return
+	}
+	now := r.Now()
+	for _, scheduledTime := range missed {
+		logSchedulingDecision(acj, decisionSkipped, SkippedRunReasonMissedDeadline, scheduledTime, "", now)
+	}
+	r.recorder.Eventf(acj, corev1.EventTypeWarning, "RunSkippedMissedDeadline",
+		"skipped %d run(s) scheduled from %s to %s: not started within spec.startingDeadlineSeconds",
+		len(missed), missed[0].Format(time.RFC3339), missed[len(missed)-1].Format(time.RFC3339))
+}
+
 func (r *ReconcileAdvancedCronJob) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&appsv1beta1.AdvancedCronJob{}).
 		Complete(r)
 }
 
+// childExists reports whether the child named for a scheduled time exists. The child may have been created
+// by a previous reconciliation of the same run which is not yet seen in the listed children.
+func (r *ReconcileAdvancedCronJob) childExists(ctx context.Context, child client.Object, namespace, name string) (bool, error) {
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, child)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
 func (r *ReconcileAdvancedCronJob) updateAdvancedJobStatus(request reconcile.Request, advancedCronJob *appsv1beta1.AdvancedCronJob) error {
 	klog.V(1).InfoS("Updating job status", "advancedCronJob", klog.KObj(advancedCronJob), "status", advancedCronJob.Status)
 	advancedCronJobCopy := advancedCronJob.DeepCopy()
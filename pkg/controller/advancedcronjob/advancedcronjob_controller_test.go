@@ -17,16 +17,26 @@ limitations under the License.
 package advancedcronjob
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/robfig/cron/v3"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -37,9 +47,12 @@ import (
 	utilpointer "k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/fieldindex"
 )
 
@@ -110,6 +123,211 @@ func TestScheduleWithTimeZone(t *testing.T) {
 	}
 }
 
+func TestParseStandardSchedule(t *testing.T) {
+	cases := []struct {
+		schedule  string
+		timeZone  *string
+		expectErr bool
+	}{
+		{schedule: "0 10 * * *"},
+		{schedule: "0 10 * * *", timeZone: utilpointer.String("Asia/Shanghai")},
+		{schedule: "30 2 * mar sun", timeZone: utilpointer.String("America/New_York")},
+		{schedule: "@daily", timeZone: utilpointer.String("Asia/Shanghai")},
+		{schedule: "@every 90m", timeZone: utilpointer.String("Asia/Shanghai")},
+		{schedule: "TZ=Asia/Shanghai 0 10 * * *"},
+		{schedule: "CRON_TZ=Europe/London 0 10 * * *", timeZone: utilpointer.String("Asia/Shanghai")},
+		{schedule: "TZ=Invalid/Zone 0 10 * * *", expectErr: true},
+		{schedule: "0 25 * * *", timeZone: utilpointer.String("Asia/Shanghai"), expectErr: true},
+	}
+	from := time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)
+	for _, tc := range cases {
+		t.Run(tc.schedule, func(t *testing.T) {
+			acj := &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule, TimeZone: tc.timeZone}}
+			sched, err := parseStandardSchedule(acj)
+			expected, expectedErr := cron.ParseStandard(formatSchedule(acj))
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Error(t, expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, expectedErr)
+			for i, next, expectedNext := 0, from, from; i < 3; i++ {
+				next, expectedNext = sched.Next(next), expected.Next(expectedNext)
+				assert.True(t, expectedNext.Equal(next), "expected %s, got %s", expectedNext, next)
+			}
+		})
+	}
+}
+
+func TestResolveSchedule(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	london, _ := time.LoadLocation("Europe/London")
+	cases := []struct {
+		name      string
+		spec      appsv1beta1.AdvancedCronJobSpec
+		expected  ResolvedSchedule
+		expectErr bool
+	}{
+		{
+			name:     "cron in time zone",
+			spec:     appsv1beta1.AdvancedCronJobSpec{Schedule: "0 10 * mar mon", TimeZone: utilpointer.String("Asia/Shanghai")},
+			expected: ResolvedSchedule{Schedule: "0 10 * MAR MON", Location: shanghai, Format: ScheduleFormatCron},
+		},
+		{
+			name:     "embedded time zone",
+			spec:     appsv1beta1.AdvancedCronJobSpec{Schedule: "TZ=Europe/London @daily"},
+			expected: ResolvedSchedule{Schedule: "@daily", Location: london, Format: ScheduleFormatDescriptor},
+		},
+		{
+			name:     "interval",
+			spec:     appsv1beta1.AdvancedCronJobSpec{Schedule: "@every 90m", TimeZone: utilpointer.String("UTC")},
+			expected: ResolvedSchedule{Schedule: "@every 90m", Location: time.UTC, Format: ScheduleFormatInterval, Interval: true},
+		},
+		{
+			name:     "expression",
+			spec:     appsv1beta1.AdvancedCronJobSpec{ScheduleExpression: utilpointer.String("CRON_TZ=Asia/Shanghai 0 9 * * mon-fri except lastday")},
+			expected: ResolvedSchedule{Schedule: "0 9 * * MON-FRI", Location: shanghai, Format: ScheduleFormatExpression},
+		},
+		{
+			name:     "run at",
+			spec:     appsv1beta1.AdvancedCronJobSpec{RunAt: &metav1.Time{Time: time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)}},
+			expected: ResolvedSchedule{Location: time.Local, Format: ScheduleFormatRunAt},
+		},
+		{
+			name:      "invalid time zone",
+			spec:      appsv1beta1.AdvancedCronJobSpec{Schedule: "0 10 * * *", TimeZone: utilpointer.String("Invalid/Zone")},
+			expectErr: true,
+		},
+		{
+			name:      "invalid day-of-week name",
+			spec:      appsv1beta1.AdvancedCronJobSpec{Schedule: "0 10 * * Funday", TimeZone: utilpointer.String("UTC")},
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved, err := ResolveSchedule(&appsv1beta1.AdvancedCronJob{Spec: tc.spec})
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected.Schedule, resolved.Schedule)
+			assert.Equal(t, tc.expected.Location.String(), resolved.Location.String())
+			assert.Equal(t, tc.expected.Format, resolved.Format)
+			assert.Equal(t, tc.expected.Interval, resolved.Interval)
+		})
+	}
+}
+
+func BenchmarkGetSchedule(b *testing.B) {
+	timeZones := []string{"Asia/Shanghai", "America/New_York", "Europe/London", "UTC"}
+	acjs := make([]*appsv1beta1.AdvancedCronJob, 5000)
+	for i := range acjs {
+		acjs[i] = &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{
+			Schedule: fmt.Sprintf("%d * * * *", i%60),
+			TimeZone: utilpointer.String(timeZones[i%len(timeZones)]),
+		}}
+	}
+	now := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, acj := range acjs {
+			sched, err := getSchedule(acj)
+			if err != nil {
+				b.Fatal(err)
+			}
+			sched.Next(now)
+		}
+	}
+}
+
+func TestNormalizeSchedule(t *testing.T) {
+	cases := []struct {
+		schedule  string
+		expected  string
+		expectErr bool
+	}{
+		{schedule: "0 9 * * mon-Fri", expected: "0 9 * * MON-FRI"},
+		{schedule: "0 9 * jan,Feb Sun", expected: "0 9 * JAN,FEB SUN"},
+		{schedule: "TZ=Asia/Shanghai 0 9 * * sun", expected: "TZ=Asia/Shanghai 0 9 * * SUN"},
+		{schedule: "0 9 * * 1-5", expected: "0 9 * * 1-5"},
+		{schedule: "@daily", expected: "@daily"},
+		{schedule: "0 9 * * Funday", expectErr: true},
+		{schedule: "0 9 * foo *", expectErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := NormalizeSchedule(tc.schedule)
+		if tc.expectErr {
+			assert.Error(t, err, tc.schedule)
+			continue
+		}
+		assert.NoError(t, err, tc.schedule)
+		assert.Equal(t, tc.expected, got)
+		_, err = cron.ParseStandard(got)
+		assert.NoError(t, err, tc.schedule)
+	}
+}
+
+func TestValidateSchedules(t *testing.T) {
+	now := time.Date(2025, 10, 10, 9, 30, 0, 0, time.UTC)
+	utc := utilpointer.String("UTC")
+	cases := []struct {
+		schedule         string
+		timeZone         *string
+		expectValid      bool
+		expectedNext     time.Time
+		expectedInterval time.Duration
+	}{
+		{
+			schedule:         "*/15 * * * *",
+			timeZone:         utc,
+			expectValid:      true,
+			expectedNext:     time.Date(2025, 10, 10, 9, 45, 0, 0, time.UTC),
+			expectedInterval: 15 * time.Minute,
+		},
+		{
+			schedule:         "0 9 * * mon-fri",
+			timeZone:         utc,
+			expectValid:      true,
+			expectedNext:     time.Date(2025, 10, 13, 9, 0, 0, 0, time.UTC),
+			expectedInterval: 24 * time.Hour,
+		},
+		{schedule: "", timeZone: utc},
+		{schedule: "0 9 * * Funday", timeZone: utc},
+		{schedule: "61 * * * *", timeZone: utc},
+		{schedule: "0 0 30 2 *", timeZone: utc},
+		{schedule: "0 * * * *", timeZone: utilpointer.String("Mars/Olympus_Mons")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.schedule, func(t *testing.T) {
+			diagnostics := DiagnoseSchedule(tc.schedule, tc.timeZone, now)
+			assert.Equal(t, tc.schedule, diagnostics.Schedule)
+			assert.Equal(t, tc.expectValid, diagnostics.Valid, diagnostics.Errors)
+			if !tc.expectValid {
+				assert.NotEmpty(t, diagnostics.Errors)
+				return
+			}
+			assert.Empty(t, diagnostics.Errors)
+			assert.True(t, tc.expectedNext.Equal(diagnostics.NextFireTime), diagnostics.NextFireTime)
+			assert.Equal(t, tc.expectedInterval, diagnostics.Interval)
+		})
+	}
+
+	diagnostics := validateSchedules([]string{"0 * * * *", "invalid", "@daily"}, utc, now)
+	assert.Len(t, diagnostics, 3)
+	assert.True(t, diagnostics[0].Valid)
+	assert.True(t, time.Date(2025, 10, 10, 10, 0, 0, 0, time.UTC).Equal(diagnostics[0].NextFireTime), diagnostics[0].NextFireTime)
+	assert.False(t, diagnostics[1].Valid)
+	assert.True(t, diagnostics[2].Valid)
+	assert.True(t, time.Date(2025, 10, 11, 0, 0, 0, 0, time.UTC).Equal(diagnostics[2].NextFireTime), diagnostics[2].NextFireTime)
+	assert.Len(t, ValidateSchedules([]string{"0 * * * *"}, utc), 1)
+}
+
 // Test scenario:
 func TestReconcileAdvancedJobCreateBroadcastJob(t *testing.T) {
 	scheme := runtime.NewScheme()
@@ -203,6 +421,7 @@ func createReconcileJobWithBroadcastJobIndex(scheme *runtime.Scheme, initObjs ..
 		Client:   fakeClient,
 		scheme:   scheme,
 		recorder: recorder,
+		Clock:    realClock{},
 	}
 	return reconcileJob
 }
@@ -224,6 +443,7 @@ func createReconcileJobWithBatchJobIndex(scheme *runtime.Scheme, initObjs ...cli
 		Client:   fakeClient,
 		scheme:   scheme,
 		recorder: recorder,
+		Clock:    realClock{},
 	}
 	return reconcileJob
 }
@@ -459,3 +679,1777 @@ func createReconcileJobWithImageListPullJobIndex(scheme *runtime.Scheme, initObj
 	}
 	return reconcileJob
 }
+
+func TestCountConsecutiveFailures(t *testing.T) {
+	base := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	run := func(minutes int, failed bool) childRun {
+		return childRun{scheduledTime: base.Add(time.Duration(minutes) * time.Minute), failed: failed}
+	}
+	active := func(minutes int) childRun {
+		return childRun{scheduledTime: base.Add(time.Duration(minutes) * time.Minute), active: true}
+	}
+	at := func(minutes int) *metav1.Time {
+		return &metav1.Time{Time: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	cases := []struct {
+		name                string
+		runs                []childRun
+		previous            int32
+		lastCounted         *metav1.Time
+		expected            int32
+		expectedLastCounted *metav1.Time
+	}{
+		{
+			name:     "no finished runs",
+			expected: 0,
+		},
+		{
+			name:                "latest run succeeded",
+			runs:                []childRun{run(0, true), run(5, true), run(10, false)},
+			expected:            0,
+			expectedLastCounted: at(10),
+		},
+		{
+			name:                "failures after a success",
+			runs:                []childRun{run(0, false), run(5, true), run(10, true)},
+			expected:            2,
+			expectedLastCounted: at(10),
+		},
+		{
+			name:                "unsorted mixed sequence",
+			runs:                []childRun{run(15, true), run(0, true), run(10, false), run(5, true), run(20, true)},
+			expected:            2,
+			expectedLastCounted: at(20),
+		},
+		{
+			name:                "counted runs are not counted again",
+			runs:                []childRun{run(0, false), run(5, true), run(10, true)},
+			previous:            2,
+			lastCounted:         at(10),
+			expected:            2,
+			expectedLastCounted: at(10),
+		},
+		{
+			name:                "failure after the cleaned up runs",
+			runs:                []childRun{run(-10, false), run(10, true)},
+			previous:            2,
+			lastCounted:         at(5),
+			expected:            3,
+			expectedLastCounted: at(10),
+		},
+		{
+			name:                "success resets the count",
+			runs:                []childRun{run(0, true), run(10, false)},
+			previous:            5,
+			lastCounted:         at(5),
+			expected:            0,
+			expectedLastCounted: at(10),
+		},
+		{
+			name:                "runs after an active run are held",
+			runs:                []childRun{run(0, false), active(5), run(10, true)},
+			expected:            0,
+			expectedLastCounted: at(0),
+		},
+		{
+			name:                "held runs counted once the earlier run finished",
+			runs:                []childRun{run(0, false), run(5, true), run(10, true)},
+			lastCounted:         at(0),
+			expected:            2,
+			expectedLastCounted: at(10),
+		},
+		{
+			name:                "count kept when no run finished since",
+			previous:            3,
+			lastCounted:         at(5),
+			expected:            3,
+			expectedLastCounted: at(5),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			failures, lastCounted := countConsecutiveFailures(tc.runs, tc.previous, tc.lastCounted)
+			assert.Equal(t, tc.expected, failures)
+			assert.Equal(t, tc.expectedLastCounted, lastCounted)
+		})
+	}
+}
+
+func TestReconcileAdvancedJobConsecutiveFailures(t *testing.T) {
+	cases := []struct {
+		name     string
+		children func(parent *appsv1beta1.AdvancedCronJob) []client.Object
+		expected int32
+	}{
+		{
+			name: "failures after the last success",
+			children: func(parent *appsv1beta1.AdvancedCronJob) []client.Object {
+				return []client.Object{
+					createImageListPullJob(-15, 2, 2, 2, parent),
+					createImageListPullJob(-10, 2, 1, 2, parent),
+					createImageListPullJob(-5, 2, 0, 2, parent),
+				}
+			},
+			expected: 2,
+		},
+		{
+			name: "last run succeeded",
+			children: func(parent *appsv1beta1.AdvancedCronJob) []client.Object {
+				return []client.Object{
+					createImageListPullJob(-15, 2, 1, 2, parent),
+					createImageListPullJob(-10, 2, 1, 2, parent),
+					createImageListPullJob(-5, 2, 2, 2, parent),
+				}
+			},
+			expected: 0,
+		},
+		{
+			name: "active run is not counted",
+			children: func(parent *appsv1beta1.AdvancedCronJob) []client.Object {
+				return []client.Object{
+					createImageListPullJob(-10, 2, 2, 2, parent),
+					createImageListPullJob(-5, 2, 1, 2, parent),
+					createImageListPullJob(0, 2, 0, 1, parent),
+				}
+			},
+			expected: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+			utilruntime.Must(v1.AddToScheme(scheme))
+
+			job1 := createJob("job1", imageListPullJobTemplate())
+			initObjs := append([]client.Object{job1}, tc.children(job1)...)
+			reconcileJob := createReconcileJobWithImageListPullJobIndex(scheme, initObjs...)
+
+			request := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "job1",
+					Namespace: "default",
+				},
+			}
+			_, err := reconcileJob.Reconcile(context.TODO(), request)
+			assert.NoError(t, err)
+
+			retrievedJob := &appsv1beta1.AdvancedCronJob{}
+			err = reconcileJob.Get(context.TODO(), request.NamespacedName, retrievedJob)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, retrievedJob.Status.ConsecutiveFailures)
+		})
+	}
+}
+
+func TestReconcileAdvancedJobConsecutiveFailuresAfterCleanup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	job1 := createJob("job1", imageListPullJobTemplate())
+	job1.Spec.FailedJobsHistoryLimit = utilpointer.Int32(1)
+	reconcileJob := createReconcileJobWithImageListPullJobIndex(scheme, job1, createImageListPullJob(-30, 2, 2, 2, job1))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "job1", Namespace: "default"}}
+	reconcileWith := func(child *appsv1beta1.ImageListPullJob) *appsv1beta1.AdvancedCronJob {
+		if child != nil {
+			assert.NoError(t, reconcileJob.Create(context.TODO(), child))
+		}
+		_, err := reconcileJob.Reconcile(context.TODO(), request)
+		assert.NoError(t, err)
+		retrievedJob := &appsv1beta1.AdvancedCronJob{}
+		assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrievedJob))
+		return retrievedJob
+	}
+
+	assert.Equal(t, int32(0), reconcileWith(nil).Status.ConsecutiveFailures)
+	// the failed runs older than the history limit are cleaned up, but stay counted
+	for i, timeDiff := range []int{-25, -20, -15} {
+		assert.Equal(t, int32(i+1), reconcileWith(createImageListPullJob(timeDiff, 2, 1, 2, job1)).Status.ConsecutiveFailures)
+	}
+	ilpJobs := &appsv1beta1.ImageListPullJobList{}
+	assert.NoError(t, reconcileJob.List(context.TODO(), ilpJobs, client.InNamespace("default")))
+	var failed int
+	for i := range ilpJobs.Items {
+		if status := ilpJobs.Items[i].Status; status.CompletionTime != nil && status.Succeeded != status.Desired {
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+
+	assert.Equal(t, int32(0), reconcileWith(createImageListPullJob(-10, 2, 2, 2, job1)).Status.ConsecutiveFailures)
+}
+
+func TestReconcileAdvancedJobConsecutiveFailuresOutOfOrder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	job1 := createJob("job1", imageListPullJobTemplate())
+	job1.Spec.ConcurrencyPolicy = appsv1beta1.AllowConcurrent
+	reconcileJob := createReconcileJobWithImageListPullJobIndex(scheme, job1,
+		createImageListPullJob(-15, 2, 2, 2, job1), createImageListPullJob(-10, 2, 0, 1, job1))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "job1", Namespace: "default"}}
+	reconcileWith := func(child *appsv1beta1.ImageListPullJob) *appsv1beta1.AdvancedCronJob {
+		if child != nil {
+			assert.NoError(t, reconcileJob.Create(context.TODO(), child))
+		}
+		_, err := reconcileJob.Reconcile(context.TODO(), request)
+		assert.NoError(t, err)
+		retrievedJob := &appsv1beta1.AdvancedCronJob{}
+		assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrievedJob))
+		return retrievedJob
+	}
+
+	assert.Equal(t, int32(0), reconcileWith(nil).Status.ConsecutiveFailures)
+	// the later run fails while the earlier one is still active
+	assert.Equal(t, int32(0), reconcileWith(createImageListPullJob(-5, 2, 1, 2, job1)).Status.ConsecutiveFailures)
+
+	// the earlier run fails after the later one
+	earlier := &appsv1beta1.ImageListPullJob{}
+	assert.NoError(t, reconcileJob.Get(context.TODO(), types.NamespacedName{Name: "job1-t-10", Namespace: "default"}, earlier))
+	earlier.Status.Completed = 2
+	earlier.Status.CompletionTime = earlier.Status.StartTime
+	assert.NoError(t, reconcileJob.Update(context.TODO(), earlier))
+	assert.Equal(t, int32(2), reconcileWith(nil).Status.ConsecutiveFailures)
+}
+
+func TestReconcileAdvancedJobChildReferences(t *testing.T) {
+	cases := []struct {
+		name                    string
+		children                func(parent *appsv1beta1.AdvancedCronJob) []client.Object
+		expectedActive          []string
+		expectedLastRunChildren []string
+	}{
+		{
+			name: "no children",
+			children: func(parent *appsv1beta1.AdvancedCronJob) []client.Object {
+				return nil
+			},
+		},
+		{
+			name: "last run finished",
+			children: func(parent *appsv1beta1.AdvancedCronJob) []client.Object {
+				return []client.Object{
+					createImageListPullJob(-10, 2, 2, 2, parent),
+					createImageListPullJob(-5, 2, 1, 2, parent),
+				}
+			},
+			expectedLastRunChildren: []string{"job1-t-5"},
+		},
+		{
+			name: "last run active",
+			children: func(parent *appsv1beta1.AdvancedCronJob) []client.Object {
+				return []client.Object{
+					createImageListPullJob(-5, 2, 2, 2, parent),
+					createImageListPullJob(0, 2, 0, 1, parent),
+				}
+			},
+			expectedActive:          []string{"job1-t0"},
+			expectedLastRunChildren: []string{"job1-t0"},
+		},
+	}
+
+	names := func(refs []v1.ObjectReference) []string {
+		var result []string
+		for _, objRef := range refs {
+			assert.Equal(t, "ImageListPullJob", objRef.Kind)
+			result = append(result, objRef.Name)
+		}
+		return result
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+			utilruntime.Must(v1.AddToScheme(scheme))
+
+			job1 := createJob("job1", imageListPullJobTemplate())
+			initObjs := append([]client.Object{job1}, tc.children(job1)...)
+			reconcileJob := createReconcileJobWithImageListPullJobIndex(scheme, initObjs...)
+
+			request := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "job1",
+					Namespace: "default",
+				},
+			}
+			_, err := reconcileJob.Reconcile(context.TODO(), request)
+			assert.NoError(t, err)
+
+			retrievedJob := &appsv1beta1.AdvancedCronJob{}
+			err = reconcileJob.Get(context.TODO(), request.NamespacedName, retrievedJob)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActive, names(retrievedJob.Status.Active))
+			assert.Equal(t, tc.expectedLastRunChildren, names(retrievedJob.Status.LastRunChildren))
+		})
+	}
+}
+
+func TestSchedulesEquivalent(t *testing.T) {
+	cases := []struct {
+		a, b      string
+		tz        *string
+		expected  bool
+		expectErr bool
+	}{
+		{a: "0 0 * * *", b: "0 0 * * *", expected: true},
+		{a: "0 0 * * *", b: "@daily", expected: true},
+		{a: "0 * * * *", b: "@hourly", expected: true},
+		{a: "0 9 * * 1-5", b: "0 9 * * MON-FRI", expected: true},
+		{a: "0 9 * * 1-5", b: "0 9 * * mon-fri", tz: utilpointer.String("Asia/Shanghai"), expected: true},
+		{a: "*/15 * * * *", b: "0,15,30,45 * * * *", expected: true},
+		{a: "0 0 * * *", b: "0 1 * * *", expected: false},
+		{a: "0 0 * * *", b: "TZ=Asia/Shanghai 0 0 * * *", tz: utilpointer.String("UTC"), expected: false},
+		{a: "0 0 * * *", b: "invalid", expectErr: true},
+		{a: "0 0 * * *", b: "@daily", tz: utilpointer.String("broken"), expectErr: true},
+	}
+
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range cases {
+		equivalent, err := schedulesEquivalent(tc.a, tc.b, tc.tz, from)
+		if tc.expectErr {
+			assert.Error(t, err, "%s vs %s", tc.a, tc.b)
+			continue
+		}
+		assert.NoError(t, err, "%s vs %s", tc.a, tc.b)
+		assert.Equal(t, tc.expected, equivalent, "%s vs %s", tc.a, tc.b)
+	}
+
+	equivalent, err := SchedulesEquivalent("0 0 * * *", "@daily", nil)
+	assert.NoError(t, err)
+	assert.True(t, equivalent)
+}
+
+func TestScheduleChanged(t *testing.T) {
+	newObj := func(schedule string, timeZone *string) *appsv1beta1.AdvancedCronJob {
+		return &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{Schedule: schedule, TimeZone: timeZone}}
+	}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, ScheduleChanged(newObj("0 0 * * *", nil), newObj("0 0 * * *", nil), now))
+	assert.False(t, ScheduleChanged(newObj("@daily", nil), newObj("0 0 * * *", nil), now))
+	assert.False(t, ScheduleChanged(newObj("0 0 * * mon", nil), newObj("0 0 * * 1", nil), now))
+	assert.True(t, ScheduleChanged(newObj("0 1 * * *", nil), newObj("0 0 * * *", nil), now))
+	assert.True(t, ScheduleChanged(newObj("@daily", utilpointer.String("Asia/Shanghai")), newObj("0 0 * * *", nil), now))
+	assert.True(t, ScheduleChanged(newObj("0 0 * * *", nil), newObj("invalid", nil), now))
+}
+
+func TestPreviewTable(t *testing.T) {
+	now := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	acj := &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{
+		Schedule:  "0 9 * * *",
+		TimeZone:  utilpointer.String("Asia/Shanghai"),
+		SkipDates: []string{"2025-10-11"},
+	}}
+	table, err := previewTable(acj, now, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "#  TIME (Asia/Shanghai)      TIME (UTC)                SKIPPED\n"+
+		"1  Fri 2025-10-10 09:00 CST  Fri 2025-10-10 01:00 UTC  \n"+
+		"2  Sat 2025-10-11 09:00 CST  Sat 2025-10-11 01:00 UTC  skip_date\n"+
+		"3  Sun 2025-10-12 09:00 CST  Sun 2025-10-12 01:00 UTC  \n", table)
+
+	// a one-time AdvancedCronJob fires only once
+	runAt := metav1.NewTime(now.Add(time.Hour))
+	table, err = previewTable(&appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{RunAt: &runAt}}, now, 3)
+	assert.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(table), "\n"), 2)
+
+	_, err = previewTable(&appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{Schedule: "0 25 * * *"}}, now, 3)
+	assert.Error(t, err)
+}
+
+func TestFiresWithin(t *testing.T) {
+	from := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name         string
+		schedule     string
+		timeZone     *string
+		window       time.Duration
+		expectFires  bool
+		expectedTime time.Time
+	}{
+		{
+			name:         "fires at from",
+			schedule:     "0 9 * * *",
+			window:       time.Minute,
+			expectFires:  true,
+			expectedTime: from,
+		},
+		{
+			name:         "fires at the end of the window",
+			schedule:     "30 9 * * *",
+			window:       30 * time.Minute,
+			expectFires:  true,
+			expectedTime: from.Add(30 * time.Minute),
+		},
+		{
+			name:     "fires after the window",
+			schedule: "31 9 * * *",
+			window:   30 * time.Minute,
+		},
+		{
+			name:         "fires in the time zone",
+			schedule:     "0 17 * * *",
+			timeZone:     utilpointer.String("Asia/Shanghai"),
+			window:       time.Minute,
+			expectFires:  true,
+			expectedTime: from,
+		},
+		{
+			name:     "invalid schedule",
+			schedule: "0 24 * * *",
+			window:   24 * time.Hour,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fires, next := FiresWithin(tc.schedule, tc.timeZone, from, tc.window)
+			assert.Equal(t, tc.expectFires, fires)
+			assert.True(t, tc.expectedTime.Equal(next), "expected %v, got %v", tc.expectedTime, next)
+		})
+	}
+}
+
+// backwardSchedule fires at the next of its fire times after t, except that it fires back at the time
+// mapped by repeats, like a clock set back.
+type backwardSchedule struct {
+	fireTimes []time.Time
+	repeats   map[time.Time]time.Time
+}
+
+func (s backwardSchedule) Next(t time.Time) time.Time {
+	if repeat, ok := s.repeats[t]; ok {
+		return repeat
+	}
+	for _, fireTime := range s.fireTimes {
+		if fireTime.After(t) {
+			return fireTime
+		}
+	}
+	return time.Time{}
+}
+
+func TestScheduleInterval(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	// the DST of America/New_York falls back at 2025-11-02 02:00 EDT to 01:00 EST
+	fallBack := time.Date(2025, 11, 2, 6, 0, 0, 0, time.UTC)
+
+	daily, err := cron.ParseStandard("CRON_TZ=America/New_York 30 1 * * *")
+	assert.NoError(t, err)
+	// 01:30 repeats in the fall-back, so the daily schedule fires twice an hour apart
+	assert.Equal(t, time.Hour, ScheduleInterval(daily, time.Date(2025, 11, 1, 12, 0, 0, 0, newYork)))
+	assert.Equal(t, 24*time.Hour, ScheduleInterval(daily, fallBack))
+
+	base := time.Date(2025, 11, 2, 5, 0, 0, 0, time.UTC)
+	backward := backwardSchedule{
+		fireTimes: []time.Time{base, base.Add(time.Hour), base.Add(3 * time.Hour)},
+		repeats:   map[time.Time]time.Time{base: base.Add(-time.Hour), base.Add(-time.Hour): base.Add(time.Hour)},
+	}
+	// the interval is measured on the following pair of fire times instead of the backward one
+	assert.Equal(t, 2*time.Hour, ScheduleInterval(backward, base.Add(-2*time.Hour)))
+
+	once := backwardSchedule{fireTimes: []time.Time{base}}
+	assert.Equal(t, time.Duration(0), ScheduleInterval(once, base.Add(-time.Hour)))
+}
+
+func TestPeakFiresPerHour(t *testing.T) {
+	from := time.Date(2025, 10, 10, 9, 30, 0, 0, time.UTC)
+	cases := []struct {
+		schedules []string
+		expected  int
+	}{
+		{schedules: []string{"0 0 * * *"}, expected: 1},
+		{schedules: []string{"* 9 * * *"}, expected: 60},
+		{schedules: []string{"*/15 * * * *", "*/20 * * * *"}, expected: 6},
+		// the same fire time is counted once
+		{schedules: []string{"*/15 * * * *", "0,15,30,45 * * * *"}, expected: 4},
+		// fires more than once a minute
+		{schedules: []string{"@every 10s"}, expected: 360},
+	}
+
+	for _, tc := range cases {
+		var schedules []cron.Schedule
+		for _, schedule := range tc.schedules {
+			sched, err := cron.ParseStandard(schedule)
+			assert.NoError(t, err, schedule)
+			schedules = append(schedules, sched)
+		}
+		assert.Equal(t, tc.expected, PeakFiresPerHour(schedules, from), "%v", tc.schedules)
+	}
+}
+
+func TestFiresPerDay(t *testing.T) {
+	from := time.Date(2025, 10, 10, 9, 30, 0, 0, time.UTC)
+	cases := []struct {
+		schedules []string
+		expected  int
+	}{
+		{schedules: []string{"0 0 * * *"}, expected: 1},
+		{schedules: []string{"* * * * *"}, expected: 24 * 60},
+		{schedules: []string{"0 * * * *", "30 * * * *"}, expected: 48},
+		// the same fire time is counted once
+		{schedules: []string{"0 */2 * * *", "0 */3 * * *"}, expected: 16},
+		// 2025-10-11 is a Saturday, the 24 hours from now cover only the run at 10:00 on 2025-10-10
+		{schedules: []string{"0 9,10 * * MON-FRI"}, expected: 1},
+		// fires more than once a minute
+		{schedules: []string{"@every 10s"}, expected: 24 * 60 * 6},
+	}
+
+	for _, tc := range cases {
+		var schedules []cron.Schedule
+		for _, schedule := range tc.schedules {
+			sched, err := cron.ParseStandard(schedule)
+			assert.NoError(t, err, schedule)
+			schedules = append(schedules, sched)
+		}
+		assert.Equal(t, tc.expected, FiresPerDay(schedules, from), "%v", tc.schedules)
+	}
+}
+
+func TestFireTimes(t *testing.T) {
+	from := time.Date(2025, 10, 10, 9, 30, 0, 0, time.UTC)
+	var schedules []cron.Schedule
+	for _, schedule := range []string{"0 12 * * *", "0 */6 * * *"} {
+		sched, err := cron.ParseStandard(schedule)
+		assert.NoError(t, err, schedule)
+		schedules = append(schedules, sched)
+	}
+
+	// the time fired by both schedules is returned once, and the end is inclusive
+	expected := []time.Time{
+		time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC),
+		time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC),
+		time.Date(2025, 10, 11, 0, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, expected, FireTimes(schedules, from, time.Date(2025, 10, 11, 0, 0, 0, 0, time.UTC)))
+	assert.Empty(t, FireTimes(schedules, from, from.Add(time.Hour)))
+}
+
+func TestMinFireInterval(t *testing.T) {
+	from := time.Date(2025, 10, 10, 9, 30, 0, 0, time.UTC)
+	cases := []struct {
+		schedules []string
+		expected  time.Duration
+	}{
+		{schedules: []string{"0 0 * * *"}, expected: 24 * time.Hour},
+		{schedules: []string{"*/15 * * * *"}, expected: 15 * time.Minute},
+		{schedules: []string{"0 * * * *", "5 * * * *"}, expected: 5 * time.Minute},
+		// fires once in the window
+		{schedules: []string{"0 0 1 1 *"}, expected: 0},
+	}
+
+	for _, tc := range cases {
+		var schedules []cron.Schedule
+		for _, schedule := range tc.schedules {
+			sched, err := cron.ParseStandard(schedule)
+			assert.NoError(t, err, schedule)
+			schedules = append(schedules, sched)
+		}
+		assert.Equal(t, tc.expected, MinFireInterval(schedules, from), "%v", tc.schedules)
+	}
+}
+
+func TestParseScheduleExpression(t *testing.T) {
+	cases := []struct {
+		expression         string
+		expectedSchedule   string
+		expectedExclusions []string
+		expectErr          bool
+	}{
+		{expression: "0 9 * * mon-fri", expectedSchedule: "0 9 * * MON-FRI"},
+		{expression: "0 9 * * MON-FRI except last fri", expectedSchedule: "0 9 * * MON-FRI", expectedExclusions: []string{"last FRI"}},
+		{expression: "0 9 * * * EXCEPT first MON or day 15 or lastday or date 2025-12-25", expectedSchedule: "0 9 * * *",
+			expectedExclusions: []string{"first MON", "day 15", "lastday", "date 2025-12-25"}},
+		{expression: "TZ=Asia/Shanghai 0 9 * * * except day 1", expectedSchedule: "TZ=Asia/Shanghai 0 9 * * *", expectedExclusions: []string{"day 1"}},
+		{expression: "0 9 * * FUN except last FRI", expectErr: true},
+		{expression: "0 9 * * except last FRI", expectErr: true},
+		{expression: "0 9 * * * except last XYZ", expectErr: true},
+		{expression: "0 9 * * * except day 32", expectErr: true},
+		{expression: "0 9 * * * except date 2025-13-01", expectErr: true},
+		{expression: "0 9 * * * except holidays", expectErr: true},
+	}
+
+	for _, tc := range cases {
+		expr, err := ParseScheduleExpression(tc.expression)
+		if tc.expectErr {
+			assert.Error(t, err, tc.expression)
+			continue
+		}
+		assert.NoError(t, err, tc.expression)
+		assert.Equal(t, tc.expectedSchedule, expr.Schedule, tc.expression)
+		var exclusions []string
+		for _, predicate := range expr.Exclusions {
+			exclusions = append(exclusions, predicate.String())
+		}
+		assert.Equal(t, tc.expectedExclusions, exclusions, tc.expression)
+	}
+}
+
+func TestScheduleExpressionNext(t *testing.T) {
+	cases := []struct {
+		expression string
+		timeZone   *string
+		from       time.Time
+		expected   []time.Time
+	}{
+		{
+			expression: "0 9 * * MON-FRI except last FRI",
+			timeZone:   utilpointer.String("UTC"),
+			from:       time.Date(2025, 10, 29, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2025, 10, 30, 9, 0, 0, 0, time.UTC),
+				// 2025-10-31 is the last Friday of October
+				time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			expression: "0 9 * * * except day 1 or lastday",
+			timeZone:   utilpointer.String("UTC"),
+			from:       time.Date(2025, 11, 29, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2025, 12, 2, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// the exclusions are evaluated in the time zone of the schedule
+			expression: "0 1 * * * except date 2025-12-25",
+			timeZone:   utilpointer.String("Asia/Shanghai"),
+			from:       time.Date(2025, 12, 24, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2025, 12, 25, 17, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// the excluded day has more fire times than any count would bound
+			expression: "* * * * * except day 1",
+			timeZone:   utilpointer.String("UTC"),
+			from:       time.Date(2025, 11, 30, 23, 58, 30, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2025, 11, 30, 23, 59, 0, 0, time.UTC),
+				time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 12, 2, 0, 1, 0, 0, time.UTC),
+			},
+		},
+		{
+			expression: "0 9 1 * * except day 1",
+			timeZone:   utilpointer.String("UTC"),
+			from:       time.Date(2025, 12, 24, 12, 0, 0, 0, time.UTC),
+			expected:   []time.Time{{}},
+		},
+	}
+
+	for _, tc := range cases {
+		expr, err := ParseScheduleExpression(tc.expression)
+		assert.NoError(t, err, tc.expression)
+		sched, err := expr.Compile(tc.timeZone)
+		assert.NoError(t, err, tc.expression)
+		next := tc.from
+		for _, expected := range tc.expected {
+			next = sched.Next(next)
+			assert.True(t, expected.Equal(next), "%s: expected %v, got %v", tc.expression, expected, next)
+		}
+	}
+}
+
+func TestSolarScheduleNext(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", value, err)
+		}
+		return parsed
+	}
+	berlin := func(event appsv1beta1.SolarEvent, offset time.Duration) *appsv1beta1.SolarSchedule {
+		return &appsv1beta1.SolarSchedule{Event: event, Offset: &metav1.Duration{Duration: offset}, Latitude: "52.52", Longitude: "13.405"}
+	}
+
+	// the expected times are from the sunrise and sunset tables of the locations, to the minute
+	cases := []struct {
+		name     string
+		schedule *appsv1beta1.SolarSchedule
+		from     string
+		expected string
+	}{
+		{
+			name:     "sunrise in Berlin on summer solstice",
+			schedule: berlin(appsv1beta1.SunriseSolarEvent, 0),
+			from:     "2024-06-21T00:00:00Z",
+			expected: "2024-06-21T02:43:00Z",
+		},
+		{
+			name:     "sunset in Berlin on summer solstice",
+			schedule: berlin(appsv1beta1.SunsetSolarEvent, 0),
+			from:     "2024-06-21T00:00:00Z",
+			expected: "2024-06-21T19:33:00Z",
+		},
+		{
+			name:     "sunrise in Berlin on winter solstice",
+			schedule: berlin(appsv1beta1.SunriseSolarEvent, 0),
+			from:     "2024-12-21T00:00:00Z",
+			expected: "2024-12-21T07:15:00Z",
+		},
+		{
+			name:     "sunset in Berlin on winter solstice",
+			schedule: berlin(appsv1beta1.SunsetSolarEvent, 0),
+			from:     "2024-12-21T00:00:00Z",
+			expected: "2024-12-21T14:54:00Z",
+		},
+		{
+			name:     "30 minutes after sunset in Berlin",
+			schedule: berlin(appsv1beta1.SunsetSolarEvent, 30*time.Minute),
+			from:     "2024-06-21T19:40:00Z",
+			expected: "2024-06-21T20:03:00Z",
+		},
+		{
+			name:     "30 minutes after sunset in Berlin of the next day",
+			schedule: berlin(appsv1beta1.SunsetSolarEvent, 30*time.Minute),
+			from:     "2024-06-21T20:10:00Z",
+			expected: "2024-06-22T20:03:00Z",
+		},
+		{
+			name:     "sunrise in Sydney on the UTC day before",
+			schedule: &appsv1beta1.SolarSchedule{Event: appsv1beta1.SunriseSolarEvent, Latitude: "-33.8688", Longitude: "151.2093"},
+			from:     "2024-12-20T12:00:00Z",
+			expected: "2024-12-20T18:41:00Z",
+		},
+		{
+			name:     "first sunrise in Tromso after the polar night",
+			schedule: &appsv1beta1.SolarSchedule{Event: appsv1beta1.SunriseSolarEvent, Latitude: "69.6492", Longitude: "18.9553"},
+			from:     "2024-12-01T00:00:00Z",
+			expected: "2025-01-15T10:33:00Z",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sched, err := NewSolarSchedule(tc.schedule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			next := sched.Next(at(tc.from))
+			if diff := next.Sub(at(tc.expected)); diff < -time.Minute || diff > time.Minute {
+				t.Errorf("expected %s, got %s", tc.expected, next.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestNewSolarScheduleInvalid(t *testing.T) {
+	cases := []*appsv1beta1.SolarSchedule{
+		{Event: "noon", Latitude: "52.52", Longitude: "13.405"},
+		{Event: appsv1beta1.SunriseSolarEvent, Latitude: "91", Longitude: "13.405"},
+		{Event: appsv1beta1.SunriseSolarEvent, Latitude: "52.52", Longitude: "east"},
+	}
+	for _, schedule := range cases {
+		if _, err := NewSolarSchedule(schedule); err == nil {
+			t.Errorf("expected error for %+v", schedule)
+		}
+	}
+}
+
+func TestResolveTemplateKind(t *testing.T) {
+	kind, err := ResolveTemplateKind(appsv1beta1.AdvancedCronJobSpec{Template: jobTemplate()})
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1beta1.JobTemplate, kind)
+
+	kind, err = ResolveTemplateKind(appsv1beta1.AdvancedCronJobSpec{Template: broadcastJobTemplate()})
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1beta1.BroadcastJobTemplate, kind)
+
+	kind, err = ResolveTemplateKind(appsv1beta1.AdvancedCronJobSpec{Template: imageListPullJobTemplate()})
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1beta1.ImageListPullJobTemplate, kind)
+
+	// FindTemplateKind falls back to BroadcastJob without a template
+	_, err = ResolveTemplateKind(appsv1beta1.AdvancedCronJobSpec{})
+	assert.Error(t, err)
+
+	template := jobTemplate()
+	template.BroadcastJobTemplate = broadcastJobTemplate().BroadcastJobTemplate
+	_, err = ResolveTemplateKind(appsv1beta1.AdvancedCronJobSpec{Template: template})
+	assert.Error(t, err)
+}
+
+func TestValidateCompletionPolicyType(t *testing.T) {
+	assert.True(t, AllowedCompletionPolicyTypes.Has(appsv1beta1.Always))
+	assert.NoError(t, ValidateCompletionPolicyType(appsv1beta1.Always))
+
+	err := ValidateCompletionPolicyType(appsv1beta1.Never)
+	assert.EqualError(t, err, "completionPolicy should be Always, but current value is: Never")
+	assert.Error(t, ValidateCompletionPolicyType(""))
+}
+
+func TestDSTTransitionMessage(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	assert.Contains(t, dstTransitionMessage(time.Date(2025, 3, 9, 3, 30, 0, 0, newYork), newYork), "springing forward")
+	assert.Contains(t, dstTransitionMessage(time.Date(2025, 11, 2, 1, 30, 0, 0, newYork), newYork), "falling back")
+	assert.Empty(t, dstTransitionMessage(time.Date(2025, 6, 1, 1, 30, 0, 0, newYork), newYork))
+	assert.Empty(t, dstTransitionMessage(time.Date(2025, 3, 9, 3, 30, 0, 0, time.UTC), time.UTC))
+}
+
+func TestUpdateNextRunDSTWarning(t *testing.T) {
+	acj := createJob("next-run-dst", jobTemplate())
+	acj.UID = "next-run-dst-uid"
+	acj.Spec.Schedule = "30 1 * * *"
+	acj.Spec.TimeZone = utilpointer.String("America/New_York")
+	defer forgetParsedSchedule(types.NamespacedName{Namespace: acj.Namespace, Name: acj.Name})
+	now := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	updateNextRunDSTWarning(acj, now)
+	assert.True(t, acj.Status.NextRunDSTWarning)
+	assert.Contains(t, acj.Status.NextRunDSTMessage, "2025-11-02T01:30:00-04:00")
+
+	// the warning is cleared once the next run is away from the transition
+	updateNextRunDSTWarning(acj, now.AddDate(0, 0, 2))
+	assert.False(t, acj.Status.NextRunDSTWarning)
+	assert.Empty(t, acj.Status.NextRunDSTMessage)
+
+	acj.Spec.Paused = utilpointer.Bool(true)
+	updateNextRunDSTWarning(acj, now)
+	assert.False(t, acj.Status.NextRunDSTWarning)
+}
+
+func TestMissedSchedulesLookbackStart(t *testing.T) {
+	defer func(intervals int) { missedSchedulesLookbackIntervals = intervals }(missedSchedulesLookbackIntervals)
+
+	sched, err := cron.ParseStandard("TZ=UTC */5 * * * *")
+	assert.NoError(t, err)
+	now := time.Date(2025, 10, 10, 9, 2, 0, 0, time.UTC)
+	monthsAgo := now.AddDate(0, -6, 0)
+
+	missedSchedulesLookbackIntervals = 100
+	assert.Equal(t, now.Add(-500*time.Minute), missedSchedulesLookbackStart(sched, monthsAgo, now))
+	// a recent earliest time is kept
+	assert.Equal(t, now.Add(-time.Hour), missedSchedulesLookbackStart(sched, now.Add(-time.Hour), now))
+
+	missedSchedulesLookbackIntervals = 0
+	assert.Equal(t, monthsAgo, missedSchedulesLookbackStart(sched, monthsAgo, now))
+}
+
+func TestReconcileAdvancedJobAfterLongDowntime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	// the last run was scheduled months ago, e.g. restored from a backup
+	job1 := createJob("job-downtime", jobTemplate())
+	monthsAgo := metav1.NewTime(time.Now().AddDate(0, -6, 0))
+	job1.CreationTimestamp = monthsAgo
+	job1.Status.LastScheduleTime = &monthsAgo
+
+	reconcileJob := createReconcileJobWithBatchJobIndex(scheme, job1)
+	request := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "job-downtime",
+			Namespace: "default",
+		},
+	}
+
+	_, err := reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+
+	// only the most recent missed run is started instead of giving up on too many missed runs
+	jobList := &batchv1.JobList{}
+	err = reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace))
+	assert.NoError(t, err)
+	assert.Len(t, jobList.Items, 1)
+	scheduledTime, err := time.Parse(time.RFC3339, jobList.Items[0].Annotations[scheduledTimeAnnotation])
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), scheduledTime, 5*time.Minute)
+}
+
+func TestReconcileAdvancedJobRunAt(t *testing.T) {
+	runAt := metav1.NewTime(time.Now().Add(-time.Minute))
+	cases := []struct {
+		name         string
+		jobName      string
+		fired        bool
+		expectedJobs int
+	}{
+		{
+			name:         "run once at runAt",
+			jobName:      "job-run-at",
+			expectedJobs: 1,
+		},
+		{
+			name:    "already fired",
+			jobName: "job-run-at-fired",
+			fired:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+			utilruntime.Must(batchv1.AddToScheme(scheme))
+			utilruntime.Must(v1.AddToScheme(scheme))
+
+			acj := createJob(tc.jobName, jobTemplate())
+			acj.CreationTimestamp = metav1.NewTime(runAt.Add(-time.Hour))
+			acj.Spec.Schedule = ""
+			acj.Spec.RunAt = &runAt
+			if tc.fired {
+				acj.Status.LastScheduleTime = &runAt
+			}
+			reconcileJob := createReconcileJobWithBatchJobIndex(scheme, acj)
+			request := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      tc.jobName,
+					Namespace: "default",
+				},
+			}
+
+			_, err := reconcileJob.Reconcile(context.TODO(), request)
+			assert.NoError(t, err)
+
+			jobList := &batchv1.JobList{}
+			err = reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace))
+			assert.NoError(t, err)
+			assert.Len(t, jobList.Items, tc.expectedJobs)
+		})
+	}
+}
+
+func TestReconcileAdvancedJobRunAtComplete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	runAt := metav1.NewTime(time.Now().Add(-time.Minute))
+	acj := createJob("job-run-at-complete", jobTemplate())
+	acj.CreationTimestamp = metav1.NewTime(runAt.Add(-time.Hour))
+	acj.Spec.Schedule = ""
+	acj.Spec.RunAt = &runAt
+	reconcileJob := createReconcileJobWithBatchJobIndex(scheme, acj)
+	request := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "job-run-at-complete", Namespace: "default"},
+	}
+	getCompleteCondition := func() *appsv1beta1.AdvancedCronJobCondition {
+		current := &appsv1beta1.AdvancedCronJob{}
+		assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, current))
+		return getAdvancedCronJobCondition(current.Status, appsv1beta1.AdvancedCronJobConditionComplete)
+	}
+
+	_, err := reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Nil(t, getCompleteCondition())
+
+	jobList := &batchv1.JobList{}
+	assert.NoError(t, reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace)))
+	assert.Len(t, jobList.Items, 1)
+	job := &jobList.Items[0]
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+	assert.NoError(t, reconcileJob.Status().Update(context.TODO(), job))
+
+	_, err = reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	condition := getCompleteCondition()
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+	}
+
+	// stays complete after the finished job is cleaned up, instead of running again
+	assert.NoError(t, reconcileJob.Delete(context.TODO(), job))
+	_, err = reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	condition = getCompleteCondition()
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+	}
+	assert.NoError(t, reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace)))
+	assert.Len(t, jobList.Items, 0)
+}
+
+func TestReconcileAdvancedJobSameScheduledTimeTwice(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	runAt := metav1.NewTime(time.Now().Add(-time.Minute))
+	acj := createJob("job-same-scheduled-time", jobTemplate())
+	acj.CreationTimestamp = metav1.NewTime(runAt.Add(-time.Hour))
+	acj.Spec.Schedule = ""
+	acj.Spec.RunAt = &runAt
+	reconcileJob := createReconcileJobWithBatchJobIndex(scheme, acj)
+	fakeClient := reconcileJob.Client.(client.WithWatch)
+	// the listed and got jobs lag behind, so both reconciliations are for the same scheduled time,
+	// and the second one creates the job again
+	reconcileJob.Client = interceptor.NewClient(fakeClient, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*batchv1.JobList); ok {
+				return nil
+			}
+			return c.List(ctx, list, opts...)
+		},
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*batchv1.Job); ok {
+				return errors.NewNotFound(batchv1.Resource("jobs"), key.Name)
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	})
+	request := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "job-same-scheduled-time", Namespace: "default"},
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := reconcileJob.Reconcile(context.TODO(), request)
+		assert.NoError(t, err)
+	}
+
+	jobList := &batchv1.JobList{}
+	err := fakeClient.List(context.TODO(), jobList, client.InNamespace(request.Namespace))
+	assert.NoError(t, err)
+	assert.Len(t, jobList.Items, 1)
+	assert.Equal(t, getChildName(acj, runAt.Time), jobList.Items[0].Name)
+
+	// the job already created is not a failure to back off from
+	retrieved := &appsv1beta1.AdvancedCronJob{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), request.NamespacedName, retrieved))
+	assert.Nil(t, getAdvancedCronJobCondition(retrieved.Status, appsv1beta1.AdvancedCronJobConditionChildCreationFailing))
+}
+
+func TestReconcileAdvancedJobUpdateDuringActiveRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	acj := createJob("job-update-during-active-run", jobTemplate())
+	acj.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	reconcileJob := createReconcileJobWithBatchJobIndex(scheme, acj)
+	request := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "job-update-during-active-run", Namespace: "default"},
+	}
+
+	_, err := reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	jobList := &batchv1.JobList{}
+	assert.NoError(t, reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace)))
+	assert.Len(t, jobList.Items, 1)
+	activeName := jobList.Items[0].Name
+
+	// the schedule and concurrencyPolicy are updatable, but the child names only depend on the name and scheduled time
+	retrieved := &appsv1beta1.AdvancedCronJob{}
+	assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrieved))
+	retrieved.Spec.Schedule = "*/10 * * * *"
+	retrieved.Spec.ConcurrencyPolicy = appsv1beta1.AllowConcurrent
+	assert.NoError(t, reconcileJob.Update(context.TODO(), retrieved))
+
+	_, err = reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	jobList = &batchv1.JobList{}
+	assert.NoError(t, reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace)))
+	assert.Len(t, jobList.Items, 1)
+	assert.Equal(t, activeName, jobList.Items[0].Name)
+	assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrieved))
+	if assert.Len(t, retrieved.Status.Active, 1) && assert.NotNil(t, retrieved.Status.LastScheduleTime) {
+		assert.Equal(t, activeName, retrieved.Status.Active[0].Name)
+		assert.Equal(t, getChildName(retrieved, retrieved.Status.LastScheduleTime.Time), activeName)
+	}
+}
+
+func TestBroadcastJobConcurrencyPolicy(t *testing.T) {
+	defer func(v int) { BroadcastJobAllowConcurrentMaxNodes = v }(BroadcastJobAllowConcurrentMaxNodes)
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	acj := createJob("job-broadcast-allow", broadcastJobTemplate())
+	acj.Spec.ConcurrencyPolicy = appsv1beta1.AllowConcurrent
+	reconcileJob := createReconcileJobWithBroadcastJobIndex(scheme, acj, createNode("node1"), createNode("node2"), createNode("node3"))
+
+	policy, err := reconcileJob.broadcastJobConcurrencyPolicy(context.TODO(), acj, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1beta1.AllowConcurrent, policy, "Allow is not restricted with the feature-gate disabled")
+
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobLimitBroadcastConcurrency, true)()
+	BroadcastJobAllowConcurrentMaxNodes = 0
+	policy, err = reconcileJob.broadcastJobConcurrencyPolicy(context.TODO(), acj, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1beta1.AllowConcurrent, policy, "Allow is not restricted without active runs")
+
+	policy, err = reconcileJob.broadcastJobConcurrencyPolicy(context.TODO(), acj, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1beta1.ForbidConcurrent, policy, "Allow is run as Forbid in any cluster with max nodes 0")
+
+	BroadcastJobAllowConcurrentMaxNodes = 3
+	policy, err = reconcileJob.broadcastJobConcurrencyPolicy(context.TODO(), acj, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1beta1.AllowConcurrent, policy, "Allow is not restricted in a cluster of at most max nodes")
+
+	BroadcastJobAllowConcurrentMaxNodes = 2
+	policy, err = reconcileJob.broadcastJobConcurrencyPolicy(context.TODO(), acj, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1beta1.ForbidConcurrent, policy, "Allow is run as Forbid in a cluster of more than max nodes")
+
+	acj.Spec.ConcurrencyPolicy = appsv1beta1.ReplaceConcurrent
+	policy, err = reconcileJob.broadcastJobConcurrencyPolicy(context.TODO(), acj, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1beta1.ReplaceConcurrent, policy)
+}
+
+func TestReconcileAdvancedJobInvalidTimeZone(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	acj := createJob("job-invalid-time-zone", jobTemplate())
+	acj.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	acj.Spec.TimeZone = utilpointer.String("Invalid/Zone")
+	reconcileJob := createReconcileJobWithBatchJobIndex(scheme, acj)
+	request := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "job-invalid-time-zone", Namespace: "default"},
+	}
+
+	// the schedule is not run in the local time zone of the controller instead
+	_, err := reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	jobList := &batchv1.JobList{}
+	assert.NoError(t, reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace)))
+	assert.Len(t, jobList.Items, 0)
+	retrieved := &appsv1beta1.AdvancedCronJob{}
+	assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrieved))
+	assert.Nil(t, retrieved.Status.LastScheduleTime)
+	condition := getAdvancedCronJobCondition(retrieved.Status, appsv1beta1.AdvancedCronJobConditionInvalidTimeZone)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, "LoadLocationFailed", condition.Reason)
+	}
+
+	// scheduling resumes once the time zone is fixed
+	retrieved.Spec.TimeZone = utilpointer.String("UTC")
+	assert.NoError(t, reconcileJob.Update(context.TODO(), retrieved))
+	_, err = reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	jobList = &batchv1.JobList{}
+	assert.NoError(t, reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace)))
+	assert.Len(t, jobList.Items, 1)
+	assert.NoError(t, reconcileJob.Get(context.TODO(), request.NamespacedName, retrieved))
+	condition = getAdvancedCronJobCondition(retrieved.Status, appsv1beta1.AdvancedCronJobConditionInvalidTimeZone)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+	}
+}
+
+func TestReconcileAdvancedJobChildCreationBackoff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	clock := clocktesting.NewFakeClock(time.Date(2025, 10, 10, 9, 1, 0, 0, time.UTC))
+	acj := createJob("job-child-creation-backoff", jobTemplate())
+	acj.CreationTimestamp = metav1.NewTime(clock.Now().Add(-time.Hour))
+	reconcileJob := createReconcileJobWithBatchJobIndex(scheme, acj)
+	reconcileJob.Clock = clock
+	fakeClient := reconcileJob.Client.(client.WithWatch)
+	// the quota is exceeded, so the jobs can not be created
+	creates := 0
+	reconcileJob.Client = interceptor.NewClient(fakeClient, interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*batchv1.Job); ok {
+				creates++
+				return fmt.Errorf("exceeded quota: compute-resources")
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	})
+	request := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "job-child-creation-backoff", Namespace: "default"},
+	}
+	defer forgetChildCreationFailures(request.NamespacedName)
+
+	failedAt := clock.Now()
+	for i, expected := range []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second} {
+		result, err := reconcileJob.Reconcile(context.TODO(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result.RequeueAfter)
+		assert.Equal(t, i+1, creates)
+
+		// the reconciliation triggered by a watch event within the backoff does not retry the creation
+		clock.Step(expected / 2)
+		result, err = reconcileJob.Reconcile(context.TODO(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, expected-expected/2, result.RequeueAfter)
+		assert.Equal(t, i+1, creates)
+		clock.Step(expected - expected/2)
+	}
+	retrieved := &appsv1beta1.AdvancedCronJob{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), request.NamespacedName, retrieved))
+	condition := getAdvancedCronJobCondition(retrieved.Status, appsv1beta1.AdvancedCronJobConditionChildCreationFailing)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, "CreateFailed", condition.Reason)
+		assert.Contains(t, condition.Message, "exceeded quota")
+		assert.True(t, failedAt.Equal(condition.LastTransitionTime.Time))
+	}
+
+	// the quota is raised, the job is created and the backoff is reset
+	reconcileJob.Client = fakeClient
+	_, err := reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.NoError(t, fakeClient.Get(context.TODO(), request.NamespacedName, retrieved))
+	condition = getAdvancedCronJobCondition(retrieved.Status, appsv1beta1.AdvancedCronJobConditionChildCreationFailing)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+	}
+	assert.Zero(t, childCreationBackoffRemaining(request.NamespacedName, retrieved.Generation, clock.Now()))
+	assert.Equal(t, childCreationBackoffBase, nextChildCreationBackoff(request.NamespacedName, retrieved.Generation, clock.Now()))
+}
+
+func TestReconcileAdvancedJobChildCreationRejected(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	acj := createJob("job-child-creation-rejected", jobTemplate())
+	acj.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	reconcileJob := createReconcileJobWithBatchJobIndex(scheme, acj)
+	fakeClient := reconcileJob.Client.(client.WithWatch)
+	// the jobs are invalid, which is not fixed by retrying
+	reconcileJob.Client = interceptor.NewClient(fakeClient, interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*batchv1.Job); ok {
+				return errors.NewInvalid(batchv1.SchemeGroupVersion.WithKind("Job").GroupKind(), obj.GetName(), nil)
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	})
+	request := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "job-child-creation-rejected", Namespace: "default"},
+	}
+	defer forgetChildCreationFailures(request.NamespacedName)
+
+	result, err := reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, childCreationBackoffCap, result.RequeueAfter)
+	retrieved := &appsv1beta1.AdvancedCronJob{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), request.NamespacedName, retrieved))
+	condition := getAdvancedCronJobCondition(retrieved.Status, appsv1beta1.AdvancedCronJobConditionChildCreationFailing)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, "CreateRejected", condition.Reason)
+	}
+
+	// the backoff is dropped once the AdvancedCronJob is deleted
+	assert.NotZero(t, childCreationBackoffRemaining(request.NamespacedName, retrieved.Generation, reconcileJob.Now()))
+	assert.NoError(t, fakeClient.Delete(context.TODO(), retrieved))
+	_, err = reconcileJob.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Zero(t, childCreationBackoffRemaining(request.NamespacedName, retrieved.Generation, reconcileJob.Now()))
+}
+
+func TestIsChildCreationRejected(t *testing.T) {
+	jobs := batchv1.Resource("jobs")
+	assert.True(t, isChildCreationRejected(errors.NewInvalid(batchv1.SchemeGroupVersion.WithKind("Job").GroupKind(), "job1", nil)))
+	assert.True(t, isChildCreationRejected(errors.NewBadRequest("bad request")))
+	// a denial of an admission webhook can not be told apart from an exceeded quota, both are backed off
+	assert.False(t, isChildCreationRejected(errors.NewForbidden(jobs, "job1", fmt.Errorf("admission webhook denied the request"))))
+	assert.False(t, isChildCreationRejected(errors.NewForbidden(jobs, "job1", fmt.Errorf("exceeded quota: compute-resources"))))
+	assert.False(t, isChildCreationRejected(errors.NewAlreadyExists(jobs, "job1")))
+	assert.False(t, isChildCreationRejected(errors.NewServiceUnavailable("unavailable")))
+	assert.False(t, isChildCreationRejected(fmt.Errorf("connection refused")))
+}
+
+func TestNextChildCreationBackoff(t *testing.T) {
+	key := types.NamespacedName{Namespace: "default", Name: "backoff"}
+	defer forgetChildCreationFailures(key)
+
+	now := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	var backoff time.Duration
+	for i := 0; i < 20; i++ {
+		backoff = nextChildCreationBackoff(key, 1, now)
+		assert.LessOrEqual(t, backoff, childCreationBackoffCap)
+	}
+	assert.Equal(t, childCreationBackoffCap, backoff)
+
+	assert.Equal(t, childCreationBackoffCap, childCreationBackoffRemaining(key, 1, now))
+	assert.Equal(t, time.Second, childCreationBackoffRemaining(key, 1, now.Add(childCreationBackoffCap-time.Second)))
+	assert.Zero(t, childCreationBackoffRemaining(key, 1, now.Add(childCreationBackoffCap)))
+	// the change of the spec lifts the backoff
+	assert.Zero(t, childCreationBackoffRemaining(key, 2, now))
+}
+
+func TestReconcileAdvancedJobTracing(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	acj := createJob("job-tracing", jobTemplate())
+	acj.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	reconcileJob := createReconcileJobWithBatchJobIndex(scheme, acj)
+	spanRecorder := tracetest.NewSpanRecorder()
+	reconcileJob.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)).Tracer(tracerName)
+
+	_, err := reconcileJob.Reconcile(context.TODO(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "job-tracing", Namespace: "default"},
+	})
+	assert.NoError(t, err)
+
+	spans := spanRecorder.Ended()
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	var order []string
+	for _, span := range spans {
+		byName[span.Name()] = span
+		order = append(order, span.Name())
+		assert.Contains(t, span.Attributes(), attribute.String("name", "job-tracing"), span.Name())
+		assert.Contains(t, span.Attributes(), attribute.String("templateKind", string(appsv1beta1.JobTemplate)), span.Name())
+	}
+	assert.Equal(t, []string{"GetNextSchedule", "IsSuppressed", "ConstructChild", "CreateChild", "Reconcile"}, order)
+	// the spans of the reconciling are children of the Reconcile span, and the child is constructed within CreateChild
+	parents := map[string]string{"GetNextSchedule": "Reconcile", "IsSuppressed": "Reconcile", "ConstructChild": "CreateChild", "CreateChild": "Reconcile"}
+	for name, parent := range parents {
+		if assert.Contains(t, byName, name) && assert.Contains(t, byName, parent) {
+			assert.Equal(t, byName[parent].SpanContext().SpanID(), byName[name].Parent().SpanID(), name)
+		}
+	}
+}
+
+func TestReconcileAdvancedJobStartingDeadline(t *testing.T) {
+	created := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	scheduled := created.Add(5 * time.Minute)
+
+	cases := []struct {
+		name            string
+		jobName         string
+		deadlinePolicy  appsv1beta1.DeadlinePolicy
+		sinceScheduled  time.Duration
+		expectedJobs    int
+		expectedSkipped float64
+	}{
+		{
+			name:           "just inside the deadline",
+			jobName:        "job-inside-deadline",
+			sinceScheduled: 9 * time.Second,
+			expectedJobs:   1,
+		},
+		{
+			name:            "just outside the deadline",
+			jobName:         "job-outside-deadline",
+			sinceScheduled:  11 * time.Second,
+			expectedSkipped: 1,
+		},
+		{
+			name:           "outside the deadline under RunImmediately",
+			jobName:        "job-outside-deadline-run-immediately",
+			deadlinePolicy: appsv1beta1.RunImmediatelyDeadlinePolicy,
+			sinceScheduled: 11 * time.Second,
+			expectedJobs:   1,
+		},
+		{
+			name:           "long after the deadline under RunImmediately",
+			jobName:        "job-long-after-deadline-run-immediately",
+			deadlinePolicy: appsv1beta1.RunImmediatelyDeadlinePolicy,
+			sinceScheduled: 4 * time.Minute,
+			expectedJobs:   1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+			utilruntime.Must(v1.AddToScheme(scheme))
+
+			acj := createJob(tc.jobName, imageListPullJobTemplate())
+			acj.CreationTimestamp = metav1.NewTime(created)
+			acj.Spec.StartingDeadlineSeconds = utilpointer.Int64(10)
+			acj.Spec.DeadlinePolicy = tc.deadlinePolicy
+			reconcileJob := createReconcileJobWithImageListPullJobIndex(scheme, acj)
+			reconcileJob.Clock = clocktesting.NewFakeClock(scheduled.Add(tc.sinceScheduled))
+
+			request := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      tc.jobName,
+					Namespace: "default",
+				},
+			}
+			counted := testutil.ToFloat64(AdvancedCronJobSkippedRunsMetrics.WithLabelValues(request.Namespace, SkippedRunReasonMissedDeadline))
+			// reconcile twice to make sure the skipped run is counted only once
+			for i := 0; i < 2; i++ {
+				_, err := reconcileJob.Reconcile(context.TODO(), request)
+				assert.NoError(t, err)
+			}
+
+			jobList := &appsv1beta1.ImageListPullJobList{}
+			err := reconcileJob.List(context.TODO(), jobList, client.InNamespace(request.Namespace))
+			assert.NoError(t, err)
+			assert.Len(t, jobList.Items, tc.expectedJobs)
+			skipped := testutil.ToFloat64(AdvancedCronJobSkippedRunsMetrics.WithLabelValues(request.Namespace, SkippedRunReasonMissedDeadline)) - counted
+			assert.Equal(t, tc.expectedSkipped, skipped)
+		})
+	}
+}
+
+func TestReconcileAdvancedJobStartingDeadlineByTemplate(t *testing.T) {
+	created := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	scheduled := created.Add(5 * time.Minute)
+
+	templates := []struct {
+		name         string
+		template     appsv1beta1.CronJobTemplate
+		reconcileJob func(scheme *runtime.Scheme, initObjs ...client.Object) ReconcileAdvancedCronJob
+		list         client.ObjectList
+	}{
+		{name: "job", template: jobTemplate(), reconcileJob: createReconcileJobWithBatchJobIndex, list: &batchv1.JobList{}},
+		{name: "broadcastjob", template: broadcastJobTemplate(), reconcileJob: createReconcileJobWithBroadcastJobIndex, list: &appsv1beta1.BroadcastJobList{}},
+	}
+	cases := []struct {
+		name            string
+		sinceScheduled  time.Duration
+		expectedJobs    int
+		expectedSkipped float64
+	}{
+		{name: "inside-deadline", sinceScheduled: 9 * time.Second, expectedJobs: 1},
+		{name: "outside-deadline", sinceScheduled: 11 * time.Second, expectedSkipped: 1},
+	}
+
+	for _, tmpl := range templates {
+		for _, tc := range cases {
+			jobName := tmpl.name + "-" + tc.name
+			t.Run(jobName, func(t *testing.T) {
+				scheme := runtime.NewScheme()
+				utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+				utilruntime.Must(batchv1.AddToScheme(scheme))
+				utilruntime.Must(v1.AddToScheme(scheme))
+
+				acj := createJob(jobName, tmpl.template)
+				acj.CreationTimestamp = metav1.NewTime(created)
+				acj.Spec.StartingDeadlineSeconds = utilpointer.Int64(10)
+				reconcileJob := tmpl.reconcileJob(scheme, acj)
+				reconcileJob.Clock = clocktesting.NewFakeClock(scheduled.Add(tc.sinceScheduled))
+
+				request := reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: jobName, Namespace: "default"},
+				}
+				defer forgetSkippedRuns(request.NamespacedName)
+				counted := testutil.ToFloat64(AdvancedCronJobSkippedRunsMetrics.WithLabelValues(request.Namespace, SkippedRunReasonMissedDeadline))
+				_, err := reconcileJob.Reconcile(context.TODO(), request)
+				assert.NoError(t, err)
+
+				list := tmpl.list.DeepCopyObject().(client.ObjectList)
+				assert.NoError(t, reconcileJob.List(context.TODO(), list, client.InNamespace(request.Namespace)))
+				assert.Equal(t, tc.expectedJobs, meta.LenList(list))
+				skipped := testutil.ToFloat64(AdvancedCronJobSkippedRunsMetrics.WithLabelValues(request.Namespace, SkippedRunReasonMissedDeadline)) - counted
+				assert.Equal(t, tc.expectedSkipped, skipped)
+			})
+		}
+	}
+}
+
+func TestReconcileAdvancedJobPaused(t *testing.T) {
+	created := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name         string
+		template     appsv1beta1.CronJobTemplate
+		reconcileJob func(scheme *runtime.Scheme, initObjs ...client.Object) ReconcileAdvancedCronJob
+		list         client.ObjectList
+	}{
+		{name: "job-paused", template: jobTemplate(), reconcileJob: createReconcileJobWithBatchJobIndex, list: &batchv1.JobList{}},
+		{name: "broadcastjob-paused", template: broadcastJobTemplate(), reconcileJob: createReconcileJobWithBroadcastJobIndex, list: &appsv1beta1.BroadcastJobList{}},
+		{name: "imagelistpulljob-paused", template: imageListPullJobTemplate(), reconcileJob: createReconcileJobWithImageListPullJobIndex, list: &appsv1beta1.ImageListPullJobList{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+			utilruntime.Must(batchv1.AddToScheme(scheme))
+			utilruntime.Must(v1.AddToScheme(scheme))
+
+			acj := createJob(tc.name, tc.template)
+			acj.CreationTimestamp = metav1.NewTime(created)
+			acj.Spec.Paused = utilpointer.Bool(true)
+			reconcileJob := tc.reconcileJob(scheme, acj)
+			clock := clocktesting.NewFakeClock(created.Add(5*time.Minute + 30*time.Second))
+			reconcileJob.Clock = clock
+			recorder := record.NewFakeRecorder(100)
+			reconcileJob.recorder = recorder
+			request := reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: tc.name, Namespace: "default"},
+			}
+			defer forgetSkippedRuns(request.NamespacedName)
+			pausedEvents := func() []string {
+				var events []string
+				for len(recorder.Events) > 0 {
+					if event := <-recorder.Events; strings.Contains(event, "RunSkippedPaused") {
+						events = append(events, event)
+					}
+				}
+				return events
+			}
+
+			counted := testutil.ToFloat64(AdvancedCronJobSkippedRunsMetrics.WithLabelValues(request.Namespace, SkippedRunReasonPaused))
+			// the run skipped while paused is reported once however many times it is reconciled,
+			// and the AdvancedCronJob is requeued at the next run
+			for i := 0; i < 2; i++ {
+				result, err := reconcileJob.Reconcile(context.TODO(), request)
+				assert.NoError(t, err)
+				assert.Equal(t, 4*time.Minute+30*time.Second, result.RequeueAfter)
+			}
+			assert.Equal(t, []string{"Normal RunSkippedPaused skipped the run scheduled at 2025-10-10T09:05:00Z: the AdvancedCronJob is paused"}, pausedEvents())
+			assert.Equal(t, counted+1, testutil.ToFloat64(AdvancedCronJobSkippedRunsMetrics.WithLabelValues(request.Namespace, SkippedRunReasonPaused)))
+
+			// the next run skipped while paused is reported as well
+			clock.Step(5 * time.Minute)
+			_, err := reconcileJob.Reconcile(context.TODO(), request)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"Normal RunSkippedPaused skipped the run scheduled at 2025-10-10T09:10:00Z: the AdvancedCronJob is paused"}, pausedEvents())
+			assert.Equal(t, counted+2, testutil.ToFloat64(AdvancedCronJobSkippedRunsMetrics.WithLabelValues(request.Namespace, SkippedRunReasonPaused)))
+
+			list := tc.list.DeepCopyObject().(client.ObjectList)
+			assert.NoError(t, reconcileJob.List(context.TODO(), list, client.InNamespace(request.Namespace)))
+			assert.Equal(t, 0, meta.LenList(list))
+		})
+	}
+}
+
+func TestScheduleDrift(t *testing.T) {
+	scheduled := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	now := scheduled.Add(time.Minute)
+
+	assert.Equal(t, 5*time.Second, scheduleDrift(scheduled, metav1.NewTime(scheduled.Add(5*time.Second)), now))
+	// the creation time is now without a creation timestamp
+	assert.Equal(t, time.Minute, scheduleDrift(scheduled, metav1.Time{}, now))
+	// the creation timestamp of a skewed apiserver clock
+	assert.Equal(t, time.Duration(0), scheduleDrift(scheduled, metav1.NewTime(scheduled.Add(-time.Second)), now))
+
+	recordScheduleDrift("drift-test", scheduled, metav1.Time{}, now)
+	// a series per namespace, other tests may have observed drifts in their namespaces
+	assert.GreaterOrEqual(t, testutil.CollectAndCount(AdvancedCronJobScheduleDriftMetrics), 1)
+}
+
+func TestWarnTZDataVersionMismatch(t *testing.T) {
+	defer func(f func() string) { localTZDataVersion = f }(localTZDataVersion)
+	localTZDataVersion = func() string { return "2025b" }
+
+	cases := []struct {
+		name          string
+		validated     string
+		expectWarning bool
+	}{
+		{name: "not stamped"},
+		{name: "same version", validated: "2025b"},
+		{name: "different version", validated: "2024a", expectWarning: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileAdvancedCronJob{recorder: recorder}
+			acj := createJob("tzdata-test", jobTemplate())
+			if tc.validated != "" {
+				acj.Annotations = map[string]string{appsv1beta1.AdvancedCronJobTZDataVersionAnnotation: tc.validated}
+			}
+			defer forgetTZDataVersionMismatch(types.NamespacedName{Namespace: acj.Namespace, Name: acj.Name})
+			// the mismatch is warned only once however many times it is reconciled
+			r.warnTZDataVersionMismatch(acj)
+			r.warnTZDataVersionMismatch(acj)
+			expectEvents := 0
+			if tc.expectWarning {
+				expectEvents = 1
+			}
+			assert.Equal(t, expectEvents, len(recorder.Events))
+		})
+	}
+}
+
+func TestLogSchedulingDecision(t *testing.T) {
+	defer func(enabled bool, w io.Writer) { enableDecisionLog, decisionLog.w = enabled, w }(enableDecisionLog, decisionLog.w)
+	buf := &bytes.Buffer{}
+	decisionLog.w = buf
+	acj := createJob("decision-log", jobTemplate())
+	acj.Spec.TimeZone = utilpointer.String("Asia/Shanghai")
+	scheduledTime := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	now := scheduledTime.Add(30 * time.Second)
+
+	enableDecisionLog = false
+	logSchedulingDecision(acj, decisionFired, "", scheduledTime, "decision-log-1760086800", now)
+	assert.Empty(t, buf.String())
+
+	enableDecisionLog = true
+	logSchedulingDecision(acj, decisionFired, "", scheduledTime, "decision-log-1760086800", now)
+	logSchedulingDecision(acj, decisionSuppressed, SkippedRunReasonSkipDate, scheduledTime.Add(24*time.Hour), "", now)
+	decoder := json.NewDecoder(buf)
+	var decisions []schedulingDecision
+	for decoder.More() {
+		var d schedulingDecision
+		assert.NoError(t, decoder.Decode(&d))
+		decisions = append(decisions, d)
+	}
+	if assert.Len(t, decisions, 2) {
+		assert.Equal(t, "default", decisions[0].Namespace)
+		assert.Equal(t, "decision-log", decisions[0].Name)
+		assert.Equal(t, decisionFired, decisions[0].Decision)
+		assert.True(t, now.Equal(decisions[0].Time))
+		assert.True(t, scheduledTime.Equal(decisions[0].ScheduledTime))
+		assert.Equal(t, "Asia/Shanghai", decisions[0].TimeZone)
+		assert.Equal(t, "decision-log-1760086800", decisions[0].Child)
+		assert.Equal(t, decisionSuppressed, decisions[1].Decision)
+		assert.Equal(t, SkippedRunReasonSkipDate, decisions[1].Reason)
+		assert.Empty(t, decisions[1].Child)
+	}
+}
+
+func TestReportSkippedRun(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &ReconcileAdvancedCronJob{recorder: recorder, Clock: fakeClock}
+	acj := createJob("skipped-run-events", jobTemplate())
+	defer forgetSkippedRuns(types.NamespacedName{Namespace: acj.Namespace, Name: acj.Name})
+	scheduledTime := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+
+	// a run suppressed in several reconciliations is reported once
+	r.reportSkippedRun(acj, scheduledTime, SkippedRunReasonPaused)
+	r.reportSkippedRun(acj, scheduledTime, SkippedRunReasonPaused)
+	assert.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal RunSkippedPaused skipped the run scheduled at 2025-10-10T09:00:00Z: the AdvancedCronJob is paused", <-recorder.Events)
+
+	// the runs missing the deadline are reported by a single event
+	sched, err := cron.ParseStandard("0 * * * *")
+	assert.NoError(t, err)
+	r.reportMissedDeadlineRuns(acj, sched, scheduledTime, scheduledTime.Add(3*time.Hour))
+	r.reportMissedDeadlineRuns(acj, sched, scheduledTime, scheduledTime.Add(3*time.Hour))
+	assert.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning RunSkippedMissedDeadline skipped 3 run(s) scheduled from 2025-10-10T10:00:00Z to 2025-10-10T12:00:00Z: "+
+		"not started within spec.startingDeadlineSeconds", <-recorder.Events)
+}
+
+func TestGetCachedSchedule(t *testing.T) {
+	acj := createJob("cached-schedule", jobTemplate())
+	acj.UID = "cached-schedule-uid"
+	acj.Generation = 1
+	acj.Spec.Schedule = "0 * * * *"
+	acj.Spec.TimeZone = utilpointer.String("UTC")
+	defer forgetParsedSchedule(types.NamespacedName{Namespace: acj.Namespace, Name: acj.Name})
+	from := time.Date(2025, 10, 10, 9, 30, 0, 0, time.UTC)
+
+	sched, err := getCachedSchedule(acj)
+	assert.NoError(t, err)
+	assert.True(t, from.Add(30*time.Minute).Equal(sched.Next(from)))
+
+	// the schedule of the same generation is not parsed again
+	acj.Spec.Schedule = "invalid"
+	sched, err = getCachedSchedule(acj)
+	assert.NoError(t, err)
+	assert.True(t, from.Add(30*time.Minute).Equal(sched.Next(from)))
+
+	// bumping the generation parses and validates the schedule again
+	acj.Generation = 2
+	_, err = getCachedSchedule(acj)
+	assert.Error(t, err)
+	acj.Generation = 3
+	acj.Spec.Schedule = "*/10 * * * *"
+	sched, err = getCachedSchedule(acj)
+	assert.NoError(t, err)
+	assert.True(t, from.Add(10*time.Minute).Equal(sched.Next(from)))
+
+	// a recreated AdvancedCronJob of the same name is parsed again
+	acj.UID = "recreated-uid"
+	acj.Generation = 1
+	acj.Spec.Schedule = "0 * * * *"
+	sched, err = getCachedSchedule(acj)
+	assert.NoError(t, err)
+	assert.True(t, from.Add(30*time.Minute).Equal(sched.Next(from)))
+}
+
+func TestIsSuppressed(t *testing.T) {
+	friday := time.Date(2025, 10, 31, 9, 0, 0, 0, time.UTC)
+	thursday := time.Date(2025, 10, 30, 9, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name               string
+		paused             bool
+		scheduleExpression *string
+		skipDates          []string
+		timeZone           string
+		time               time.Time
+		expectedSuppressed bool
+		expectedReason     string
+	}{
+		{
+			name: "not suppressed",
+			time: friday,
+		},
+		{
+			name:               "on a skip date",
+			skipDates:          []string{"2025-10-01", "2025-10-31"},
+			time:               friday,
+			expectedSuppressed: true,
+			expectedReason:     SkippedRunReasonSkipDate,
+		},
+		{
+			name:      "not on a skip date",
+			skipDates: []string{"2025-10-01", "2025-10-31"},
+			time:      thursday,
+		},
+		{
+			name:               "on a skip date in the time zone",
+			skipDates:          []string{"2025-10-31"},
+			timeZone:           "Asia/Shanghai",
+			time:               thursday.Add(12 * time.Hour),
+			expectedSuppressed: true,
+			expectedReason:     SkippedRunReasonSkipDate,
+		},
+		{
+			name:               "paused",
+			paused:             true,
+			scheduleExpression: utilpointer.String("0 9 * * MON-FRI except last FRI"),
+			time:               thursday,
+			expectedSuppressed: true,
+			expectedReason:     SkippedRunReasonPaused,
+		},
+		{
+			name:               "paused on a skip date",
+			paused:             true,
+			skipDates:          []string{"2025-10-31"},
+			time:               friday,
+			expectedSuppressed: true,
+			expectedReason:     SkippedRunReasonPaused,
+		},
+		{
+			name:               "excluded",
+			scheduleExpression: utilpointer.String("0 9 * * MON-FRI except last FRI"),
+			time:               friday,
+			expectedSuppressed: true,
+			expectedReason:     SkippedRunReasonExcluded,
+		},
+		{
+			name:               "not excluded",
+			scheduleExpression: utilpointer.String("0 9 * * MON-FRI except last FRI"),
+			time:               thursday,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			acj := createJob("job-suppressed", jobTemplate())
+			acj.Spec.Paused = utilpointer.Bool(tc.paused)
+			acj.Spec.ScheduleExpression = tc.scheduleExpression
+			acj.Spec.SkipDates = tc.skipDates
+			acj.Spec.TimeZone = utilpointer.String("UTC")
+			if tc.timeZone != "" {
+				acj.Spec.TimeZone = utilpointer.String(tc.timeZone)
+			}
+			suppressed, reason := IsSuppressed(acj, tc.time)
+			assert.Equal(t, tc.expectedSuppressed, suppressed)
+			assert.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
+)
+
+// The scheduling decisions of a run, written to the decision log.
+const (
+	// decisionFired is a run whose child is created.
+	decisionFired = "fired"
+	// decisionSuppressed is a run suppressed by IsSuppressed, with its reason.
+	decisionSuppressed = "suppressed"
+	// decisionSkipped is a run not started within spec.startingDeadlineSeconds.
+	decisionSkipped = "skipped"
+)
+
+var (
+	// enableDecisionLog writes a JSON line of every scheduling decision to decisionLog.
+	enableDecisionLog bool
+
+	decisionLog = struct {
+		sync.Mutex
+		w io.Writer
+	}{w: os.Stdout}
+)
+
+// schedulingDecision is a line of the decision log, from which the runs of the AdvancedCronJobs can be replayed.
+type schedulingDecision struct {
+	Time          time.Time `json:"time"`
+	Namespace     string    `json:"namespace"`
+	Name          string    `json:"name"`
+	Decision      string    `json:"decision"`
+	Reason        string    `json:"reason,omitempty"`
+	ScheduledTime time.Time `json:"scheduledTime"`
+	TimeZone      string    `json:"timeZone,omitempty"`
+	Child         string    `json:"child,omitempty"`
+}
+
+// logSchedulingDecision writes the decision of the run scheduled at scheduledTime made at now to the decision log, if
+// it is enabled. The reason is one of the reasons of the skipped-runs metric, and child is the name of the child of a
+// fired run.
+func logSchedulingDecision(acj *appsv1beta1.AdvancedCronJob, decision, reason string, scheduledTime time.Time, child string, now time.Time) {
+	if !enableDecisionLog {
+		return
+	}
+	d := schedulingDecision{
+		Time:          now.UTC(),
+		Namespace:     acj.Namespace,
+		Name:          acj.Name,
+		Decision:      decision,
+		Reason:        reason,
+		ScheduledTime: scheduledTime.UTC(),
+		Child:         child,
+	}
+	if loc, err := webhookutil.ResolveLocation(acj); err == nil {
+		d.TimeZone = loc.String()
+	}
+
+	decisionLog.Lock()
+	defer decisionLog.Unlock()
+	if err := json.NewEncoder(decisionLog.w).Encode(d); err != nil {
+		klog.ErrorS(err, "Failed to write the scheduling decision", "advancedCronJob", klog.KObj(acj), "decision", decision)
+	}
+}
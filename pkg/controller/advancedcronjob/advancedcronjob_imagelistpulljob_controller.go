@@ -22,7 +22,7 @@ import (
 	"sort"
 	"time"
 
-	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ref "k8s.io/client-go/tools/reference"
@@ -102,6 +102,9 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 	// +kubebuilder:docs-gen:collapse=getScheduledTimeForJob
 
 	var mostRecentTime *time.Time
+	var runs []childRun
+	// the children of the most recent run
+	var lastRunJobs []*appsv1beta1.ImageListPullJob
 	for i, job := range childJobs.Items {
 		_, finishedType := isImageListPullJobFinished(&job)
 		switch finishedType {
@@ -121,10 +124,13 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 			continue
 		}
 		if scheduledTimeForJob != nil {
-			if mostRecentTime == nil {
-				mostRecentTime = scheduledTimeForJob
-			} else if mostRecentTime.Before(*scheduledTimeForJob) {
+			runs = append(runs, childRun{scheduledTime: *scheduledTimeForJob, active: finishedType == "", failed: finishedType == appsv1beta1.JobFailed})
+			if mostRecentTime == nil || mostRecentTime.Before(*scheduledTimeForJob) {
 				mostRecentTime = scheduledTimeForJob
+				lastRunJobs = nil
+			}
+			if mostRecentTime.Equal(*scheduledTimeForJob) {
+				lastRunJobs = append(lastRunJobs, &childJobs.Items[i])
 			}
 		}
 	}
@@ -134,6 +140,8 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 	} else {
 		advancedCronJob.Status.LastScheduleTime = nil
 	}
+	advancedCronJob.Status.ConsecutiveFailures, advancedCronJob.Status.ConsecutiveFailuresCountedTime = countConsecutiveFailures(
+		runs, advancedCronJob.Status.ConsecutiveFailures, advancedCronJob.Status.ConsecutiveFailuresCountedTime)
 
 	advancedCronJob.Status.Active = nil
 	for _, activeJob := range activeJobs {
@@ -145,7 +153,20 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 		advancedCronJob.Status.Active = append(advancedCronJob.Status.Active, *jobRef)
 	}
 
+	advancedCronJob.Status.LastRunChildren = nil
+	for _, lastRunJob := range lastRunJobs {
+		jobRef, err := ref.GetReference(r.scheme, lastRunJob)
+		if err != nil {
+			klog.ErrorS(err, "Unable to make reference to last run ImageListPullJob", "imageListPullJob", klog.KObj(lastRunJob), "advancedCronJob", req)
+			continue
+		}
+		advancedCronJob.Status.LastRunChildren = append(advancedCronJob.Status.LastRunChildren, *jobRef)
+	}
+
 	klog.V(1).InfoS("AdvancedCronJob ImageListPullJob count", "activeJobCount", len(activeJobs), "successfulJobCount", len(successfulJobs), "failedJobCount", len(failedJobs), "advancedCronJob", req)
+	complete := updateCompleteCondition(&advancedCronJob, r.Now())
+	invalidTimeZone := updateInvalidTimeZoneCondition(&advancedCronJob, r.Now())
+	updateNextRunDSTWarning(&advancedCronJob, r.Now())
 	if err := r.updateAdvancedJobStatus(req, &advancedCronJob); err != nil {
 		klog.ErrorS(err, "Unable to update AdvancedCronJob status", "advancedCronJob", req)
 		return ctrl.Result{}, err
@@ -209,6 +230,16 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 
 	if advancedCronJob.Spec.Paused != nil && *advancedCronJob.Spec.Paused {
 		klog.V(1).InfoS("AdvancedCronJob paused, skipping", "advancedCronJob", req)
+		return r.reportPausedRun(&advancedCronJob), nil
+	}
+
+	if complete {
+		klog.V(1).InfoS("One-time AdvancedCronJob complete, skipping", "advancedCronJob", req)
+		return ctrl.Result{}, nil
+	}
+
+	if invalidTimeZone {
+		klog.InfoS("AdvancedCronJob time zone can not be loaded, skipping until it is fixed", "advancedCronJob", req)
 		return ctrl.Result{}, nil
 	}
 
@@ -228,8 +259,11 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 		and the next run, so that we can know when it's time to reconcile again.
 	*/
 	getNextSchedule := func(cronJob *appsv1beta1.AdvancedCronJob, now time.Time) (lastMissed time.Time, next time.Time, err error) {
-		sched, err := cron.ParseStandard(formatSchedule(cronJob))
+		sched, err := getCachedSchedule(cronJob)
 		if err != nil {
+			if cronJob.Spec.ScheduleExpression != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("unparsable schedule expression %q: %v", *cronJob.Spec.ScheduleExpression, err)
+			}
 			return time.Time{}, time.Time{}, fmt.Errorf("unparsable schedule %q: %v", cronJob.Spec.Schedule, err)
 		}
 
@@ -242,20 +276,26 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 		} else {
 			earliestTime = cronJob.ObjectMeta.CreationTimestamp.Time
 		}
-		if cronJob.Spec.StartingDeadlineSeconds != nil {
+		if skipsLateRuns(cronJob) {
 			// controller is not going to schedule anything below this point
 			schedulingDeadline := now.Add(-time.Second * time.Duration(*cronJob.Spec.StartingDeadlineSeconds))
 
 			if schedulingDeadline.After(earliestTime) {
+				// the runs scheduled before the deadline can not be started any more
+				r.reportMissedDeadlineRuns(cronJob, sched, earliestTime, schedulingDeadline)
 				earliestTime = schedulingDeadline
 			}
+		} else {
+			// without a deadline, or starting the late runs under deadlinePolicy RunImmediately, don't try to catch up
+			// with the runs missed long ago
+			earliestTime = missedSchedulesLookbackStart(sched, earliestTime, now)
 		}
 		if earliestTime.After(now) {
 			return time.Time{}, sched.Next(now), nil
 		}
 
 		starts := 0
-		for t := sched.Next(earliestTime); !t.After(now); t = sched.Next(t) {
+		for t := sched.Next(earliestTime); !t.IsZero() && !t.After(now); t = sched.Next(t) {
 			lastMissed = t
 			// An object might miss several starts. For example, if
 			// controller gets wedged on Friday at 5:01pm when everyone has
@@ -283,7 +323,9 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 
 	// figure out the next times that we need to create jobs
 	now := r.Now()
+	_, scheduleSpan := r.startSpan(ctx, "GetNextSchedule", &advancedCronJob)
 	missedRun, nextRun, err := getNextSchedule(&advancedCronJob, now)
+	endSpan(scheduleSpan, err)
 	if err != nil {
 		klog.ErrorS(err, "Unable to figure out CronJob schedule", "advancedCronJob", req)
 		// we don't really care about requeuing until we get an update that
@@ -306,9 +348,18 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 		return scheduledResult, nil
 	}
 
+	_, suppressionSpan := r.startSpan(ctx, "IsSuppressed", &advancedCronJob)
+	suppressed, reason := IsSuppressed(&advancedCronJob, missedRun)
+	endSpan(suppressionSpan, nil, attribute.Bool("suppressed", suppressed), attribute.String("reason", reason))
+	if suppressed {
+		klog.V(1).InfoS("Run suppressed, sleeping till next run", "missedRun", missedRun, "reason", reason, "advancedCronJob", req)
+		r.reportSkippedRun(&advancedCronJob, missedRun, reason)
+		return scheduledResult, nil
+	}
+
 	// make sure we're not too late to start the run
 	tooLate := false
-	if advancedCronJob.Spec.StartingDeadlineSeconds != nil {
+	if skipsLateRuns(&advancedCronJob) {
 		tooLate = missedRun.Add(time.Duration(*advancedCronJob.Spec.StartingDeadlineSeconds) * time.Second).Before(now)
 	}
 	if tooLate {
@@ -316,6 +367,22 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 		return scheduledResult, nil
 	}
 
+	// the run is a no-op if its child was already created by a previous reconciliation
+	childName := getChildName(&advancedCronJob, missedRun)
+	if exists, err := r.childExists(ctx, &appsv1beta1.ImageListPullJob{}, advancedCronJob.Namespace, childName); err != nil {
+		klog.ErrorS(err, "Unable to get ImageListPullJob for AdvancedCronJob run", "job", klog.KRef(advancedCronJob.Namespace, childName), "advancedCronJob", req)
+		return ctrl.Result{}, err
+	} else if exists {
+		klog.V(1).InfoS("ImageListPullJob of AdvancedCronJob run already exists, skipping", "job", klog.KRef(advancedCronJob.Namespace, childName), "advancedCronJob", req)
+		return scheduledResult, nil
+	}
+
+	// a failure to create the child backs off until it expires, no matter what triggers the reconciliation
+	if backoff := childCreationBackoffRemaining(req.NamespacedName, advancedCronJob.Generation, now); backoff > 0 {
+		klog.V(1).InfoS("Backing off creating ImageListPullJob of AdvancedCronJob run", "backoff", backoff, "advancedCronJob", req)
+		return ctrl.Result{RequeueAfter: backoff}, nil
+	}
+
 	/*
 		If we actually have to run a job, we'll need to either wait till existing ones finish,
 		replace the existing ones, or just add new ones.  If our information is out of date due
@@ -349,9 +416,10 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 		to clean up jobs when we delete the CronJob, and allows controller-runtime to figure out
 		which cronjob needs to be reconciled when a given job changes (is added, deleted, completes, etc).
 	*/
-	constructImageListPullJobForCronJob := func(advancedCronJob *appsv1beta1.AdvancedCronJob, scheduledTime time.Time) (*appsv1beta1.ImageListPullJob, error) {
+	constructImageListPullJobForCronJob := func(ctx context.Context, advancedCronJob *appsv1beta1.AdvancedCronJob, scheduledTime time.Time) (*appsv1beta1.ImageListPullJob, error) {
+		_, span := r.startSpan(ctx, "ConstructChild", advancedCronJob)
 		// We want job names for a given nominal start time to have a deterministic name to avoid the same job being created twice
-		name := fmt.Sprintf("%s-%d", advancedCronJob.Name, scheduledTime.Unix())
+		name := getChildName(advancedCronJob, scheduledTime)
 
 		job := &appsv1beta1.ImageListPullJob{
 			ObjectMeta: metav1.ObjectMeta{
@@ -370,28 +438,42 @@ func (r *ReconcileAdvancedCronJob) reconcileImageListPullJob(ctx context.Context
 			job.Labels[k] = v
 		}
 		if err := ctrl.SetControllerReference(advancedCronJob, job, r.scheme); err != nil {
+			endSpan(span, err)
 			return nil, err
 		}
 
+		endSpan(span, nil, attribute.String("child", job.Name))
 		return job, nil
 	}
 	// +kubebuilder:docs-gen:collapse=constructJobForCronJob
 
 	// actually make the job...
-	job, err := constructImageListPullJobForCronJob(&advancedCronJob, missedRun)
+	createCtx, createSpan := r.startSpan(ctx, "CreateChild", &advancedCronJob)
+	job, err := constructImageListPullJobForCronJob(createCtx, &advancedCronJob, missedRun)
 	if err != nil {
+		endSpan(createSpan, err)
 		klog.ErrorS(err, "Unable to construct ImageListPullJob from template", "advancedCronJob", req)
 		// don't bother requeuing until we get a change to the spec
 		return scheduledResult, nil
 	}
 
 	// ...and create it on the cluster
-	if err := r.Create(ctx, job); err != nil {
+	err = r.Create(createCtx, job)
+	endSpan(createSpan, err, attribute.String("child", job.Name))
+	if errors.IsAlreadyExists(err) {
+		// created by a previous reconciliation of the same run, which childExists missed in the stale cache
+		klog.V(1).InfoS("ImageListPullJob of AdvancedCronJob run already exists, skipping", "job", klog.KObj(job), "advancedCronJob", req)
+		return scheduledResult, nil
+	}
+	if err != nil {
 		klog.ErrorS(err, "Unable to create ImageListPullJob for CronJob", "job", klog.KObj(job), "advancedCronJob", req)
-		return ctrl.Result{}, err
+		return r.childCreationFailed(req, &advancedCronJob, err)
 	}
 
 	klog.V(1).InfoS("Created ImageListPullJob for CronJob run", "job", klog.KObj(job), "advancedCronJob", req)
+	recordScheduleDrift(req.Namespace, missedRun, job.CreationTimestamp, now)
+	logSchedulingDecision(&advancedCronJob, decisionFired, "", missedRun, job.Name, now)
+	r.childCreationSucceeded(req, &advancedCronJob)
 
 	/*
 		### 7: Requeue when we either see a running job or it's time for the next scheduled run
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// SkippedRunReasonMissedDeadline means the run is not started within startingDeadlineSeconds.
+	SkippedRunReasonMissedDeadline = "missed_deadline"
+	// SkippedRunReasonPaused means the AdvancedCronJob is paused.
+	SkippedRunReasonPaused = "paused"
+	// SkippedRunReasonExcluded means the run is excluded by the scheduleExpression.
+	SkippedRunReasonExcluded = "excluded"
+	// SkippedRunReasonSkipDate means the run is on one of the skipDates.
+	SkippedRunReasonSkipDate = "skip_date"
+)
+
+var (
+	AdvancedCronJobSkippedRunsMetrics = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "advancedcronjob_skipped_runs_total",
+			Help: "The number of scheduled runs skipped by AdvancedCronJobs",
+		}, []string{"namespace", "reason"},
+	)
+
+	AdvancedCronJobScheduleDriftMetrics = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "advancedcronjob_schedule_drift_seconds",
+			Help:    "The seconds between the scheduled time of an AdvancedCronJob run and the creation of its child",
+			Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 3600},
+		}, []string{"namespace"},
+	)
+
+	// lastSkippedRuns is the scheduled time of the last skipped run of each AdvancedCronJob,
+	// so that a skipped run is counted only once no matter how many times it is reconciled.
+	lastSkippedRuns = struct {
+		sync.Mutex
+		times map[types.NamespacedName]time.Time
+	}{times: map[types.NamespacedName]time.Time{}}
+)
+
+func init() {
+	metrics.Registry.MustRegister(AdvancedCronJobSkippedRunsMetrics, AdvancedCronJobScheduleDriftMetrics)
+}
+
+// recordSkippedRun counts the skipped run with the reason, unless it has been counted before.
+// It returns whether the run is counted. The metric is counted by namespace to bound its series,
+// the skipped runs of each AdvancedCronJob are reported by its Events.
+func recordSkippedRun(key types.NamespacedName, scheduledTime time.Time, reason string) bool {
+	lastSkippedRuns.Lock()
+	defer lastSkippedRuns.Unlock()
+	if last, ok := lastSkippedRuns.times[key]; ok && !scheduledTime.After(last) {
+		return false
+	}
+	lastSkippedRuns.times[key] = scheduledTime
+	AdvancedCronJobSkippedRunsMetrics.WithLabelValues(key.Namespace, reason).Inc()
+	return true
+}
+
+// recordMissedDeadlineRuns counts the runs scheduled after earliestTime and not after schedulingDeadline
+// as skipped, looking back no further than missedSchedulesLookbackStart. It returns the scheduled times
+// of the runs counted.
+func recordMissedDeadlineRuns(key types.NamespacedName, sched cron.Schedule, earliestTime, schedulingDeadline time.Time) []time.Time {
+	var missed []time.Time
+	earliestTime = missedSchedulesLookbackStart(sched, earliestTime, schedulingDeadline)
+	for t := sched.Next(earliestTime); !t.IsZero() && !t.After(schedulingDeadline); t = sched.Next(t) {
+		if recordSkippedRun(key, t, SkippedRunReasonMissedDeadline) {
+			missed = append(missed, t)
+		}
+	}
+	return missed
+}
+
+// recordScheduleDrift observes the drift of the child of a run created at the creation timestamp.
+func recordScheduleDrift(namespace string, scheduledTime time.Time, creationTimestamp metav1.Time, now time.Time) {
+	AdvancedCronJobScheduleDriftMetrics.WithLabelValues(namespace).Observe(scheduleDrift(scheduledTime, creationTimestamp, now).Seconds())
+}
+
+// scheduleDrift returns how long after the scheduled time the child of a run is created,
+// the creation time is now if the child has no creation timestamp, e.g. the response of the
+// creation lacks it. A child stamped before the scheduled time by a skewed apiserver clock
+// has no drift.
+func scheduleDrift(scheduledTime time.Time, creationTimestamp metav1.Time, now time.Time) time.Duration {
+	createdTime := creationTimestamp.Time
+	if createdTime.IsZero() {
+		createdTime = now
+	}
+	if createdTime.Before(scheduledTime) {
+		return 0
+	}
+	return createdTime.Sub(scheduledTime)
+}
+
+// forgetSkippedRuns drops the skipped runs of a deleted AdvancedCronJob.
+func forgetSkippedRuns(key types.NamespacedName) {
+	lastSkippedRuns.Lock()
+	defer lastSkippedRuns.Unlock()
+	delete(lastSkippedRuns.times, key)
+}
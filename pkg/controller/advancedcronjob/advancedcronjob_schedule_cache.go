@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"sync"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// parsedSchedule is the result of parsing the schedule of a generation of an AdvancedCronJob.
+type parsedSchedule struct {
+	uid        types.UID
+	generation int64
+	schedule   cron.Schedule
+	err        error
+}
+
+// parsedSchedules caches the parsed schedule of each AdvancedCronJob, so that the schedule is parsed and
+// validated again only when the spec changes, which bumps the generation.
+var parsedSchedules = struct {
+	sync.Mutex
+	schedules map[types.NamespacedName]parsedSchedule
+}{schedules: map[types.NamespacedName]parsedSchedule{}}
+
+// getCachedSchedule returns the schedule of the AdvancedCronJob like getSchedule, reusing the result
+// of the same generation. An object without generation is not cached, its spec may change without notice.
+func getCachedSchedule(acj *appsv1beta1.AdvancedCronJob) (cron.Schedule, error) {
+	if acj.Generation == 0 {
+		return getSchedule(acj)
+	}
+	key := types.NamespacedName{Namespace: acj.Namespace, Name: acj.Name}
+	parsedSchedules.Lock()
+	defer parsedSchedules.Unlock()
+	if parsed, ok := parsedSchedules.schedules[key]; ok && parsed.uid == acj.UID && parsed.generation == acj.Generation {
+		return parsed.schedule, parsed.err
+	}
+	sched, err := getSchedule(acj)
+	parsedSchedules.schedules[key] = parsedSchedule{uid: acj.UID, generation: acj.Generation, schedule: sched, err: err}
+	return sched, err
+}
+
+// forgetParsedSchedule drops the parsed schedule of a deleted AdvancedCronJob.
+func forgetParsedSchedule(key types.NamespacedName) {
+	parsedSchedules.Lock()
+	defer parsedSchedules.Unlock()
+	delete(parsedSchedules.schedules, key)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
+)
+
+// ScheduleDiagnostics is the diagnosis of a cron schedule, for the tools to give feedback on it.
+type ScheduleDiagnostics struct {
+	// Schedule is the diagnosed schedule.
+	Schedule string
+	// Valid is true if the schedule parses and fires.
+	Valid bool
+	// NextFireTime is the first fire time of the schedule after the diagnosis.
+	NextFireTime time.Time
+	// Interval is the duration between the first two fire times, zero if the schedule fires only once.
+	Interval time.Duration
+	// Errors are the reasons why the schedule is invalid.
+	Errors []string
+}
+
+// DiagnoseSchedule parses the schedule in the time zone and evaluates its fire times after now.
+func DiagnoseSchedule(schedule string, timeZone *string, now time.Time) ScheduleDiagnostics {
+	diagnostics := ScheduleDiagnostics{Schedule: schedule}
+	sched, err := parseScheduleSafely(schedule, timeZone)
+	if err != nil {
+		diagnostics.Errors = append(diagnostics.Errors, err.Error())
+		return diagnostics
+	}
+
+	diagnostics.NextFireTime = sched.Next(now)
+	if diagnostics.NextFireTime.IsZero() {
+		diagnostics.Errors = append(diagnostics.Errors, "schedule never fires")
+		return diagnostics
+	}
+	diagnostics.Interval = ScheduleInterval(sched, now)
+	diagnostics.Valid = true
+	return diagnostics
+}
+
+// ValidateSchedules diagnoses each of the schedules in the time zone after now, the diagnostics are in
+// the order of the schedules.
+func ValidateSchedules(schedules []string, timeZone *string) []ScheduleDiagnostics {
+	return validateSchedules(schedules, timeZone, time.Now())
+}
+
+func validateSchedules(schedules []string, timeZone *string, now time.Time) []ScheduleDiagnostics {
+	diagnostics := make([]ScheduleDiagnostics, len(schedules))
+	for i, schedule := range schedules {
+		diagnostics[i] = DiagnoseSchedule(schedule, timeZone, now)
+	}
+	return diagnostics
+}
+
+// previewTimeLayout is the layout of the fire times in the preview table, schedules fire at most once a minute.
+const previewTimeLayout = "Mon 2006-01-02 15:04 MST"
+
+// PreviewTable renders the next n fire times of the AdvancedCronJob after now as a table, in the time zone it is
+// scheduled in and in UTC, for the CLI tools. The runs the controller skips are listed with the reason.
+func PreviewTable(acj *appsv1beta1.AdvancedCronJob, n int) (string, error) {
+	return previewTable(acj, time.Now(), n)
+}
+
+func previewTable(acj *appsv1beta1.AdvancedCronJob, now time.Time, n int) (string, error) {
+	schedules, err := Schedules(acj)
+	if err != nil {
+		return "", err
+	}
+	loc, err := webhookutil.ResolveLocation(acj)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "#\tTIME (%s)\tTIME (UTC)\tSKIPPED\n", loc)
+	t := now
+	for i := 1; i <= n; i++ {
+		var next time.Time
+		for _, sched := range schedules {
+			if fireTime := sched.Next(t); !fireTime.IsZero() && (next.IsZero() || fireTime.Before(next)) {
+				next = fireTime
+			}
+		}
+		if next.IsZero() {
+			break
+		}
+		_, reason := IsSuppressed(acj, next)
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", i, next.In(loc).Format(previewTimeLayout), next.UTC().Format(previewTimeLayout), reason)
+		t = next
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// FiresWithin returns whether the schedule in the time zone fires in [from, from+window], and the first fire
+// time in it. An invalid schedule never fires.
+func FiresWithin(schedule string, timeZone *string, from time.Time, window time.Duration) (bool, time.Time) {
+	sched, err := parseScheduleSafely(schedule, timeZone)
+	if err != nil {
+		return false, time.Time{}
+	}
+	// the cron schedules fire strictly after the given time, start right before from to include it
+	next := sched.Next(from.Add(-time.Nanosecond))
+	if next.IsZero() || next.After(from.Add(window)) {
+		return false, time.Time{}
+	}
+	return true, next
+}
+
+// parseScheduleSafely parses the schedule like parseSchedule, converting the panics of the cron parser
+// on malformed schedules to errors.
+func parseScheduleSafely(schedule string, timeZone *string) (sched cron.Schedule, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid cron schedule: %v", r)
+		}
+	}()
+
+	if len(schedule) == 0 {
+		return nil, fmt.Errorf("schedule cannot be empty")
+	}
+	if _, err := NormalizeSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return parseSchedule(schedule, timeZone)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// maxExclusionHorizon bounds how far the excluded fire times are skipped before an expression
+	// is considered to never fire, like the five years searched by the cron schedules.
+	maxExclusionHorizon = 5 * 365 * 24 * time.Hour
+)
+
+var (
+	scheduleExpressionExceptRegex = regexp.MustCompile(`(?i)\s+except\s+`)
+	scheduleExpressionOrRegex     = regexp.MustCompile(`(?i)\s+or\s+`)
+)
+
+// ScheduleExpression is the parsed form of spec.scheduleExpression, a standard cron schedule
+// with the clauses excluding some of its fire times.
+type ScheduleExpression struct {
+	// Schedule is the standard cron schedule, with normalized names.
+	Schedule string
+	// Exclusions are the predicates of the excluded fire times.
+	Exclusions []SchedulePredicate
+}
+
+// SchedulePredicate matches the fire times excluded by a clause of a schedule expression.
+type SchedulePredicate interface {
+	// Matches reports whether the fire time, in the location of the schedule, is excluded.
+	Matches(t time.Time) bool
+	String() string
+}
+
+// weekdayOfMonthPredicate matches the first or the last given weekday of a month.
+type weekdayOfMonthPredicate struct {
+	last    bool
+	weekday time.Weekday
+}
+
+func (p weekdayOfMonthPredicate) Matches(t time.Time) bool {
+	if t.Weekday() != p.weekday {
+		return false
+	}
+	if p.last {
+		return t.AddDate(0, 0, 7).Month() != t.Month()
+	}
+	return t.Day() <= 7
+}
+
+func (p weekdayOfMonthPredicate) String() string {
+	if p.last {
+		return "last " + scheduleDayOfWeekNames[p.weekday]
+	}
+	return "first " + scheduleDayOfWeekNames[p.weekday]
+}
+
+// dayOfMonthPredicate matches a day of month.
+type dayOfMonthPredicate struct {
+	day int
+}
+
+func (p dayOfMonthPredicate) Matches(t time.Time) bool {
+	return t.Day() == p.day
+}
+
+func (p dayOfMonthPredicate) String() string {
+	return fmt.Sprintf("day %d", p.day)
+}
+
+// lastDayOfMonthPredicate matches the last day of a month.
+type lastDayOfMonthPredicate struct{}
+
+func (p lastDayOfMonthPredicate) Matches(t time.Time) bool {
+	return t.AddDate(0, 0, 1).Month() != t.Month()
+}
+
+func (p lastDayOfMonthPredicate) String() string {
+	return "lastday"
+}
+
+// datePredicate matches a calendar date.
+type datePredicate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func (p datePredicate) Matches(t time.Time) bool {
+	year, month, day := t.Date()
+	return year == p.year && month == p.month && day == p.day
+}
+
+func (p datePredicate) String() string {
+	return fmt.Sprintf("date %04d-%02d-%02d", p.year, p.month, p.day)
+}
+
+// ParseScheduleExpression parses an expression like "0 9 * * MON-FRI except last FRI or day 1".
+// The schedule part may have a TZ or CRON_TZ prefix like spec.schedule.
+func ParseScheduleExpression(expression string) (*ScheduleExpression, error) {
+	parts := scheduleExpressionExceptRegex.Split(strings.TrimSpace(expression), 2)
+	schedule, err := NormalizeSchedule(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return nil, fmt.Errorf("invalid cron schedule %q: %v", parts[0], err)
+	}
+
+	expr := &ScheduleExpression{Schedule: schedule}
+	if len(parts) == 1 {
+		return expr, nil
+	}
+	for _, clause := range scheduleExpressionOrRegex.Split(parts[1], -1) {
+		predicate, err := parseSchedulePredicate(clause)
+		if err != nil {
+			return nil, err
+		}
+		expr.Exclusions = append(expr.Exclusions, predicate)
+	}
+	return expr, nil
+}
+
+func parseSchedulePredicate(clause string) (SchedulePredicate, error) {
+	fields := strings.Fields(clause)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty exclusion clause")
+	}
+
+	keyword := strings.ToLower(fields[0])
+	switch {
+	case keyword == "lastday" && len(fields) == 1:
+		return lastDayOfMonthPredicate{}, nil
+	case (keyword == "first" || keyword == "last") && len(fields) == 2:
+		for i, name := range scheduleDayOfWeekNames {
+			if strings.EqualFold(fields[1], name) {
+				return weekdayOfMonthPredicate{last: keyword == "last", weekday: time.Weekday(i)}, nil
+			}
+		}
+		return nil, fmt.Errorf("unrecognized day-of-week name %q in clause %q, valid names are: %s",
+			fields[1], clause, strings.Join(scheduleDayOfWeekNames, ", "))
+	case keyword == "day" && len(fields) == 2:
+		day, err := strconv.Atoi(fields[1])
+		if err != nil || day < 1 || day > 31 {
+			return nil, fmt.Errorf("invalid day of month %q in clause %q, must be in 1-31", fields[1], clause)
+		}
+		return dayOfMonthPredicate{day: day}, nil
+	case keyword == "date" && len(fields) == 2:
+		date, err := time.Parse("2006-01-02", fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q in clause %q, must be in YYYY-MM-DD format", fields[1], clause)
+		}
+		return datePredicate{year: date.Year(), month: date.Month(), day: date.Day()}, nil
+	}
+	return nil, fmt.Errorf("unrecognized exclusion clause %q, valid clauses are: first <day-of-week>, last <day-of-week>, day <day-of-month>, lastday, date <YYYY-MM-DD>", clause)
+}
+
+// Compile returns the cron schedule of the expression in the given time zone, which is ignored
+// if the schedule has an embedded TZ or CRON_TZ.
+func (e *ScheduleExpression) Compile(timeZone *string) (cron.Schedule, error) {
+	sched, err := parseSchedule(e.Schedule, timeZone)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.Local
+	if spec, ok := sched.(*cron.SpecSchedule); ok {
+		loc = spec.Location
+	}
+	return &expressionSchedule{schedule: sched, location: loc, exclusions: e.Exclusions}, nil
+}
+
+// expressionSchedule skips the fire times of the cron schedule matched by any exclusion.
+type expressionSchedule struct {
+	schedule   cron.Schedule
+	location   *time.Location
+	exclusions []SchedulePredicate
+}
+
+// Next returns the next fire time after t which is not excluded, or the zero time if there is none within
+// maxExclusionHorizon. The exclusions match whole days, so the rest of an excluded day is skipped at once,
+// however densely the schedule fires.
+func (s *expressionSchedule) Next(t time.Time) time.Time {
+	horizon := t.Add(maxExclusionHorizon)
+	next := s.schedule.Next(t)
+	for !next.IsZero() && !next.After(horizon) {
+		local := next.In(s.location)
+		if !s.excluded(local) {
+			return next
+		}
+		year, month, day := local.Date()
+		nextDay := time.Date(year, month, day+1, 0, 0, 0, 0, s.location)
+		// the schedules fire at least a second after the given time
+		next = s.schedule.Next(nextDay.Add(-time.Second))
+	}
+	return time.Time{}
+}
+
+func (s *expressionSchedule) excluded(t time.Time) bool {
+	for _, predicate := range s.exclusions {
+		if predicate.Matches(t) {
+			return true
+		}
+	}
+	return false
+}
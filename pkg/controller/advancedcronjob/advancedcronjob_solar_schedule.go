@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+const (
+	julianDayUnixEpoch = 2440587.5
+	julianDayJ2000     = 2451545.0
+
+	// solarEventAltitude is the altitude of the sun center at sunrise and sunset in degrees, corrected
+	// for the atmospheric refraction and the radius of the sun.
+	solarEventAltitude = -0.833
+	// earthObliquity is the axial tilt of the earth in degrees.
+	earthObliquity = 23.4397
+
+	// maxSolarScheduleDays bounds how many days are searched for the next solar event, a year covers
+	// the polar nights and days.
+	maxSolarScheduleDays = 370
+)
+
+// solarSchedule fires daily at the sunrise or sunset of a location, shifted by the offset.
+type solarSchedule struct {
+	sunrise   bool
+	offset    time.Duration
+	latitude  float64
+	longitude float64
+}
+
+// NewSolarSchedule returns the cron schedule of spec.solarSchedule.
+func NewSolarSchedule(spec *appsv1beta1.SolarSchedule) (cron.Schedule, error) {
+	s := solarSchedule{}
+	switch spec.Event {
+	case appsv1beta1.SunriseSolarEvent:
+		s.sunrise = true
+	case appsv1beta1.SunsetSolarEvent:
+	default:
+		return nil, fmt.Errorf("unknown solar event %q, should be %s or %s", spec.Event, appsv1beta1.SunriseSolarEvent, appsv1beta1.SunsetSolarEvent)
+	}
+	if spec.Offset != nil {
+		s.offset = spec.Offset.Duration
+	}
+
+	var err error
+	if s.latitude, err = ParseCoordinate(spec.Latitude, 90); err != nil {
+		return nil, fmt.Errorf("invalid latitude: %v", err)
+	}
+	if s.longitude, err = ParseCoordinate(spec.Longitude, 180); err != nil {
+		return nil, fmt.Errorf("invalid longitude: %v", err)
+	}
+	return s, nil
+}
+
+// ParseCoordinate parses a latitude or longitude in decimal degrees, which must be in [-limit, limit].
+func ParseCoordinate(value string, limit float64) (float64, error) {
+	degrees, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a decimal number", value)
+	}
+	if math.IsNaN(degrees) || degrees < -limit || degrees > limit {
+		return 0, fmt.Errorf("%q is out of range [-%v, %v]", value, limit, limit)
+	}
+	return degrees, nil
+}
+
+// Next returns the first event after t shifted by the offset, or the zero time if the event does not
+// happen in maxSolarScheduleDays.
+func (s solarSchedule) Next(t time.Time) time.Time {
+	// the event of a day may happen on the UTC day before or after it far from the prime meridian,
+	// and the offset may shift it further, so start searching two days before
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -2)
+	for i := 0; i < maxSolarScheduleDays; i++ {
+		if event, ok := solarEventTime(day.AddDate(0, 0, i), s.latitude, s.longitude, s.sunrise); ok {
+			if next := event.Add(s.offset); next.After(t) {
+				return next.In(t.Location())
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// solarEventTime calculates the sunrise or sunset of the day at the location by the sunrise equation,
+// see https://en.wikipedia.org/wiki/Sunrise_equation. It is accurate to about a minute outside the polar
+// regions. It returns false if the sun does not rise or set on the day.
+func solarEventTime(day time.Time, latitude, longitude float64, sunrise bool) (time.Time, bool) {
+	julianDay := float64(day.Unix())/float64(24*60*60) + julianDayUnixEpoch
+	n := math.Ceil(julianDay - julianDayJ2000 + 0.0008)
+	meanSolarTime := n - longitude/360
+
+	meanAnomaly := math.Mod(357.5291+0.98560028*meanSolarTime, 360)
+	m := degreesToRadians(meanAnomaly)
+	center := 1.9148*math.Sin(m) + 0.0200*math.Sin(2*m) + 0.0003*math.Sin(3*m)
+	eclipticLongitude := degreesToRadians(math.Mod(meanAnomaly+center+180+102.9372, 360))
+	transit := julianDayJ2000 + meanSolarTime + 0.0053*math.Sin(m) - 0.0069*math.Sin(2*eclipticLongitude)
+
+	sinDeclination := math.Sin(eclipticLongitude) * math.Sin(degreesToRadians(earthObliquity))
+	cosDeclination := math.Cos(math.Asin(sinDeclination))
+	phi := degreesToRadians(latitude)
+	cosHourAngle := (math.Sin(degreesToRadians(solarEventAltitude)) - math.Sin(phi)*sinDeclination) / (math.Cos(phi) * cosDeclination)
+	if cosHourAngle < -1 || cosHourAngle > 1 {
+		// polar day or polar night
+		return time.Time{}, false
+	}
+
+	hourAngle := math.Acos(cosHourAngle) * 180 / math.Pi / 360
+	event := transit + hourAngle
+	if sunrise {
+		event = transit - hourAngle
+	}
+	seconds := (event - julianDayUnixEpoch) * 24 * 60 * 60
+	return time.Unix(int64(math.Round(seconds)), 0).UTC(), true
+}
+
+func degreesToRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package advancedcronjob
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+const tracerName = "github.com/openkruise/kruise/pkg/controller/advancedcronjob"
+
+// enableTracing emits the spans of reconciling AdvancedCronJobs to the global OpenTelemetry tracer provider, which
+// is installed by SetupTracerProvider.
+var enableTracing bool
+
+// SetupTracerProvider installs the global tracer provider exporting the spans over OTLP gRPC if tracing is enabled,
+// configured by the standard OTEL_EXPORTER_OTLP_* environment variables, and returns the function flushing and
+// shutting it down. It must be called before the controller is set up.
+func SetupTracerProvider(ctx context.Context) (func(context.Context) error, error) {
+	if !enableTracing {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newTracer returns the tracer of the global tracer provider if tracing is enabled, or else a no-op one.
+func newTracer() oteltrace.Tracer {
+	if enableTracing {
+		return otel.GetTracerProvider().Tracer(tracerName)
+	}
+	return noop.NewTracerProvider().Tracer(tracerName)
+}
+
+func (r *ReconcileAdvancedCronJob) getTracer() oteltrace.Tracer {
+	if r.tracer == nil {
+		return noop.NewTracerProvider().Tracer(tracerName)
+	}
+	return r.tracer
+}
+
+// startSpan starts a span of reconciling the AdvancedCronJob, with the attributes identifying it.
+func (r *ReconcileAdvancedCronJob) startSpan(ctx context.Context, name string, acj *appsv1beta1.AdvancedCronJob) (context.Context, oteltrace.Span) {
+	return r.getTracer().Start(ctx, name, oteltrace.WithAttributes(
+		attribute.String("namespace", acj.Namespace),
+		attribute.String("name", acj.Name),
+		attribute.String("templateKind", string(FindTemplateKind(acj.Spec))),
+	))
+}
+
+// endSpan ends the span with the attributes, and marks it failed if err is not nil.
+func endSpan(span oteltrace.Span, err error, attrs ...attribute.KeyValue) {
+	span.SetAttributes(attrs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -2,12 +2,58 @@ package advancedcronjob
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
-	"k8s.io/klog/v2"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
+)
+
+// BroadcastJobAllowConcurrentMaxNodes is the max number of nodes of the cluster in which BroadcastJob templates can
+// use concurrencyPolicy Allow, works with AdvancedCronJobLimitBroadcastConcurrency feature-gate, 0 means
+// Allow is restricted in any cluster.
+var BroadcastJobAllowConcurrentMaxNodes = 0
+
+// RestrictsBroadcastJobAllowConcurrent reports whether concurrencyPolicy Allow is restricted for BroadcastJob
+// templates in a cluster of the number of nodes, which is larger than maxNodes, as each overlapping run adds a pod
+// to every node.
+func RestrictsBroadcastJobAllowConcurrent(nodes, maxNodes int) bool {
+	return utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobLimitBroadcastConcurrency) &&
+		nodes > maxNodes
+}
+
+// SkipDateLayout is the layout of the dates in spec.skipDates.
+const SkipDateLayout = "2006-01-02"
+
+var (
+	scheduleMonthNames     = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	scheduleDayOfWeekNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+	scheduleNameRegex      = regexp.MustCompile(`[a-zA-Z]+`)
+
+	// scheduleEquivalenceSamples is the number of fire times compared by SchedulesEquivalent.
+	scheduleEquivalenceSamples = 100
+
+	// scheduleIntervalAttempts bounds the pairs of fire times ScheduleInterval measures for a positive interval.
+	scheduleIntervalAttempts = 10
+
+	// fireDensityWindow is the window sampled by PeakFiresPerHour, a week covers the schedules varying by day of week.
+	fireDensityWindow = 7 * 24 * time.Hour
+	// maxFireTimes bounds the fire times walked from each schedule in case a schedule never passes the end,
+	// it covers a day of @every 1s, the finest interval of the schedules.
+	maxFireTimes = 24 * 60 * 60
+
+	// AllowedCompletionPolicyTypes are the completionPolicy types supported by the
+	// ImageListPullJob template of AdvancedCronJob.
+	AllowedCompletionPolicyTypes = sets.New[appsv1beta1.CompletionPolicyType](appsv1beta1.Always)
 )
 
 func FindTemplateKind(spec appsv1beta1.AdvancedCronJobSpec) appsv1beta1.TemplateKind {
@@ -22,16 +68,615 @@ func FindTemplateKind(spec appsv1beta1.AdvancedCronJobSpec) appsv1beta1.Template
 	return appsv1beta1.BroadcastJobTemplate
 }
 
+// ResolveTemplateKind returns the kind of the template of the AdvancedCronJob, or an error unless exactly one
+// template is set, which is the invariant enforced by the webhook.
+func ResolveTemplateKind(spec appsv1beta1.AdvancedCronJobSpec) (appsv1beta1.TemplateKind, error) {
+	var kinds []appsv1beta1.TemplateKind
+	if spec.Template.JobTemplate != nil {
+		kinds = append(kinds, appsv1beta1.JobTemplate)
+	}
+	if spec.Template.BroadcastJobTemplate != nil {
+		kinds = append(kinds, appsv1beta1.BroadcastJobTemplate)
+	}
+	if spec.Template.ImageListPullJobTemplate != nil {
+		kinds = append(kinds, appsv1beta1.ImageListPullJobTemplate)
+	}
+	switch len(kinds) {
+	case 0:
+		return "", fmt.Errorf("no template is set, one of jobTemplate, broadcastJobTemplate and imageListPullJobTemplate is required")
+	case 1:
+		return kinds[0], nil
+	default:
+		return "", fmt.Errorf("%d templates are set, only one of jobTemplate, broadcastJobTemplate and imageListPullJobTemplate is allowed", len(kinds))
+	}
+}
+
+// ValidateCompletionPolicyType returns an error if the completionPolicy type is not one of AllowedCompletionPolicyTypes.
+func ValidateCompletionPolicyType(t appsv1beta1.CompletionPolicyType) error {
+	if AllowedCompletionPolicyTypes.Has(t) {
+		return nil
+	}
+	allowed := make([]string, 0, AllowedCompletionPolicyTypes.Len())
+	for _, allowedType := range sets.List(AllowedCompletionPolicyTypes) {
+		allowed = append(allowed, string(allowedType))
+	}
+	return fmt.Errorf("completionPolicy should be %s, but current value is: %s", strings.Join(allowed, " or "), t)
+}
+
 func formatSchedule(acj *appsv1beta1.AdvancedCronJob) string {
-	if strings.Contains(acj.Spec.Schedule, "TZ") {
-		return acj.Spec.Schedule
+	schedule, err := NormalizeSchedule(acj.Spec.Schedule)
+	if err != nil {
+		// leave the original schedule to the cron parser, which reports the error
+		schedule = acj.Spec.Schedule
+	}
+	loc, err := webhookutil.ResolveLocation(acj)
+	if err != nil {
+		// reported by parseStandardSchedule, which refuses to parse the schedule in the local time zone instead
+		return schedule
+	}
+	if _, ok := webhookutil.ParseEmbeddedTimeZone(schedule); ok || loc == time.Local {
+		return schedule
+	}
+	return fmt.Sprintf("TZ=%s %s", loc, schedule)
+}
+
+// NormalizeSchedule upper-cases the month and day-of-week names of a standard cron schedule,
+// so that the webhook and the controller parse exactly the same schedule.
+// It returns an error with the valid names if an unrecognized name is found.
+// Descriptors like @daily and schedules with an unexpected number of fields are returned
+// as they are and left to the cron parser.
+func NormalizeSchedule(schedule string) (string, error) {
+	prefix, spec := "", schedule
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		i := strings.Index(spec, " ")
+		if i < 0 {
+			return schedule, nil
+		}
+		prefix, spec = spec[:i+1], strings.TrimSpace(spec[i:])
+	}
+	if strings.HasPrefix(spec, "@") {
+		return schedule, nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return schedule, nil
+	}
+	var err error
+	if fields[3], err = normalizeScheduleNames(fields[3], "month", scheduleMonthNames); err != nil {
+		return schedule, err
+	}
+	if fields[4], err = normalizeScheduleNames(fields[4], "day-of-week", scheduleDayOfWeekNames); err != nil {
+		return schedule, err
+	}
+	return prefix + strings.Join(fields, " "), nil
+}
+
+func normalizeScheduleNames(field, fieldName string, validNames []string) (string, error) {
+	valid := sets.NewString(validNames...)
+	var err error
+	normalized := scheduleNameRegex.ReplaceAllStringFunc(field, func(name string) string {
+		upper := strings.ToUpper(name)
+		if !valid.Has(upper) && err == nil {
+			err = fmt.Errorf("unrecognized %s name %q, valid names are: %s", fieldName, name, strings.Join(validNames, ", "))
+		}
+		return upper
+	})
+	return normalized, err
+}
+
+// parseSchedule parses a standard cron schedule in the given time zone,
+// which is ignored if the schedule has an embedded TZ or CRON_TZ.
+func parseSchedule(schedule string, timeZone *string) (cron.Schedule, error) {
+	if timeZone != nil {
+		if _, err := webhookutil.LoadLocation(*timeZone); err != nil {
+			return nil, err
+		}
+	}
+	acj := &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{Schedule: schedule, TimeZone: timeZone}}
+	return parseStandardSchedule(acj)
+}
+
+// ScheduleFormat is the format the schedule of an AdvancedCronJob is specified in.
+type ScheduleFormat string
+
+const (
+	// ScheduleFormatCron is a standard cron schedule of five fields in spec.schedule.
+	ScheduleFormatCron ScheduleFormat = "Cron"
+	// ScheduleFormatDescriptor is a predefined schedule like @daily in spec.schedule.
+	ScheduleFormatDescriptor ScheduleFormat = "Descriptor"
+	// ScheduleFormatInterval is a fixed interval like @every 90m in spec.schedule.
+	ScheduleFormatInterval ScheduleFormat = "Interval"
+	// ScheduleFormatExpression is a cron schedule with exclusion clauses in spec.scheduleExpression.
+	ScheduleFormatExpression ScheduleFormat = "Expression"
+	// ScheduleFormatRunAt is the only run at spec.runAt.
+	ScheduleFormatRunAt ScheduleFormat = "RunAt"
+	// ScheduleFormatSolar is the daily solar event of spec.solarSchedule.
+	ScheduleFormatSolar ScheduleFormat = "Solar"
+)
+
+// ResolvedSchedule is the schedule an AdvancedCronJob effectively runs by.
+type ResolvedSchedule struct {
+	// Schedule is the normalized cron schedule without the TZ= or CRON_TZ= prefix, or the cron schedule of
+	// spec.scheduleExpression without the exclusions, empty for runAt and solarSchedule.
+	Schedule string
+	// Location is the location the schedule fires in, see webhookutil.ResolveLocation.
+	Location *time.Location
+	// Format is the format the schedule is specified in.
+	Format ScheduleFormat
+	// Interval reports whether the schedule fires at a fixed interval, which does not depend on the location.
+	Interval bool
+}
+
+// ResolveSchedule resolves the schedule and the location the AdvancedCronJob runs by, in the same precedence as
+// getSchedule, so that the webhook and the controller make the same decisions. The cron schedule is normalized but
+// not parsed, the invalid fields are reported by the cron parser.
+func ResolveSchedule(acj *appsv1beta1.AdvancedCronJob) (ResolvedSchedule, error) {
+	loc, err := webhookutil.ResolveLocation(acj)
+	if err != nil {
+		return ResolvedSchedule{}, fmt.Errorf("provided bad location: %v", err)
+	}
+	resolved := ResolvedSchedule{Location: loc}
+	schedule := acj.Spec.Schedule
+	switch {
+	case acj.Spec.RunAt != nil:
+		resolved.Format = ScheduleFormatRunAt
+		return resolved, nil
+	case acj.Spec.SolarSchedule != nil:
+		resolved.Format = ScheduleFormatSolar
+		return resolved, nil
+	case acj.Spec.ScheduleExpression != nil:
+		expr, err := ParseScheduleExpression(*acj.Spec.ScheduleExpression)
+		if err != nil {
+			return ResolvedSchedule{}, err
+		}
+		resolved.Format, schedule = ScheduleFormatExpression, expr.Schedule
+	}
+
+	schedule = strings.TrimSpace(schedule)
+	if _, ok := webhookutil.ParseEmbeddedTimeZone(schedule); ok {
+		_, schedule, _ = strings.Cut(schedule, " ")
+		schedule = strings.TrimSpace(schedule)
+	}
+	if resolved.Schedule, err = NormalizeSchedule(schedule); err != nil {
+		return ResolvedSchedule{}, err
+	}
+	resolved.Interval = strings.HasPrefix(resolved.Schedule, "@every ")
+	if resolved.Format == "" {
+		switch {
+		case resolved.Interval:
+			resolved.Format = ScheduleFormatInterval
+		case strings.HasPrefix(resolved.Schedule, "@"):
+			resolved.Format = ScheduleFormatDescriptor
+		default:
+			resolved.Format = ScheduleFormatCron
+		}
+	}
+	return resolved, nil
+}
+
+// parseStandardSchedule parses the schedule of the AdvancedCronJob like cron.ParseStandard, but takes the location
+// from ResolveSchedule, instead of letting the cron parser load it from the zoneinfo on every call.
+// It fails if the location can not be resolved.
+func parseStandardSchedule(acj *appsv1beta1.AdvancedCronJob) (cron.Schedule, error) {
+	resolved, err := ResolveSchedule(acj)
+	if err != nil {
+		return nil, err
+	}
+	sched, err := cron.ParseStandard(resolved.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if spec, ok := sched.(*cron.SpecSchedule); ok {
+		spec.Location = resolved.Location
+	}
+	return sched, nil
+}
+
+// getSchedule returns the cron schedule of the AdvancedCronJob, which fires only once at spec.runAt
+// if it is set, or at the solar events of spec.solarSchedule if it is set, or is evaluated from
+// spec.scheduleExpression if it is set, or else parsed from spec.schedule.
+func getSchedule(acj *appsv1beta1.AdvancedCronJob) (cron.Schedule, error) {
+	if acj.Spec.RunAt != nil {
+		return runAtSchedule{runAt: acj.Spec.RunAt.Time}, nil
+	}
+	if acj.Spec.SolarSchedule != nil {
+		return NewSolarSchedule(acj.Spec.SolarSchedule)
+	}
+	if acj.Spec.ScheduleExpression != nil {
+		expr, err := ParseScheduleExpression(*acj.Spec.ScheduleExpression)
+		if err != nil {
+			return nil, err
+		}
+		return expr.Compile(acj.Spec.TimeZone)
+	}
+	return parseStandardSchedule(acj)
+}
+
+// Schedules returns the cron schedules whose fire times together make up the runs of the AdvancedCronJob.
+func Schedules(acj *appsv1beta1.AdvancedCronJob) ([]cron.Schedule, error) {
+	sched, err := getSchedule(acj)
+	if err != nil {
+		return nil, err
+	}
+	return []cron.Schedule{sched}, nil
+}
+
+// PeakFiresPerHour returns the max number of times the schedules fire in a clock hour combined, sampling
+// their fire times in fireDensityWindow from the given time. A time fired by several schedules is counted once.
+func PeakFiresPerHour(schedules []cron.Schedule, from time.Time) int {
+	end := from.Add(fireDensityWindow)
+	fireTimes := sets.New[int64]()
+	for _, sched := range schedules {
+		t := from
+		for i := 0; i < maxFireTimes; i++ {
+			t = sched.Next(t)
+			if t.IsZero() || !t.Before(end) {
+				break
+			}
+			fireTimes.Insert(t.Unix())
+		}
+	}
+
+	peak := 0
+	firesPerHour := map[int64]int{}
+	for fireTime := range fireTimes {
+		hour := fireTime / int64(time.Hour/time.Second)
+		firesPerHour[hour]++
+		if firesPerHour[hour] > peak {
+			peak = firesPerHour[hour]
+		}
+	}
+	return peak
+}
+
+// FiresPerDay returns the number of times the schedules fire in the 24 hours after the given time combined.
+// A time fired by several schedules is counted once.
+func FiresPerDay(schedules []cron.Schedule, from time.Time) int {
+	return len(FireTimes(schedules, from, from.Add(24*time.Hour)))
+}
+
+// FireTimes returns the times the schedules fire after from until end inclusive combined, in ascending order.
+// A time fired by several schedules is returned once.
+func FireTimes(schedules []cron.Schedule, from, end time.Time) []time.Time {
+	fireTimes := map[int64]time.Time{}
+	for _, sched := range schedules {
+		t := from
+		for i := 0; i < maxFireTimes; i++ {
+			if t = sched.Next(t); t.IsZero() || t.After(end) {
+				break
+			}
+			fireTimes[t.Unix()] = t
+		}
 	}
-	if acj.Spec.TimeZone != nil {
-		if _, err := time.LoadLocation(*acj.Spec.TimeZone); err != nil {
-			klog.ErrorS(err, "Failed to load location for advancedCronJob", "location", *acj.Spec.TimeZone, "advancedCronJob", klog.KObj(acj))
-			return acj.Spec.Schedule
+	sorted := make([]time.Time, 0, len(fireTimes))
+	for _, unix := range sets.List(sets.KeySet(fireTimes)) {
+		sorted = append(sorted, fireTimes[unix])
+	}
+	return sorted
+}
+
+// MinFireInterval returns the shortest duration between two consecutive fire times of the schedules combined,
+// sampling their fire times in fireDensityWindow from the given time. It returns zero if less than two fire
+// times are sampled.
+func MinFireInterval(schedules []cron.Schedule, from time.Time) time.Duration {
+	end := from.Add(fireDensityWindow)
+	fireTimes := sets.New[int64]()
+	for _, sched := range schedules {
+		t := from
+		for i := 0; i < maxFireTimes; i++ {
+			t = sched.Next(t)
+			if t.IsZero() || !t.Before(end) {
+				break
+			}
+			fireTimes.Insert(t.Unix())
+		}
+	}
+
+	var interval time.Duration
+	sorted := sets.List(fireTimes)
+	for i := 1; i < len(sorted); i++ {
+		if d := time.Duration(sorted[i]-sorted[i-1]) * time.Second; interval == 0 || d < interval {
+			interval = d
+		}
+	}
+	return interval
+}
+
+// runAtSchedule fires only once at spec.runAt.
+type runAtSchedule struct {
+	runAt time.Time
+}
+
+func (s runAtSchedule) Next(t time.Time) time.Time {
+	if t.Before(s.runAt) {
+		return s.runAt
+	}
+	return time.Time{}
+}
+
+// updateCompleteCondition sets the Complete condition of a one-time AdvancedCronJob, which is complete once its
+// run at spec.runAt is scheduled and finished, and stays complete after the children are cleaned up by the history
+// limits, until runAt is moved after the completion. It returns whether the AdvancedCronJob is complete.
+func updateCompleteCondition(acj *appsv1beta1.AdvancedCronJob, now time.Time) bool {
+	condition := getAdvancedCronJobCondition(acj.Status, appsv1beta1.AdvancedCronJobConditionComplete)
+	if acj.Spec.RunAt == nil {
+		return false
+	}
+	fired := acj.Status.LastScheduleTime != nil && !acj.Status.LastScheduleTime.Before(acj.Spec.RunAt)
+	wasComplete := condition != nil && condition.Status == corev1.ConditionTrue && acj.Spec.RunAt.Before(&condition.LastTransitionTime)
+	if len(acj.Status.Active) == 0 && (fired || wasComplete) {
+		setAdvancedCronJobCondition(&acj.Status, appsv1beta1.AdvancedCronJobCondition{
+			Type:    appsv1beta1.AdvancedCronJobConditionComplete,
+			Status:  corev1.ConditionTrue,
+			Reason:  "RunFinished",
+			Message: fmt.Sprintf("the run at %s has finished", acj.Spec.RunAt.Format(time.RFC3339)),
+		}, now)
+		return true
+	}
+	if condition != nil {
+		setAdvancedCronJobCondition(&acj.Status, appsv1beta1.AdvancedCronJobCondition{
+			Type:   appsv1beta1.AdvancedCronJobConditionComplete,
+			Status: corev1.ConditionFalse,
+			Reason: "RunPending",
+		}, now)
+	}
+	return false
+}
+
+// updateInvalidTimeZoneCondition sets the InvalidTimeZone condition of the AdvancedCronJob if the location it is
+// scheduled in can not be resolved, so that it stops scheduling rather than firing in the local time zone of the
+// controller, and clears the condition once it is fixed. It returns whether the time zone is invalid.
+func updateInvalidTimeZoneCondition(acj *appsv1beta1.AdvancedCronJob, now time.Time) bool {
+	condition := getAdvancedCronJobCondition(acj.Status, appsv1beta1.AdvancedCronJobConditionInvalidTimeZone)
+	if acj.Spec.RunAt == nil && acj.Spec.SolarSchedule == nil {
+		if _, err := webhookutil.ResolveLocation(acj); err != nil {
+			setAdvancedCronJobCondition(&acj.Status, appsv1beta1.AdvancedCronJobCondition{
+				Type:    appsv1beta1.AdvancedCronJobConditionInvalidTimeZone,
+				Status:  corev1.ConditionTrue,
+				Reason:  "LoadLocationFailed",
+				Message: err.Error(),
+			}, now)
+			return true
+		}
+	}
+	if condition != nil && condition.Status == corev1.ConditionTrue {
+		setAdvancedCronJobCondition(&acj.Status, appsv1beta1.AdvancedCronJobCondition{
+			Type:   appsv1beta1.AdvancedCronJobConditionInvalidTimeZone,
+			Status: corev1.ConditionFalse,
+			Reason: "LocationLoaded",
+		}, now)
+	}
+	return false
+}
+
+// updateNextRunDSTWarning warns in the status if the next run of the AdvancedCronJob from now is within an hour of
+// a daylight saving time transition of the location it is scheduled in, see dstTransitionMessage.
+func updateNextRunDSTWarning(acj *appsv1beta1.AdvancedCronJob, now time.Time) {
+	acj.Status.NextRunDSTWarning = false
+	acj.Status.NextRunDSTMessage = ""
+	if acj.Spec.Paused != nil && *acj.Spec.Paused {
+		return
+	}
+	sched, err := getCachedSchedule(acj)
+	if err != nil {
+		return
+	}
+	loc, err := webhookutil.ResolveLocation(acj)
+	if err != nil {
+		return
+	}
+	next := sched.Next(now)
+	if next.IsZero() {
+		return
+	}
+	if message := dstTransitionMessage(next, loc); message != "" {
+		acj.Status.NextRunDSTWarning = true
+		acj.Status.NextRunDSTMessage = message
+	}
+}
+
+// dstTransitionMessage returns why a run at t is at risk if it is within an hour of a daylight saving time
+// transition of loc, or an empty string otherwise. The runs scheduled by the wall clock in the hour skipped
+// when the clocks spring forward may be skipped, and those in the hour repeated when they fall back may run twice.
+func dstTransitionMessage(t time.Time, loc *time.Location) string {
+	t = t.In(loc)
+	_, offsetBefore := t.Add(-time.Hour).Zone()
+	_, offsetAfter := t.Add(time.Hour).Zone()
+	switch {
+	case offsetAfter > offsetBefore:
+		return fmt.Sprintf("the next run at %s is within an hour of the clocks springing forward in %s, the runs scheduled in the skipped hour may be skipped",
+			t.Format(time.RFC3339), loc)
+	case offsetAfter < offsetBefore:
+		return fmt.Sprintf("the next run at %s is within an hour of the clocks falling back in %s, the runs scheduled in the repeated hour may run twice",
+			t.Format(time.RFC3339), loc)
+	}
+	return ""
+}
+
+// IsSuppressed reports whether a run of the AdvancedCronJob at t is suppressed, either because the
+// AdvancedCronJob is paused, or t is excluded by its scheduleExpression or on one of its skipDates. The reason is the label of
+// the skipped-runs metric, i.e. SkippedRunReasonPaused, SkippedRunReasonExcluded or SkippedRunReasonSkipDate.
+// The controllers decide the last run of a paused AdvancedCronJob with it, see reportPausedRun, and the due runs
+// otherwise, so that the pause takes precedence over the other reasons.
+func IsSuppressed(acj *appsv1beta1.AdvancedCronJob, t time.Time) (bool, string) {
+	if acj.Spec.Paused != nil && *acj.Spec.Paused {
+		return true, SkippedRunReasonPaused
+	}
+	if acj.Spec.ScheduleExpression != nil {
+		sched, err := getSchedule(acj)
+		if err != nil {
+			return false, ""
+		}
+		if expr, ok := sched.(*expressionSchedule); ok && expr.excluded(t.In(expr.location)) {
+			return true, SkippedRunReasonExcluded
+		}
+	}
+	if len(acj.Spec.SkipDates) > 0 {
+		loc, err := webhookutil.ResolveLocation(acj)
+		if err != nil {
+			loc = t.Location()
+		}
+		date := t.In(loc).Format(SkipDateLayout)
+		for _, skipDate := range acj.Spec.SkipDates {
+			if skipDate == date {
+				return true, SkippedRunReasonSkipDate
+			}
+		}
+	}
+	return false, ""
+}
+
+// ScheduleInterval returns the interval between the next two fire times of the schedule after t, or zero if
+// the schedule fires less than twice. A fire time not after the previous one, which a schedule in a time zone may
+// produce around the DST fall-back, would give a non-positive interval, so the interval is measured on the
+// following pairs of fire times instead, up to scheduleIntervalAttempts pairs.
+func ScheduleInterval(sched cron.Schedule, t time.Time) time.Duration {
+	next := sched.Next(t)
+	for i := 0; i < scheduleIntervalAttempts && !next.IsZero(); i++ {
+		following := sched.Next(next)
+		if following.IsZero() {
+			return 0
+		}
+		if interval := following.Sub(next); interval > 0 {
+			return interval
+		}
+		next = following
+	}
+	return 0
+}
+
+// skipsLateRuns reports whether the runs not started within spec.startingDeadlineSeconds are skipped, which is the
+// case unless spec.deadlinePolicy is RunImmediately, under which the most recent missed run is started however late.
+func skipsLateRuns(acj *appsv1beta1.AdvancedCronJob) bool {
+	return acj.Spec.StartingDeadlineSeconds != nil && acj.Spec.DeadlinePolicy != appsv1beta1.RunImmediatelyDeadlinePolicy
+}
+
+// missedSchedulesLookbackStart bounds the earliest time to look for missed runs by
+// missedSchedulesLookbackIntervals times the interval between the next two fire times, so that the
+// AdvancedCronJob catches up with the most recent run instead of failing on too many missed runs,
+// e.g. after a long downtime or being restored from a backup.
+func missedSchedulesLookbackStart(sched cron.Schedule, earliestTime, now time.Time) time.Time {
+	if missedSchedulesLookbackIntervals <= 0 {
+		return earliestTime
+	}
+	interval := ScheduleInterval(sched, now)
+	if interval <= 0 {
+		return earliestTime
+	}
+	lookbackStart := now.Add(-interval * time.Duration(missedSchedulesLookbackIntervals))
+	if lookbackStart.After(earliestTime) {
+		return lookbackStart
+	}
+	return earliestTime
+}
+
+// lastScheduledTime returns the last fire time of the schedule not after now since the last run of the
+// AdvancedCronJob, or its creation, looking back no further than missedSchedulesLookbackStart. It returns zero
+// if the schedule has not fired since.
+func lastScheduledTime(acj *appsv1beta1.AdvancedCronJob, sched cron.Schedule, now time.Time) time.Time {
+	earliestTime := acj.CreationTimestamp.Time
+	if acj.Status.LastScheduleTime != nil {
+		earliestTime = acj.Status.LastScheduleTime.Time
+	}
+	var last time.Time
+	for t := sched.Next(missedSchedulesLookbackStart(sched, earliestTime, now)); !t.IsZero() && !t.After(now); t = sched.Next(t) {
+		last = t
+	}
+	return last
+}
+
+// ScheduleChanged reports whether the update of the AdvancedCronJob changes when it runs after now, like
+// webhookutil.ScheduleChanged, except that a rewrite of the schedule firing at the same times,
+// e.g. "0 0 * * *" to "@daily", is not a change.
+func ScheduleChanged(obj, oldObj *appsv1beta1.AdvancedCronJob, now time.Time) bool {
+	if !webhookutil.ScheduleChanged(obj, oldObj) {
+		return false
+	}
+	rewritten := oldObj.DeepCopy()
+	rewritten.Spec.Schedule = obj.Spec.Schedule
+	if webhookutil.ScheduleChanged(obj, rewritten) {
+		// a field other than the schedule is changed
+		return true
+	}
+	equivalent, err := schedulesEquivalent(obj.Spec.Schedule, oldObj.Spec.Schedule, obj.Spec.TimeZone, now)
+	return err != nil || !equivalent
+}
+
+// SchedulesEquivalent reports whether two schedules fire at the same times, by comparing their
+// next fire times after now, e.g. "0 0 * * *" and "@daily" are equivalent.
+func SchedulesEquivalent(a, b string, tz *string) (bool, error) {
+	return schedulesEquivalent(a, b, tz, time.Now())
+}
+
+func schedulesEquivalent(a, b string, tz *string, from time.Time) (bool, error) {
+	if a == b {
+		return true, nil
+	}
+	schedA, err := parseSchedule(a, tz)
+	if err != nil {
+		return false, fmt.Errorf("unparsable schedule %q: %v", a, err)
+	}
+	schedB, err := parseSchedule(b, tz)
+	if err != nil {
+		return false, fmt.Errorf("unparsable schedule %q: %v", b, err)
+	}
+
+	nextA, nextB := from, from
+	for i := 0; i < scheduleEquivalenceSamples; i++ {
+		nextA, nextB = schedA.Next(nextA), schedB.Next(nextB)
+		if !nextA.Equal(nextB) {
+			return false, nil
+		}
+		if nextA.IsZero() {
+			break
+		}
+	}
+	return true, nil
+}
+
+// getChildName returns the name of the child created for the scheduled time. The name is deterministic
+// so that the same run is never created twice. It must not depend on the fields updatable in the spec,
+// like the schedule, or an update during an active run would create the run again under another name.
+func getChildName(acj *appsv1beta1.AdvancedCronJob, scheduledTime time.Time) string {
+	return fmt.Sprintf("%s-%d", acj.Name, scheduledTime.Unix())
+}
+
+// childRun is the state of a child job, keyed by its scheduled time.
+type childRun struct {
+	scheduledTime time.Time
+	active        bool
+	failed        bool
+}
+
+// countConsecutiveFailures counts the finished runs scheduled after lastCounted on top of previous, a failed run
+// increments the count and a successful one resets it. The finished children are cleaned up by the history limits,
+// so the count is kept in the status instead of rebuilt from the children, and it returns the scheduled time of the
+// most recent run counted, so that the children still listed are not counted again. An earlier run may finish after
+// a later one under concurrencyPolicy Allow, so the runs scheduled after a run still active are held until it
+// finishes, and are counted in schedule order then. The count starts over from the listed runs if none was counted
+// before.
+func countConsecutiveFailures(runs []childRun, previous int32, lastCounted *metav1.Time) (int32, *metav1.Time) {
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].scheduledTime.Before(runs[j].scheduledTime)
+	})
+	failures := previous
+	if lastCounted == nil {
+		failures = 0
+	}
+	for _, run := range runs {
+		if lastCounted != nil && !run.scheduledTime.After(lastCounted.Time) {
+			continue
+		}
+		if run.active {
+			break
+		}
+		if run.failed {
+			failures++
+		} else {
+			failures = 0
 		}
-		return fmt.Sprintf("TZ=%s %s", *acj.Spec.TimeZone, acj.Spec.Schedule)
+		lastCounted = &metav1.Time{Time: run.scheduledTime}
 	}
-	return acj.Spec.Schedule
+	return failures, lastCounted
 }
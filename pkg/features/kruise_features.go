@@ -150,6 +150,67 @@ const (
 	// Under this feature, kruise will think all legal pod-vertical-scaling actions must success.
 	// PodUnavailableBudget will specifically protect the resize actions of individual Pods.
 	InPlacePodVerticalScaling featuregate.Feature = "InPlacePodVerticalScaling"
+
+	// AdvancedCronJobRequireResourceRequests enables AdvancedCronJob webhook to reject Job and BroadcastJob templates
+	// whose containers do not set cpu and memory requests.
+	AdvancedCronJobRequireResourceRequests featuregate.Feature = "AdvancedCronJobRequireResourceRequests"
+
+	// AdvancedCronJobLimitImageRegistries enables AdvancedCronJob webhook to reject ImageListPullJob templates
+	// whose images are pulled from more registries than the configured maximum.
+	AdvancedCronJobLimitImageRegistries featuregate.Feature = "AdvancedCronJobLimitImageRegistries"
+
+	// AdvancedCronJobImagePullBudgetWarning enables AdvancedCronJob webhook to warn about ImageListPullJob templates
+	// whose activeDeadlineSeconds leaves too little time to pull each image.
+	AdvancedCronJobImagePullBudgetWarning featuregate.Feature = "AdvancedCronJobImagePullBudgetWarning"
+
+	// AdvancedCronJobDeprecatedFieldValidation enables AdvancedCronJob webhook to flag the deprecated fields used by
+	// Job and BroadcastJob templates, as warnings or errors by the configured severity.
+	AdvancedCronJobDeprecatedFieldValidation featuregate.Feature = "AdvancedCronJobDeprecatedFieldValidation"
+
+	// AdvancedCronJobSolarSchedule enables AdvancedCronJobs to be scheduled relative to sunrise or sunset
+	// of a location by spec.solarSchedule.
+	AdvancedCronJobSolarSchedule featuregate.Feature = "AdvancedCronJobSolarSchedule"
+
+	// AdvancedCronJobLimitRunResources enables AdvancedCronJob webhook to reject Job and BroadcastJob templates
+	// whose runs request more resources than the configured budget.
+	AdvancedCronJobLimitRunResources featuregate.Feature = "AdvancedCronJobLimitRunResources"
+
+	// AdvancedCronJobValidateOwnerReferences enables AdvancedCronJob webhook to reject the controller owner
+	// references whose kinds are not registered in the scheme.
+	AdvancedCronJobValidateOwnerReferences featuregate.Feature = "AdvancedCronJobValidateOwnerReferences"
+
+	// AdvancedCronJobAPIResourceWarnings enables AdvancedCronJob webhook to warn about the features used by Job and
+	// BroadcastJob templates whose API resources are not served by the cluster.
+	AdvancedCronJobAPIResourceWarnings featuregate.Feature = "AdvancedCronJobAPIResourceWarnings"
+
+	// AdvancedCronJobScheduleEditCooldown enables AdvancedCronJob webhook to record the time the schedule is modified,
+	// and to reject the modifications of the schedule within a cooldown from the last one.
+	AdvancedCronJobScheduleEditCooldown featuregate.Feature = "AdvancedCronJobScheduleEditCooldown"
+
+	// AdvancedCronJobRejectMixedImageRefs enables AdvancedCronJob webhook to reject the ImageListPullJob
+	// templates referencing a repository by both tag and digest.
+	AdvancedCronJobRejectMixedImageRefs featuregate.Feature = "AdvancedCronJobRejectMixedImageRefs"
+
+	// AdvancedCronJobNodeOSWarning enables AdvancedCronJob webhook to warn about ImageListPullJob templates
+	// pulling on every node with no selector, whose platform-specific images fail on the nodes of the other OS.
+	AdvancedCronJobNodeOSWarning featuregate.Feature = "AdvancedCronJobNodeOSWarning"
+
+	// AdvancedCronJobPrePullWarning enables AdvancedCronJob webhook to warn about the images of Job and BroadcastJob
+	// templates not pulled by any ImageListPullJob in the namespace, which pre-pulls the other images.
+	AdvancedCronJobPrePullWarning featuregate.Feature = "AdvancedCronJobPrePullWarning"
+
+	// AdvancedCronJobVolumeRefWarning enables AdvancedCronJob webhook to warn about the ConfigMaps, Secrets and
+	// PersistentVolumeClaims referenced by the volumes of Job and BroadcastJob templates not found in the namespace.
+	AdvancedCronJobVolumeRefWarning featuregate.Feature = "AdvancedCronJobVolumeRefWarning"
+
+	// AdvancedCronJobLimitBroadcastConcurrency enables AdvancedCronJob webhook to reject concurrencyPolicy Allow for
+	// BroadcastJob templates in the clusters with more nodes than advancedcronjob-broadcastjob-allow-concurrency-max-nodes,
+	// and AdvancedCronJob controller to run the existing ones as Forbid, since the overlapping runs load every node.
+	AdvancedCronJobLimitBroadcastConcurrency featuregate.Feature = "AdvancedCronJobLimitBroadcastConcurrency"
+
+	// AdvancedCronJobSecurityContextBaseline enables AdvancedCronJob webhook to reject Job and BroadcastJob templates
+	// whose containers do not meet the security context baseline, e.g. runAsNonRoot.
+	AdvancedCronJobSecurityContextBaseline featuregate.Feature = "AdvancedCronJobSecurityContextBaseline"
 )
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -190,6 +251,21 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	EnablePodProbeMarkerOnServerless:         {Default: false, PreRelease: featuregate.Alpha},
 	EnableSortSidecarContainerByName:         {Default: false, PreRelease: featuregate.Alpha},
 	InPlacePodVerticalScaling:                {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobRequireResourceRequests:   {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobLimitImageRegistries:      {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobImagePullBudgetWarning:    {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobDeprecatedFieldValidation: {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobSolarSchedule:             {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobLimitRunResources:         {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobValidateOwnerReferences:   {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobAPIResourceWarnings:       {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobScheduleEditCooldown:      {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobRejectMixedImageRefs:      {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobNodeOSWarning:             {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobPrePullWarning:            {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobVolumeRefWarning:          {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobLimitBroadcastConcurrency: {Default: false, PreRelease: featuregate.Alpha},
+	AdvancedCronJobSecurityContextBaseline:   {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func init() {
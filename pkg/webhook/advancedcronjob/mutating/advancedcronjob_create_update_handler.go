@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,10 +32,18 @@ import (
 	"github.com/openkruise/kruise/apis/apps/defaults"
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/controller/advancedcronjob"
 	"github.com/openkruise/kruise/pkg/features"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 )
 
+// tzdataVersion returns the version of the time zone database the AdvancedCronJobs are validated with.
+var tzdataVersion = webhookutil.TZDataVersion
+
+// now returns the time the schedules of the AdvancedCronJobs are modified at.
+var now = time.Now
+
 // BroadcastJobCreateUpdateHandler handles BroadcastJob
 type AdvancedCronJobCreateUpdateHandler struct {
 	// To use the client, you need to do the following:
@@ -69,25 +79,29 @@ func (h *AdvancedCronJobCreateUpdateHandler) Handle(ctx context.Context, req adm
 	}
 	var copy runtime.Object = obj.DeepCopy()
 
+	var oldObj *appsv1beta1.AdvancedCronJob
+	if req.AdmissionRequest.Operation == admissionv1.Update {
+		oldObj = &appsv1beta1.AdvancedCronJob{}
+		var oldObjv1alpha1 *appsv1alpha1.AdvancedCronJob
+		switch req.AdmissionRequest.Resource.Version {
+		case appsv1beta1.GroupVersion.Version:
+			if err := h.Decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+				return admission.Errored(http.StatusBadRequest, err)
+			}
+		case appsv1alpha1.GroupVersion.Version:
+			oldObjv1alpha1 = &appsv1alpha1.AdvancedCronJob{}
+			if err := h.Decoder.DecodeRaw(req.OldObject, oldObjv1alpha1); err != nil {
+				return admission.Errored(http.StatusBadRequest, err)
+			}
+			if err := oldObjv1alpha1.ConvertTo(oldObj); err != nil {
+				return admission.Errored(http.StatusBadRequest, fmt.Errorf("failed to convert v1alpha1->v1beta1: %v", err))
+			}
+		}
+	}
+
 	injectTemplateDefaults := false
 	if !utilfeature.DefaultFeatureGate.Enabled(features.TemplateNoDefaults) {
-		if req.AdmissionRequest.Operation == admissionv1.Update {
-			oldObj := &appsv1beta1.AdvancedCronJob{}
-			var oldObjv1alpha1 *appsv1alpha1.AdvancedCronJob
-			switch req.AdmissionRequest.Resource.Version {
-			case appsv1beta1.GroupVersion.Version:
-				if err := h.Decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
-					return admission.Errored(http.StatusBadRequest, err)
-				}
-			case appsv1alpha1.GroupVersion.Version:
-				oldObjv1alpha1 = &appsv1alpha1.AdvancedCronJob{}
-				if err := h.Decoder.DecodeRaw(req.OldObject, oldObjv1alpha1); err != nil {
-					return admission.Errored(http.StatusBadRequest, err)
-				}
-				if err := oldObjv1alpha1.ConvertTo(oldObj); err != nil {
-					return admission.Errored(http.StatusBadRequest, fmt.Errorf("failed to convert v1alpha1->v1beta1: %v", err))
-				}
-			}
+		if oldObj != nil {
 			if !reflect.DeepEqual(obj.Spec.Template, oldObj.Spec.Template) {
 				injectTemplateDefaults = true
 			}
@@ -96,6 +110,13 @@ func (h *AdvancedCronJobCreateUpdateHandler) Handle(ctx context.Context, req adm
 		}
 	}
 	defaults.SetDefaultsAdvancedCronJob(obj, injectTemplateDefaults)
+	// keep the skipDates in the canonical order, which is the chronological order of the dates in YYYY-MM-DD
+	sort.Strings(obj.Spec.SkipDates)
+	stampTZDataVersion(obj)
+	// the annotation is stripped on create even without the cooldown, so that it can not be forged before the cooldown is enabled
+	if oldObj == nil || utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobScheduleEditCooldown) {
+		stampScheduleModified(obj, oldObj)
+	}
 	obj.Status = appsv1beta1.AdvancedCronJobStatus{}
 
 	var err error
@@ -121,6 +142,49 @@ func (h *AdvancedCronJobCreateUpdateHandler) Handle(ctx context.Context, req adm
 	return resp
 }
 
+// stampTZDataVersion records the version of the time zone database in the annotation of the AdvancedCronJob
+// scheduled in an explicit time zone, so that the controller can tell if it resolves the time zone differently.
+func stampTZDataVersion(obj *appsv1beta1.AdvancedCronJob) {
+	version := tzdataVersion()
+	if version == "" {
+		return
+	}
+	if loc, err := webhookutil.ResolveLocation(obj); err != nil || loc == time.Local {
+		return
+	}
+	if obj.Annotations == nil {
+		obj.Annotations = map[string]string{}
+	}
+	obj.Annotations[appsv1beta1.AdvancedCronJobTZDataVersionAnnotation] = version
+}
+
+// stampScheduleModified records the time the schedule of the AdvancedCronJob is modified in the annotation, for the
+// cooldown of the modifications. The annotation is managed by the webhook, so the time recorded by the last
+// modification is kept if the schedule is not modified, and the annotation set by the user on create is stripped.
+func stampScheduleModified(obj, oldObj *appsv1beta1.AdvancedCronJob) {
+	if oldObj == nil {
+		delete(obj.Annotations, appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation)
+		return
+	}
+	modifiedAt := now()
+	if advancedcronjob.ScheduleChanged(obj, oldObj, modifiedAt) {
+		if obj.Annotations == nil {
+			obj.Annotations = map[string]string{}
+		}
+		obj.Annotations[appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation] = modifiedAt.UTC().Format(time.RFC3339)
+		return
+	}
+	modified, ok := oldObj.Annotations[appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation]
+	if !ok {
+		delete(obj.Annotations, appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation)
+		return
+	}
+	if obj.Annotations == nil {
+		obj.Annotations = map[string]string{}
+	}
+	obj.Annotations[appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation] = modified
+}
+
 // var _ inject.Client = &BroadcastJobCreateUpdateHandler{}
 //
 // // InjectClient injects the client into the BroadcastJobCreateUpdateHandler
@@ -21,7 +21,9 @@ import (
 	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
+	jsonpatchapply "github.com/evanphx/json-patch"
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -30,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/openkruise/kruise/apis"
@@ -110,6 +113,11 @@ func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
 					Path:      "/spec/failedJobsHistoryLimit",
 					Value:     float64(1),
 				},
+				{
+					Operation: "add",
+					Path:      "/spec/deadlinePolicy",
+					Value:     "Skip",
+				},
 				{
 					Operation: "add",
 					Path:      "/spec/template/jobTemplate/spec/template/spec/containers/0/imagePullPolicy",
@@ -210,6 +218,11 @@ func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
 					Path:      "/spec/failedJobsHistoryLimit",
 					Value:     float64(1),
 				},
+				{
+					Operation: "add",
+					Path:      "/spec/deadlinePolicy",
+					Value:     "Skip",
+				},
 				{
 					Operation: "add",
 					Path:      "/spec/template/broadcastJobTemplate/spec/template/spec/containers/0/imagePullPolicy",
@@ -310,6 +323,11 @@ func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
 					Path:      "/spec/failedJobsHistoryLimit",
 					Value:     float64(1),
 				},
+				{
+					Operation: "add",
+					Path:      "/spec/deadlinePolicy",
+					Value:     "Skip",
+				},
 				{
 					Operation: "add",
 					Path:      "/spec/template/jobTemplate/spec/template/spec/containers/0/imagePullPolicy",
@@ -369,6 +387,7 @@ func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
 								Paused:                     func() *bool { b := true; return &b }(),
 								SuccessfulJobsHistoryLimit: func() *int32 { i := int32(5); return &i }(),
 								FailedJobsHistoryLimit:     func() *int32 { i := int32(2); return &i }(),
+								DeadlinePolicy:             appsv1beta1.SkipDeadlinePolicy,
 								Template: appsv1beta1.CronJobTemplate{
 									JobTemplate: &batchv1.JobTemplateSpec{
 										Spec: batchv1.JobSpec{
@@ -522,6 +541,11 @@ func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
 					Path:      "/spec/failedJobsHistoryLimit",
 					Value:     float64(1),
 				},
+				{
+					Operation: "add",
+					Path:      "/spec/deadlinePolicy",
+					Value:     "Skip",
+				},
 				{
 					Operation: "add",
 					Path:      "/spec/template/jobTemplate/spec/template/spec/containers/0/imagePullPolicy",
@@ -650,6 +674,11 @@ func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
 					Path:      "/spec/failedJobsHistoryLimit",
 					Value:     float64(1),
 				},
+				{
+					Operation: "add",
+					Path:      "/spec/deadlinePolicy",
+					Value:     "Skip",
+				},
 			},
 		},
 		{
@@ -733,6 +762,175 @@ func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
 	}
 }
 
+func TestAdvancedCronJobCreateUpdateHandler_SortSkipDates(t *testing.T) {
+	utilruntime.Must(apis.AddToScheme(scheme.Scheme))
+
+	handler := AdvancedCronJobCreateUpdateHandler{
+		Decoder: admission.NewDecoder(scheme.Scheme),
+	}
+	raw := createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-acj",
+			Namespace: "default",
+		},
+		Spec: appsv1beta1.AdvancedCronJobSpec{
+			Schedule:  "0 0 * * *",
+			SkipDates: []string{"2026-01-01", "2025-12-25"},
+			Template: appsv1beta1.CronJobTemplate{
+				JobTemplate: &batchv1.JobTemplateSpec{},
+			},
+		},
+	})
+	response := handler.Handle(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Resource: metav1.GroupVersionResource{
+				Group:    appsv1beta1.GroupVersion.Group,
+				Version:  appsv1beta1.GroupVersion.Version,
+				Resource: "advancedcronjobs",
+			},
+			Object: runtime.RawExtension{Raw: raw},
+		},
+	})
+	if !response.Allowed {
+		t.Fatalf("expected allowed response but got error: %s", response.Result.Message)
+	}
+
+	// the patches of a reordered array depend on the diff algorithm, so check the patched object instead
+	patches, err := json.Marshal(response.Patches)
+	if err != nil {
+		t.Fatalf("failed to marshal patches: %v", err)
+	}
+	patch, err := jsonpatchapply.DecodePatch(patches)
+	if err != nil {
+		t.Fatalf("failed to decode patches: %v", err)
+	}
+	patched, err := patch.Apply(raw)
+	if err != nil {
+		t.Fatalf("failed to apply patches: %v", err)
+	}
+	acj := &appsv1beta1.AdvancedCronJob{}
+	if err := json.Unmarshal(patched, acj); err != nil {
+		t.Fatalf("failed to unmarshal patched AdvancedCronJob: %v", err)
+	}
+	if expected := []string{"2025-12-25", "2026-01-01"}; !reflect.DeepEqual(expected, acj.Spec.SkipDates) {
+		t.Errorf("expected skipDates %v, got %v", expected, acj.Spec.SkipDates)
+	}
+}
+
+func TestStampTZDataVersion(t *testing.T) {
+	defer func(f func() string) { tzdataVersion = f }(tzdataVersion)
+
+	cases := []struct {
+		name            string
+		version         string
+		schedule        string
+		timeZone        *string
+		expectedVersion string
+	}{
+		{
+			name:            "spec.timeZone",
+			version:         "2025b",
+			schedule:        "0 0 * * *",
+			timeZone:        pointer.String("Asia/Shanghai"),
+			expectedVersion: "2025b",
+		},
+		{
+			name:            "embedded time zone",
+			version:         "2025b",
+			schedule:        "CRON_TZ=Asia/Shanghai 0 0 * * *",
+			expectedVersion: "2025b",
+		},
+		{
+			name:     "local time zone",
+			version:  "2025b",
+			schedule: "0 0 * * *",
+		},
+		{
+			name:     "unknown version",
+			schedule: "0 0 * * *",
+			timeZone: pointer.String("Asia/Shanghai"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tzdataVersion = func() string { return tc.version }
+			obj := &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule, TimeZone: tc.timeZone}}
+			stampTZDataVersion(obj)
+			if version := obj.Annotations[appsv1beta1.AdvancedCronJobTZDataVersionAnnotation]; version != tc.expectedVersion {
+				t.Fatalf("expected tzdata version %q, got %q", tc.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestStampScheduleModified(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time { return time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC) }
+	lastModified := "2025-10-10T08:00:00Z"
+
+	cases := []struct {
+		name             string
+		create           bool
+		oldAnnotation    string
+		schedule         string
+		annotation       string
+		expectedModified string
+	}{
+		{
+			name:             "schedule modified",
+			oldAnnotation:    lastModified,
+			schedule:         "*/5 * * * *",
+			expectedModified: "2025-10-10T09:00:00Z",
+		},
+		{
+			name:             "schedule not modified",
+			oldAnnotation:    lastModified,
+			schedule:         "0 0 * * *",
+			expectedModified: lastModified,
+		},
+		{
+			name:             "annotation modified without the schedule",
+			oldAnnotation:    lastModified,
+			schedule:         "0 0 * * *",
+			annotation:       "2020-01-01T00:00:00Z",
+			expectedModified: lastModified,
+		},
+		{
+			name:       "annotation added without the schedule",
+			schedule:   "0 0 * * *",
+			annotation: "2020-01-01T00:00:00Z",
+		},
+		{
+			name:       "annotation set on create",
+			create:     true,
+			schedule:   "0 0 * * *",
+			annotation: "2099-01-01T00:00:00Z",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldObj := &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{Schedule: "0 0 * * *"}}
+			if tc.oldAnnotation != "" {
+				oldObj.Annotations = map[string]string{appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation: tc.oldAnnotation}
+			}
+			obj := &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule}}
+			if tc.annotation != "" {
+				obj.Annotations = map[string]string{appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation: tc.annotation}
+			}
+			if tc.create {
+				oldObj = nil
+			}
+			stampScheduleModified(obj, oldObj)
+			if modified := obj.Annotations[appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation]; modified != tc.expectedModified {
+				t.Fatalf("expected schedule modified at %q, got %q", tc.expectedModified, modified)
+			}
+		})
+	}
+}
+
 func createAdvancedCronJobV1Beta1JSON(t *testing.T, acj *appsv1beta1.AdvancedCronJob) []byte {
 	data, err := json.Marshal(acj)
 	if err != nil {
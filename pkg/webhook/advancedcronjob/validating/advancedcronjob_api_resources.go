@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+// templateAPIResource is a feature of the pod template which works only if the cluster serves the API resource.
+type templateAPIResource struct {
+	feature      string
+	groupVersion string
+	resource     string
+	// usedAt returns the path of the first field of the pod spec at fldPath using the feature, or nil.
+	usedAt func(podSpec *v1.PodSpec, fldPath *field.Path) *field.Path
+}
+
+// templateAPIResources is the registry of the features checked against the API resources of the cluster
+// in Job and BroadcastJob templates. Ephemeral containers are not listed, they are forbidden in pod templates.
+var templateAPIResources = []templateAPIResource{
+	{
+		feature:      "in-place pod resize",
+		groupVersion: "v1",
+		resource:     "pods/resize",
+		usedAt: func(podSpec *v1.PodSpec, fldPath *field.Path) *field.Path {
+			for i := range podSpec.Containers {
+				if len(podSpec.Containers[i].ResizePolicy) > 0 {
+					return fldPath.Child("containers").Index(i).Child("resizePolicy")
+				}
+			}
+			return nil
+		},
+	},
+}
+
+// apiResourceWarnings warns about the features used by the Job or BroadcastJob template whose API resources
+// are not served by the cluster, which would be ignored or rejected when the pods are created.
+func (h *AdvancedCronJobCreateUpdateHandler) apiResourceWarnings(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path) []string {
+	var warnings []string
+	if !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobAPIResourceWarnings) || h.DiscoveryClient == nil {
+		return warnings
+	}
+	podSpec, podSpecPath := templatePodSpec(spec, fldPath)
+	if podSpec == nil {
+		return warnings
+	}
+
+	served := map[string]map[string]bool{}
+	for _, r := range templateAPIResources {
+		path := r.usedAt(podSpec, podSpecPath)
+		if path == nil {
+			continue
+		}
+		if _, ok := served[r.groupVersion]; !ok {
+			resourceList, err := h.DiscoveryClient.ServerResourcesForGroupVersion(r.groupVersion)
+			if err != nil {
+				klog.ErrorS(err, "Failed to discover API resources", "groupVersion", r.groupVersion)
+				continue
+			}
+			served[r.groupVersion] = map[string]bool{}
+			for _, apiResource := range resourceList.APIResources {
+				served[r.groupVersion][apiResource.Name] = true
+			}
+		}
+		if !served[r.groupVersion][r.resource] {
+			warnings = append(warnings, fmt.Sprintf("%s: %s is not supported by the cluster, which does not serve %s in %s",
+				path, r.feature, r.resource, r.groupVersion))
+		}
+	}
+	return warnings
+}
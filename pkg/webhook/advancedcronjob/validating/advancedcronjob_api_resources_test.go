@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+func TestAPIResourceWarnings(t *testing.T) {
+	newSpec := func(resizePolicy []v1.ContainerResizePolicy) *appsv1beta1.AdvancedCronJobSpec {
+		template := createValidPodTemplateSpec()
+		template.Spec.Containers[0].ResizePolicy = resizePolicy
+		return &appsv1beta1.AdvancedCronJobSpec{
+			Template: appsv1beta1.CronJobTemplate{
+				JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}},
+			},
+		}
+	}
+	newDiscovery := func(resources ...string) *fakediscovery.FakeDiscovery {
+		resourceList := &metav1.APIResourceList{GroupVersion: "v1"}
+		for _, resource := range resources {
+			resourceList.APIResources = append(resourceList.APIResources, metav1.APIResource{Name: resource})
+		}
+		return &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{resourceList}}}
+	}
+	resizePolicy := []v1.ContainerResizePolicy{{ResourceName: v1.ResourceCPU, RestartPolicy: v1.NotRequired}}
+
+	cases := []struct {
+		name            string
+		enabled         bool
+		spec            *appsv1beta1.AdvancedCronJobSpec
+		discovery       *fakediscovery.FakeDiscovery
+		expectedWarning string
+	}{
+		{
+			name:      "feature-gate disabled",
+			spec:      newSpec(resizePolicy),
+			discovery: newDiscovery("pods"),
+		},
+		{
+			name:      "feature not used",
+			enabled:   true,
+			spec:      newSpec(nil),
+			discovery: newDiscovery("pods"),
+		},
+		{
+			name:      "feature served",
+			enabled:   true,
+			spec:      newSpec(resizePolicy),
+			discovery: newDiscovery("pods", "pods/resize"),
+		},
+		{
+			name:            "feature not served",
+			enabled:         true,
+			spec:            newSpec(resizePolicy),
+			discovery:       newDiscovery("pods"),
+			expectedWarning: "spec.template.jobTemplate.spec.template.spec.containers[0].resizePolicy: in-place pod resize is not supported",
+		},
+		{
+			name:    "no discovery client",
+			enabled: true,
+			spec:    newSpec(resizePolicy),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobAPIResourceWarnings, tc.enabled)()
+			handler := &AdvancedCronJobCreateUpdateHandler{}
+			if tc.discovery != nil {
+				handler.DiscoveryClient = tc.discovery
+			}
+			warnings := handler.apiResourceWarnings(tc.spec, field.NewPath("spec"))
+			if tc.expectedWarning == "" {
+				if len(warnings) > 0 {
+					t.Fatalf("expected no warnings, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.HasPrefix(warnings[0], tc.expectedWarning) {
+				t.Fatalf("expected warning %q, got %v", tc.expectedWarning, warnings)
+			}
+		})
+	}
+}
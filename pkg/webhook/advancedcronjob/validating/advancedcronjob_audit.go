@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AuditResultVersion is the version of AuditResult, which is bumped on any incompatible change of its JSON structure.
+const AuditResultVersion = "v1"
+
+const (
+	AuditSeverityError   = "Error"
+	AuditSeverityWarning = "Warning"
+
+	// AuditReasonWarning is the reason code of the advisory warnings, the reason code of an error is its field.ErrorType.
+	AuditReasonWarning = "AdvisoryWarning"
+)
+
+// AuditResult is the validation result of an AdvancedCronJob for the policy-audit log.
+type AuditResult struct {
+	Version string       `json:"version"`
+	Allowed bool         `json:"allowed"`
+	Entries []AuditEntry `json:"entries"`
+}
+
+// AuditEntry is a validation error or warning of an AuditResult.
+type AuditEntry struct {
+	// Field is the path of the invalid field, which is empty for the warnings.
+	Field    string `json:"field,omitempty"`
+	Reason   string `json:"reason"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+// AuditHook receives the validation result of each admission request, e.g. to send it to an audit store.
+// It is called synchronously, so it should not block.
+type AuditHook func(req admission.Request, result *AuditResult)
+
+var registeredAuditHooks []AuditHook
+
+// RegisterAuditHook registers an audit hook for the AdvancedCronJob webhook. It should be called at startup
+// before the webhook is set up, like RegisterPolicy.
+func RegisterAuditHook(hook AuditHook) {
+	registeredAuditHooks = append(registeredAuditHooks, hook)
+}
+
+// NewAuditResult converts the validation errors and warnings to an AuditResult, in which the errors go first.
+func NewAuditResult(warnings []string, allErrs field.ErrorList) *AuditResult {
+	result := &AuditResult{
+		Version: AuditResultVersion,
+		Allowed: len(allErrs) == 0,
+		Entries: make([]AuditEntry, 0, len(allErrs)+len(warnings)),
+	}
+	for _, err := range allErrs {
+		result.Entries = append(result.Entries, AuditEntry{
+			Field:    err.Field,
+			Reason:   string(err.Type),
+			Message:  err.ErrorBody(),
+			Severity: AuditSeverityError,
+		})
+	}
+	for _, warning := range warnings {
+		result.Entries = append(result.Entries, AuditEntry{
+			Reason:   AuditReasonWarning,
+			Message:  warning,
+			Severity: AuditSeverityWarning,
+		})
+	}
+	return result
+}
+
+// MarshalAuditResult serializes the validation errors and warnings as the JSON of an AuditResult.
+func MarshalAuditResult(warnings []string, allErrs field.ErrorList) ([]byte, error) {
+	return json.Marshal(NewAuditResult(warnings, allErrs))
+}
+
+func (h *AdvancedCronJobCreateUpdateHandler) audit(req admission.Request, warnings []string, allErrs field.ErrorList) {
+	if len(h.AuditHooks) == 0 {
+		return
+	}
+	result := NewAuditResult(warnings, allErrs)
+	for _, hook := range h.AuditHooks {
+		hook(req, result)
+	}
+}
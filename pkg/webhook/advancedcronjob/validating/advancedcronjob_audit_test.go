@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestMarshalAuditResult(t *testing.T) {
+	cases := []struct {
+		name     string
+		warnings []string
+		allErrs  field.ErrorList
+		expected string
+	}{
+		{
+			name:     "allowed",
+			expected: `{"version":"v1","allowed":true,"entries":[]}`,
+		},
+		{
+			name:     "denied with warnings",
+			warnings: []string{"spec.schedule: restricted"},
+			allErrs:  field.ErrorList{field.Invalid(field.NewPath("spec", "schedule"), "x", "bad schedule")},
+			expected: `{"version":"v1","allowed":false,"entries":[` +
+				`{"field":"spec.schedule","reason":"FieldValueInvalid","message":"Invalid value: \"x\": bad schedule","severity":"Error"},` +
+				`{"reason":"AdvisoryWarning","message":"spec.schedule: restricted","severity":"Warning"}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := MarshalAuditResult(tc.warnings, tc.allErrs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, data)
+			}
+		})
+	}
+}
+
+func TestAdvancedCronJobCreateUpdateHandler_AuditHooks(t *testing.T) {
+	var results []*AuditResult
+	handler := AdvancedCronJobCreateUpdateHandler{
+		Decoder: admission.NewDecoder(scheme.Scheme),
+		AuditHooks: []AuditHook{func(req admission.Request, result *AuditResult) {
+			results = append(results, result)
+		}},
+	}
+	request := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Resource: metav1.GroupVersionResource{
+				Group:    appsv1beta1.GroupVersion.Group,
+				Version:  appsv1beta1.GroupVersion.Version,
+				Resource: "advancedcronjobs",
+			},
+			Object: runtime.RawExtension{
+				Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-acj-audit",
+						Namespace: "default",
+					},
+					Spec: appsv1beta1.AdvancedCronJobSpec{
+						Schedule: "invalid",
+						Template: appsv1beta1.CronJobTemplate{
+							JobTemplate: &batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: createValidPodTemplateSpec(),
+								},
+							},
+						},
+					},
+				}),
+			},
+		},
+	}
+
+	response := handler.Handle(context.TODO(), request)
+	if response.Allowed {
+		t.Fatalf("expected denied response")
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected audit hook to be called once, got %d", len(results))
+	}
+	if results[0].Allowed || len(results[0].Entries) == 0 || results[0].Entries[0].Field != "spec.schedule" {
+		t.Errorf("expected denied audit result of spec.schedule, got %+v", results[0])
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"time"
+
+	"k8s.io/kubernetes/pkg/apis/core"
+
+	"github.com/openkruise/kruise/pkg/controller/advancedcronjob"
+)
+
+// ValidationConfig is the cluster policy the AdvancedCronJobs are validated against, set by the flags of
+// kruise-controller-manager.
+type ValidationConfig struct {
+	// EnforcedTimeZone is the only time zone allowed for AdvancedCronJobs, empty means no restriction.
+	EnforcedTimeZone string
+
+	// AllowedTimeZones are the time zones allowed in spec.timeZone, empty means any loadable time zone is allowed.
+	AllowedTimeZones []string
+
+	// AllowedHours is the window of the hours of a day the schedules of AdvancedCronJobs can fire in, in the time
+	// zones they are scheduled in, nil means any hour is allowed.
+	AllowedHours *hoursWindow
+
+	// MaxFiresPerHour is the max number of times the schedules of an AdvancedCronJob can fire in an hour combined,
+	// 0 means no limit.
+	MaxFiresPerHour int
+
+	// MaxFiresPerDay is the max number of times the schedules of an AdvancedCronJob can fire in a day combined,
+	// 0 means no limit.
+	MaxFiresPerDay int
+
+	// ScheduleEditCooldown is the min duration between two modifications of the schedule of an AdvancedCronJob,
+	// works with AdvancedCronJobScheduleEditCooldown feature-gate.
+	ScheduleEditCooldown time.Duration
+
+	// MinIntervalWithoutDeadline is the min interval of the schedules of an AdvancedCronJob under concurrencyPolicy
+	// Allow whose Job or BroadcastJob template sets no activeDeadlineSeconds, below which a warning is returned,
+	// 0 means no warning.
+	MinIntervalWithoutDeadline time.Duration
+
+	// MaxSpecBytes is the max size of the serialized spec of an AdvancedCronJob, which keeps the object well under
+	// the size limit of etcd, 0 means no limit.
+	MaxSpecBytes int
+
+	// MaxJobCompletions and MaxJobParallelism are the max completions and parallelism of a Job template,
+	// 0 means no limit.
+	MaxJobCompletions int
+	MaxJobParallelism int
+
+	// MaxShortJobParallelism is the max parallelism of a Job template under concurrencyPolicy Forbid, whose
+	// activeDeadlineSeconds is shorter than the interval of the schedules, 0 means no limit.
+	MaxShortJobParallelism int
+
+	// MaxTerminationGracePeriodSeconds is the max terminationGracePeriodSeconds of the pod template of a Job or
+	// BroadcastJob template, 0 means no limit.
+	MaxTerminationGracePeriodSeconds int
+
+	// MaxBroadcastJobRestartLimit is the max failurePolicy.restartLimit of a BroadcastJob template, as the pods are
+	// restarted that many times on every node, 0 means no limit.
+	MaxBroadcastJobRestartLimit int
+
+	// BroadcastJobAllowConcurrentMaxNodes is the max number of nodes of the cluster in which BroadcastJob templates
+	// can use concurrencyPolicy Allow, see advancedcronjob.BroadcastJobAllowConcurrentMaxNodes, which the controller
+	// shares.
+	BroadcastJobAllowConcurrentMaxNodes int
+
+	// MaxRunResourceRequests is the budget of the resource requests of the pods of a run of a Job or BroadcastJob
+	// template, the resources not in it are not limited.
+	MaxRunResourceRequests core.ResourceList
+
+	// SecurityContextBaseline are the rules of the security context the containers of Job and BroadcastJob
+	// templates must meet, works with AdvancedCronJobSecurityContextBaseline feature-gate.
+	SecurityContextBaseline []securityContextRule
+
+	// DeprecatedFieldSeverity is how the use of deprecated fields is flagged, any value other than
+	// DeprecatedFieldSeverityError is taken as DeprecatedFieldSeverityWarning.
+	DeprecatedFieldSeverity string
+
+	// MaxImageRegistries is the max number of distinct registries of the images in an ImageListPullJob template.
+	MaxImageRegistries int
+
+	// MaxImagesAndSelectorNames is the max number of images and selector names of an ImageListPullJob template
+	// combined, which bounds the size of the object, 0 means no limit.
+	MaxImagesAndSelectorNames int
+
+	// MaxClusterWideImages is the max number of images of an ImageListPullJob template pulled on every node,
+	// above which a warning is returned, 0 means no warning.
+	MaxClusterWideImages int
+
+	// MinImagePullBudgetSeconds is the min seconds of activeDeadlineSeconds per image in an ImageListPullJob
+	// template.
+	MinImagePullBudgetSeconds int
+
+	// GroupLabel is the label key grouping AdvancedCronJobs, in which no two of them can have the same schedule and
+	// template kind, empty means no check.
+	GroupLabel string
+
+	// ForbidAllowConcurrent enables ForbidAllowConcurrentPolicy.
+	ForbidAllowConcurrent bool
+
+	// RequiredLabels are the label keys required by RequiredLabelsPolicy, which is enabled if it is not empty.
+	RequiredLabels []string
+}
+
+// DefaultValidationConfig returns the config of the default values of the flags.
+func DefaultValidationConfig() *ValidationConfig {
+	return &ValidationConfig{
+		ScheduleEditCooldown:                5 * time.Minute,
+		BroadcastJobAllowConcurrentMaxNodes: advancedcronjob.BroadcastJobAllowConcurrentMaxNodes,
+		SecurityContextBaseline:             []securityContextRule{runAsNonRootRule},
+		DeprecatedFieldSeverity:             DeprecatedFieldSeverityWarning,
+		MaxImageRegistries:                  5,
+		MaxClusterWideImages:                50,
+		MinImagePullBudgetSeconds:           10,
+	}
+}
+
+// flagConfig is populated from the flags registered in init, and copied into the handlers when they are set up.
+var flagConfig = DefaultValidationConfig()
+
+// configFromFlags returns a copy of flagConfig, with the flags shared with the controller.
+func configFromFlags() *ValidationConfig {
+	config := *flagConfig
+	config.BroadcastJobAllowConcurrentMaxNodes = advancedcronjob.BroadcastJobAllowConcurrentMaxNodes
+	return &config
+}
+
+func (h *AdvancedCronJobCreateUpdateHandler) config() *ValidationConfig {
+	if h.Config == nil {
+		return DefaultValidationConfig()
+	}
+	return h.Config
+}
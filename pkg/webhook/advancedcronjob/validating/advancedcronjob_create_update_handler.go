@@ -18,31 +18,48 @@ package validating
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/docker/distribution/reference"
 	"github.com/robfig/cron/v3"
 	admissionv1 "k8s.io/api/admission/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	genericvalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	validationutil "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/apis/core"
 	corev1 "k8s.io/kubernetes/pkg/apis/core/v1"
 	apivalidation "k8s.io/kubernetes/pkg/apis/core/validation"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/openkruise/kruise/apis/apps/defaults"
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
-	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+	"github.com/openkruise/kruise/pkg/controller/advancedcronjob"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 )
 
@@ -51,223 +68,1797 @@ const (
 	validateAdvancedCronJobNameMsg = "AdvancedCronJob name must consist of alphanumeric characters or '-'"
 	validAdvancedCronJobNameFmt    = `^[a-zA-Z0-9\-]+$`
 	MaxActiveDeadLineSeconds       = 3600 * 24
+	MaxSolarScheduleOffset         = 12 * time.Hour
+
+	// the IANA time zone names are at most 30-ish characters of letters, digits, '/', '_', '-' and '+'
+	timeZoneMaxLen   = 64
+	validTimeZoneFmt = `^[A-Za-z0-9/_+\-]+$`
+
+	reservedAnnotationPrefix = "advancedcronjob.kruise.io/"
 )
 
 var (
 	validateAdvancedCronJobNameRegex = regexp.MustCompile(validAdvancedCronJobNameFmt)
+	validTimeZoneRegex               = regexp.MustCompile(validTimeZoneFmt)
+
+	// reservedTimestampAnnotations are the reserved annotations whose values are RFC3339 times read by the controller
+	// or the webhooks.
+	reservedTimestampAnnotations = []string{
+		appsv1beta1.AdvancedCronJobTriggerAnnotation,
+		appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation,
+	}
+
+	// reservedAnnotations are all the annotations with reservedAnnotationPrefix known to the controller and the
+	// webhooks.
+	reservedAnnotations = append([]string{appsv1beta1.AdvancedCronJobTZDataVersionAnnotation}, reservedTimestampAnnotations...)
+
+	// localLocation is the location the AdvancedCronJobs setting no time zone are scheduled in, the one of
+	// kruise-controller-manager which serves the webhook too.
+	localLocation = time.Local
+
+	// minRunAtDelay is the min duration between the creation and the runAt of a one-time AdvancedCronJob.
+	minRunAtDelay = 10 * time.Second
+
+	// maxRunAtStartingDeadline is the max startingDeadlineSeconds of a one-time AdvancedCronJob.
+	maxRunAtStartingDeadline = 24 * time.Hour
 )
 
 // AdvancedCronJobCreateUpdateHandler handles AdvancedCronJob
 type AdvancedCronJobCreateUpdateHandler struct {
+	Client client.Client
+
 	// Decoder decodes objects
 	Decoder admission.Decoder
+
+	// Policies validate AdvancedCronJobs after the built-in validation.
+	Policies []AdvancedCronJobPolicy
+
+	// StructuredReport returns each validation error as a cause in the status details of the response,
+	// with its field path, type and detail, instead of a single aggregated message.
+	StructuredReport bool
+
+	// AuditHooks receive the validation result of each admission request.
+	AuditHooks []AuditHook
+
+	// Clock is the clock of the time-dependent validations, the real clock is used if it is nil.
+	Clock clock.PassiveClock
+
+	// ImageRefNormalizer normalizes the images of ImageListPullJob templates before they are validated, e.g. to apply
+	// the registry rewriting rules of the cluster. DefaultImageRefNormalizer is used if it is nil.
+	ImageRefNormalizer ImageRefNormalizer
+
+	// DiscoveryClient discovers the API resources served by the cluster, to warn about the features used by the
+	// templates which the cluster does not support. The warnings are skipped if it is nil.
+	DiscoveryClient discovery.DiscoveryInterface
+
+	// Config is the cluster policy the AdvancedCronJobs are validated against, DefaultValidationConfig is used if it
+	// is nil.
+	Config *ValidationConfig
+}
+
+func (h *AdvancedCronJobCreateUpdateHandler) now() time.Time {
+	if h.Clock == nil {
+		return time.Now()
+	}
+	return h.Clock.Now()
 }
 
-func (h *AdvancedCronJobCreateUpdateHandler) validateAdvancedCronJob(obj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+func (h *AdvancedCronJobCreateUpdateHandler) validateAdvancedCronJob(obj *appsv1beta1.AdvancedCronJob) ([]string, field.ErrorList) {
 	allErrs := genericvalidation.ValidateObjectMeta(&obj.ObjectMeta, true, validateAdvancedCronJobName, field.NewPath("metadata"))
-	allErrs = append(allErrs, validateAdvancedCronJobSpec(&obj.Spec, field.NewPath("spec"))...)
+	warnings, specErrs := validateAdvancedCronJobSpec(&obj.Spec, field.NewPath("spec"), h.now(), h.imageRefNormalizer(), h.config())
+	allErrs = append(allErrs, specErrs...)
+	if len(specErrs) == 0 {
+		warnings = append(warnings, h.immediateRunWarnings(obj)...)
+	}
+	allErrs = append(allErrs, h.validateSchedulePolicies(obj, nil)...)
+	allErrs = append(allErrs, validateRunAtCreate(obj, h.now())...)
+	allErrs = append(allErrs, validateReservedAnnotations(obj.Annotations, nil, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateScheduleModifiedAnnotation(obj.Annotations, field.NewPath("metadata", "annotations"), h.now())...)
+	allErrs = append(allErrs, validateTriggerAnnotation(obj)...)
+	allErrs = append(allErrs, h.validateOwnerReferences(obj.OwnerReferences, field.NewPath("metadata", "ownerReferences"))...)
+	allErrs = append(allErrs, h.validatePolicies(obj)...)
+	return warnings, allErrs
+}
+
+// validateOwnerReferences rejects the controller owner reference whose kind is not registered in the scheme,
+// which can not be resolved and may let the AdvancedCronJob be garbage collected prematurely. More than one
+// controller owner reference is already rejected by the validation of the object meta.
+func (h *AdvancedCronJobCreateUpdateHandler) validateOwnerReferences(ownerReferences []metav1.OwnerReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobValidateOwnerReferences) || h.Client == nil {
+		return allErrs
+	}
+	for i, ref := range ownerReferences {
+		if ref.Controller == nil || !*ref.Controller {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			// the format of apiVersion is validated with the object meta
+			continue
+		}
+		if !h.Client.Scheme().Recognizes(gv.WithKind(ref.Kind)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("kind"), ref.Kind,
+				fmt.Sprintf("controller owner kind %s is not registered in the scheme", gv.WithKind(ref.Kind))))
+		}
+	}
+	return allErrs
+}
+
+func (h *AdvancedCronJobCreateUpdateHandler) validatePolicies(obj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, policy := range h.Policies {
+		allErrs = append(allErrs, policy.Validate(obj)...)
+	}
+	return allErrs
+}
+
+// validateGroupUniqueness rejects creating an AdvancedCronJob with the same schedule and template kind as another one
+// in its group, i.e. the AdvancedCronJobs in the namespace with the same value of groupLabel, which would run the
+// same workload twice.
+func (h *AdvancedCronJobCreateUpdateHandler) validateGroupUniqueness(ctx context.Context, obj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+	allErrs := field.ErrorList{}
+	groupLabel := h.config().GroupLabel
+	if groupLabel == "" || h.Client == nil {
+		return allErrs
+	}
+	group, ok := obj.Labels[groupLabel]
+	if !ok {
+		return allErrs
+	}
+	key, err := groupScheduleKey(obj)
+	if err != nil {
+		// the invalid schedule is reported along with the spec
+		return allErrs
+	}
+	kind := advancedcronjob.FindTemplateKind(obj.Spec)
+
+	acjs := &appsv1beta1.AdvancedCronJobList{}
+	if err := h.Client.List(ctx, acjs, client.InNamespace(obj.Namespace), client.MatchingLabels{groupLabel: group}); err != nil {
+		allErrs = append(allErrs, field.InternalError(field.NewPath("metadata", "labels").Key(groupLabel),
+			fmt.Errorf("failed to list the AdvancedCronJobs in the group: %v", err)))
+		return allErrs
+	}
+	for i := range acjs.Items {
+		existing := &acjs.Items[i]
+		if existing.Name == obj.Name || advancedcronjob.FindTemplateKind(existing.Spec) != kind {
+			continue
+		}
+		if existingKey, err := groupScheduleKey(existing); err != nil || existingKey != key {
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", activeScheduleField(&obj.Spec)),
+			fmt.Sprintf("AdvancedCronJob %s in group %s=%s already runs a %s template on the same schedule",
+				existing.Name, groupLabel, group, kind)))
+		break
+	}
+	return allErrs
+}
+
+// groupScheduleKey returns the logical schedule of the AdvancedCronJob, which is the same for the schedules firing at
+// the same times, e.g. with an embedded TZ or spec.timeZone of the same location.
+func groupScheduleKey(acj *appsv1beta1.AdvancedCronJob) (string, error) {
+	resolved, err := advancedcronjob.ResolveSchedule(acj)
+	if err != nil {
+		return "", err
+	}
+	switch resolved.Format {
+	case advancedcronjob.ScheduleFormatRunAt:
+		return fmt.Sprintf("runAt %s", acj.Spec.RunAt.UTC().Format(time.RFC3339)), nil
+	case advancedcronjob.ScheduleFormatSolar:
+		data, err := json.Marshal(acj.Spec.SolarSchedule)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("solar %s", data), nil
+	case advancedcronjob.ScheduleFormatExpression:
+		return fmt.Sprintf("expression %s in %s", strings.TrimSpace(*acj.Spec.ScheduleExpression), resolved.Location), nil
+	}
+	if resolved.Interval {
+		return resolved.Schedule, nil
+	}
+	return fmt.Sprintf("%s in %s", resolved.Schedule, resolved.Location), nil
+}
+
+// validateRestrictedConcurrency rejects the concurrencyPolicy restricted for the kind of template in the cluster,
+// see restrictedConcurrencyPolicies, on create or when it is changed to, oldObj is nil on create. The existing
+// AdvancedCronJobs are left to the controller, which does not start their runs concurrently.
+func (h *AdvancedCronJobCreateUpdateHandler) validateRestrictedConcurrency(ctx context.Context, obj, oldObj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if h.Client == nil || !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobLimitBroadcastConcurrency) {
+		return allErrs
+	}
+	kind := advancedcronjob.FindTemplateKind(obj.Spec)
+	supported, ok := restrictedConcurrencyPolicies[kind]
+	if !ok || slices.Contains(supported, obj.Spec.ConcurrencyPolicy) ||
+		(oldObj != nil && oldObj.Spec.ConcurrencyPolicy == obj.Spec.ConcurrencyPolicy) {
+		return allErrs
+	}
+	nodes := &v1.NodeList{}
+	if err := h.Client.List(ctx, nodes); err != nil {
+		allErrs = append(allErrs, field.InternalError(field.NewPath("spec", "concurrencyPolicy"),
+			fmt.Errorf("failed to list the nodes: %v", err)))
+		return allErrs
+	}
+	if !advancedcronjob.RestrictsBroadcastJobAllowConcurrent(len(nodes.Items), h.config().BroadcastJobAllowConcurrentMaxNodes) {
+		return allErrs
+	}
+	return validateSupportedConcurrency(obj.Spec.ConcurrencyPolicy, kind, supported)
+}
+
+// validateRunAtCreate requires runAt to be later than the creation by minRunAtDelay, so that a one-time
+// AdvancedCronJob can not be created with its only run already missed. The creation is now if it is not set yet.
+func validateRunAtCreate(obj *appsv1beta1.AdvancedCronJob, now time.Time) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if obj.Spec.RunAt == nil {
+		return allErrs
+	}
+	created := obj.CreationTimestamp.Time
+	if created.IsZero() {
+		// creationTimestamp is not set yet when the creation is admitted
+		created = now
+	}
+	if obj.Spec.RunAt.Time.Before(created.Add(minRunAtDelay)) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "runAt"), obj.Spec.RunAt.Format(time.RFC3339),
+			fmt.Sprintf("runAt must be at least %v after the creation, or the only run is missed", minRunAtDelay)))
+	}
+	allErrs = append(allErrs, validateRunAtStartingDeadline(obj.Spec.RunAt.Time, obj.Spec.StartingDeadlineSeconds, created)...)
+	return allErrs
+}
+
+// validateRunAtStartingDeadline bounds the startingDeadlineSeconds of a one-time AdvancedCronJob by
+// maxRunAtStartingDeadline, and by the time between the creation and runAt, since a deadline longer than
+// the wait for the run is contradictory with a runAt so close to the creation.
+func validateRunAtStartingDeadline(runAt time.Time, startingDeadlineSeconds *int64, created time.Time) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if startingDeadlineSeconds == nil || *startingDeadlineSeconds < 0 {
+		// the negative value is reported along with the spec
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "startingDeadlineSeconds")
+	deadline := time.Duration(*startingDeadlineSeconds) * time.Second
+	if deadline > maxRunAtStartingDeadline {
+		allErrs = append(allErrs, field.Invalid(fldPath, *startingDeadlineSeconds,
+			fmt.Sprintf("must be no more than %d with runAt", int64(maxRunAtStartingDeadline/time.Second))))
+	}
+	if runAt.Add(-deadline).Before(created) {
+		allErrs = append(allErrs, field.Invalid(fldPath, *startingDeadlineSeconds,
+			fmt.Sprintf("must be no more than the %d seconds between the creation and runAt", int64(runAt.Sub(created)/time.Second))))
+	}
+	return allErrs
+}
+
+// validateRunAtUpdate forbids moving runAt before now once the one-time AdvancedCronJob has fired,
+// with which it would never run again. Moving runAt into the future runs it once more.
+func validateRunAtUpdate(obj, oldObj *appsv1beta1.AdvancedCronJob, now time.Time) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if obj.Spec.RunAt != nil && !apiequality.Semantic.DeepEqual(obj.Spec.StartingDeadlineSeconds, oldObj.Spec.StartingDeadlineSeconds) {
+		allErrs = append(allErrs, validateRunAtStartingDeadline(obj.Spec.RunAt.Time, obj.Spec.StartingDeadlineSeconds, obj.CreationTimestamp.Time)...)
+	}
+	if obj.Spec.RunAt == nil || oldObj.Spec.RunAt == nil || obj.Spec.RunAt.Equal(oldObj.Spec.RunAt) {
+		return allErrs
+	}
+	fired := oldObj.Status.LastScheduleTime != nil && !oldObj.Status.LastScheduleTime.Before(oldObj.Spec.RunAt)
+	if fired && obj.Spec.RunAt.Time.Before(now) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "runAt"),
+			fmt.Sprintf("runAt can not be moved into the past once it has fired at %s", oldObj.Status.LastScheduleTime.Format(time.RFC3339))))
+	}
+	return allErrs
+}
+
+// validateReservedAnnotations rejects the malformed values of the reserved annotations, so that the controller and
+// the webhooks reading them do not have to guess what was meant, and the unknown annotations with the reserved
+// prefix, which are most likely misspelled. The unknown annotations already in oldAnnotations are kept, so that the
+// AdvancedCronJobs annotated by older tooling or a newer version of kruise can still be updated.
+func validateReservedAnnotations(annotations, oldAnnotations map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, key := range sets.List(sets.KeySet(annotations)) {
+		if _, ok := oldAnnotations[key]; ok {
+			continue
+		}
+		if strings.HasPrefix(key, reservedAnnotationPrefix) && !slices.Contains(reservedAnnotations, key) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Key(key), key, reservedAnnotations))
+		}
+	}
+	if value, ok := annotations[appsv1beta1.AdvancedCronJobTZDataVersionAnnotation]; ok && !webhookutil.IsTZDataVersion(value) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Key(appsv1beta1.AdvancedCronJobTZDataVersionAnnotation), value,
+			"must be a version of the time zone database, e.g. 2025b"))
+	}
+	for _, key := range reservedTimestampAnnotations {
+		value, ok := annotations[key]
+		if !ok {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), value, "must be an RFC3339 time, e.g. 2006-01-02T15:04:05Z"))
+		}
+	}
+	return allErrs
+}
+
+// validateScheduleModifiedAnnotation rejects the time of the last modification of the schedule in the future, which
+// would hold off the modifications beyond the cooldown. The annotation is managed by the mutating webhook, so it is
+// only in the future if forged.
+func validateScheduleModifiedAnnotation(annotations map[string]string, fldPath *field.Path, now time.Time) field.ErrorList {
+	allErrs := field.ErrorList{}
+	value, ok := annotations[appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation]
+	if !ok {
+		return allErrs
+	}
+	modifiedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		// the malformed time is reported by validateReservedAnnotations
+		return allErrs
+	}
+	if modifiedAt.After(now) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Key(appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation), value,
+			fmt.Sprintf("must not be after the current time %s", now.UTC().Format(time.RFC3339))))
+	}
+	return allErrs
+}
+
+// validateTriggerAnnotation rejects the manual trigger annotation on a paused AdvancedCronJob,
+// because paused takes precedence and the trigger would be silently ignored.
+func validateTriggerAnnotation(obj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, ok := obj.Annotations[appsv1beta1.AdvancedCronJobTriggerAnnotation]; !ok {
+		return allErrs
+	}
+	if obj.Spec.Paused != nil && *obj.Spec.Paused {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "annotations").Key(appsv1beta1.AdvancedCronJobTriggerAnnotation),
+			"paused AdvancedCronJob takes precedence over the manual trigger, unpause it or remove the annotation"))
+	}
 	return allErrs
 }
 
-func validateAdvancedCronJobSpec(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path) field.ErrorList {
+// validateAdvancedCronJobSpec returns the advisory warnings along with the errors of the spec, the schedules are
+// checked for the runs after now. The warnings do not reject the AdvancedCronJob.
+func validateAdvancedCronJobSpec(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, now time.Time, normalizer ImageRefNormalizer, config *ValidationConfig) ([]string, field.ErrorList) {
 	allErrs := field.ErrorList{}
-	allErrs = append(allErrs, validateAdvancedCronJobSpecSchedule(spec, fldPath)...)
-	allErrs = append(allErrs, validateAdvancedCronJobSpecTemplate(spec, fldPath)...)
+	allErrs = append(allErrs, validateAdvancedCronJobSpecSchedule(spec, fldPath, now)...)
+	warnings := scheduleWarnings(spec, fldPath, now)
+	templateWarnings, templateErrs := validateAdvancedCronJobSpecTemplate(spec, fldPath, now, normalizer, config)
+	warnings = append(warnings, templateWarnings...)
+	allErrs = append(allErrs, templateErrs...)
 	if spec.StartingDeadlineSeconds != nil {
 		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.StartingDeadlineSeconds, fldPath.Child("startingDeadlineSeconds"))...)
 	}
-	if spec.SuccessfulJobsHistoryLimit != nil {
-		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*spec.SuccessfulJobsHistoryLimit), fldPath.Child("successfulJobsHistoryLimit"))...)
+	switch spec.DeadlinePolicy {
+	case "", appsv1beta1.SkipDeadlinePolicy, appsv1beta1.RunImmediatelyDeadlinePolicy:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("deadlinePolicy"), spec.DeadlinePolicy,
+			[]string{string(appsv1beta1.SkipDeadlinePolicy), string(appsv1beta1.RunImmediatelyDeadlinePolicy)}))
+	}
+	if spec.SuccessfulJobsHistoryLimit != nil && *spec.SuccessfulJobsHistoryLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("successfulJobsHistoryLimit"), *spec.SuccessfulJobsHistoryLimit,
+			fmt.Sprintf("must be greater than or equal to 0, or unset to use the default %d", defaults.DefaultAdvancedCronJobSuccessfulJobsHistoryLimit)))
+	}
+	if spec.FailedJobsHistoryLimit != nil && *spec.FailedJobsHistoryLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("failedJobsHistoryLimit"), *spec.FailedJobsHistoryLimit,
+			fmt.Sprintf("must be greater than or equal to 0, or unset to use the default %d", defaults.DefaultAdvancedCronJobFailedJobsHistoryLimit)))
+	}
+	allErrs = append(allErrs, validateTimeZone(spec.TimeZone, fldPath.Child("timeZone"), config.AllowedTimeZones)...)
+	allErrs = append(allErrs, validateScheduleTimeZone(spec, fldPath)...)
+	allErrs = append(allErrs, validateEnforcedTimeZone(spec, fldPath, config.EnforcedTimeZone)...)
+	allErrs = append(allErrs, validateSkipDates(spec.SkipDates, fldPath.Child("skipDates"))...)
+	allErrs = append(allErrs, validateSpecSize(spec, fldPath, config.MaxSpecBytes)...)
+	return warnings, allErrs
+}
+
+// validateSpecSize rejects the spec whose serialized size exceeds maxSpecBytes, 0 means no limit, e.g. by a large pod template or
+// a long list of images, which the per-field limits do not bound as a whole.
+func validateSpecSize(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, maxSpecBytes int) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if maxSpecBytes <= 0 {
+		return allErrs
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		allErrs = append(allErrs, field.InternalError(fldPath, fmt.Errorf("failed to serialize the spec: %v", err)))
+		return allErrs
+	}
+	if len(data) > maxSpecBytes {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			fmt.Sprintf("the serialized spec is %d bytes, exceeding the limit of %d bytes", len(data), maxSpecBytes)))
+	}
+	return allErrs
+}
+
+// validateSkipDates requires each of the skipDates to be a date in YYYY-MM-DD, and rejects the duplicates.
+func validateSkipDates(skipDates []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.New[time.Time]()
+	for i, skipDate := range skipDates {
+		date, err := time.Parse(advancedcronjob.SkipDateLayout, skipDate)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), skipDate, "must be a date in YYYY-MM-DD"))
+			continue
+		}
+		if seen.Has(date) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), skipDate))
+			continue
+		}
+		seen.Insert(date)
+	}
+	return allErrs
+}
+
+func validateAdvancedCronJobSpecSchedule(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, now time.Time) field.ErrorList {
+	allErrs := field.ErrorList{}
+	// the hidden characters fail the parsing with baffling errors, report them instead
+	allErrs = append(allErrs, validateHiddenCharacters(spec.Schedule, fldPath.Child("schedule"))...)
+	allErrs = append(allErrs, validateEmbeddedTimeZones(spec.Schedule, fldPath.Child("schedule"))...)
+	if spec.ScheduleExpression != nil {
+		allErrs = append(allErrs, validateHiddenCharacters(*spec.ScheduleExpression, fldPath.Child("scheduleExpression"))...)
+		allErrs = append(allErrs, validateEmbeddedTimeZones(*spec.ScheduleExpression, fldPath.Child("scheduleExpression"))...)
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+	if spec.SolarSchedule != nil {
+		if !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobSolarSchedule) {
+			return append(allErrs, field.Forbidden(fldPath.Child("solarSchedule"),
+				"solarSchedule is not allowed when the AdvancedCronJobSolarSchedule feature-gate is disabled"))
+		}
+		if len(spec.Schedule) != 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("schedule"),
+				"schedule must be empty when solarSchedule is set"))
+		}
+		if spec.ScheduleExpression != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("scheduleExpression"),
+				"scheduleExpression must be empty when solarSchedule is set"))
+		}
+		if spec.RunAt != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("runAt"),
+				"runAt must be empty when solarSchedule is set"))
+		}
+		return append(allErrs, validateSolarSchedule(spec.SolarSchedule, fldPath.Child("solarSchedule"))...)
+	}
+	if spec.RunAt != nil {
+		if len(spec.Schedule) != 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("schedule"),
+				"schedule must be empty when runAt is set"))
+		}
+		if spec.ScheduleExpression != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("scheduleExpression"),
+				"scheduleExpression must be empty when runAt is set"))
+		}
+		return allErrs
+	}
+	if spec.ScheduleExpression != nil {
+		if len(spec.Schedule) != 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("schedule"),
+				"schedule must be empty when scheduleExpression is set"))
+		}
+		return append(allErrs, validateScheduleExpression(*spec.ScheduleExpression, spec.TimeZone, fldPath.Child("scheduleExpression"), now)...)
+	}
+	if len(spec.Schedule) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"),
+			spec.Schedule,
+			"schedule cannot be empty, please provide valid cron schedule."))
+	}
+
+	// Use a helper function to safely parse cron expressions and handle panics
+	if err := validateCronSchedule(spec.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"),
+			spec.Schedule, err.Error()))
+	}
+	if strings.Contains(spec.Schedule, "TZ") && spec.TimeZone != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"),
+			spec.Schedule, "cannot use both timeZone field and TZ or CRON_TZ in schedule"))
+	}
+	return allErrs
+}
+
+// validateEmbeddedTimeZones rejects the schedule with more than one TZ= or CRON_TZ= token, which would fail
+// the cron parser with a baffling error, as only the first one is taken as the time zone.
+func validateEmbeddedTimeZones(schedule string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if timeZones := webhookutil.EmbeddedTimeZones(schedule); len(timeZones) > 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, schedule,
+			fmt.Sprintf("found %d time zones %s, only one TZ= or CRON_TZ= prefix is allowed", len(timeZones), strings.Join(timeZones, ", "))))
+	}
+	return allErrs
+}
+
+// validateHiddenCharacters rejects the control and format characters in a schedule, e.g. a zero-width space
+// copied from rich text, which are invisible but not whitespace separating the fields.
+func validateHiddenCharacters(schedule string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	var hidden []string
+	for i, r := range schedule {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			hidden = append(hidden, fmt.Sprintf("%U at byte %d", r, i))
+		}
+	}
+	if len(hidden) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, schedule,
+			fmt.Sprintf("contains hidden characters, e.g. copied from rich text: %s", strings.Join(hidden, ", "))))
+	}
+	return allErrs
+}
+
+// timeZoneRequirement is whether spec.timeZone is required, allowed or forbidden by a type of schedule.
+type timeZoneRequirement string
+
+const (
+	timeZoneRequired  timeZoneRequirement = "required"
+	timeZoneAllowed   timeZoneRequirement = "allowed"
+	timeZoneForbidden timeZoneRequirement = "forbidden"
+)
+
+// scheduleTimeZoneRequirements are the requirements of spec.timeZone by the field of each type of schedule.
+// The solar events are calculated in UTC, so the time zone is required to tell the date of a run, e.g. by
+// skipDates. runAt is an absolute time, which a time zone would only make ambiguous.
+var scheduleTimeZoneRequirements = map[string]timeZoneRequirement{
+	"runAt":              timeZoneForbidden,
+	"solarSchedule":      timeZoneRequired,
+	"scheduleExpression": timeZoneAllowed,
+	"schedule":           timeZoneAllowed,
+}
+
+// validateSchedulePolicies checks the schedule against the policies set by the admin on create, and on update only
+// when the schedule is changed, so that the AdvancedCronJobs admitted before a policy was set can still be updated,
+// e.g. to pause them.
+func (h *AdvancedCronJobCreateUpdateHandler) validateSchedulePolicies(obj, oldObj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+	if oldObj != nil && !advancedcronjob.ScheduleChanged(obj, oldObj, h.now()) {
+		return field.ErrorList{}
+	}
+	config := h.config()
+	allErrs := validateFireDensity(&obj.Spec, field.NewPath("spec"), h.now(), config.MaxFiresPerHour)
+	allErrs = append(allErrs, validateDailyFires(&obj.Spec, field.NewPath("spec"), h.now(), config.MaxFiresPerDay)...)
+	allErrs = append(allErrs, validateAllowedHours(&obj.Spec, field.NewPath("spec"), h.now(), config.AllowedHours)...)
+	return allErrs
+}
+
+// validateAllowedHours rejects the schedules firing out of allowedHours in the 24 hours after now, which is checked
+// in the time zone the AdvancedCronJob is scheduled in, nil means any hour is allowed.
+func validateAllowedHours(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, now time.Time, allowedHours *hoursWindow) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if allowedHours == nil {
+		return allErrs
+	}
+	acj := &appsv1beta1.AdvancedCronJob{Spec: *spec}
+	schedules, err := advancedcronjob.Schedules(acj)
+	if err != nil {
+		// the invalid schedule or time zone is reported by the other validations
+		return allErrs
+	}
+	resolved, err := advancedcronjob.ResolveSchedule(acj)
+	if err != nil {
+		return allErrs
+	}
+	for _, fireTime := range advancedcronjob.FireTimes(schedules, now, now.Add(24*time.Hour)) {
+		if fireTime = fireTime.In(resolved.Location); !allowedHours.contains(fireTime) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(activeScheduleField(spec)),
+				fmt.Sprintf("fires at %s, out of the allowed hours %s", fireTime.Format(time.RFC3339), allowedHours)))
+			break
+		}
+	}
+	return allErrs
+}
+
+// activeScheduleField returns the field of the schedule the AdvancedCronJob runs by, in the same precedence
+// as the controller.
+func activeScheduleField(spec *appsv1beta1.AdvancedCronJobSpec) string {
+	switch {
+	case spec.RunAt != nil:
+		return "runAt"
+	case spec.SolarSchedule != nil:
+		return "solarSchedule"
+	case spec.ScheduleExpression != nil:
+		return "scheduleExpression"
+	default:
+		return "schedule"
+	}
+}
+
+// validateScheduleTimeZone validates spec.timeZone against the requirement of the active type of schedule.
+func validateScheduleTimeZone(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	scheduleField := activeScheduleField(spec)
+	switch scheduleTimeZoneRequirements[scheduleField] {
+	case timeZoneRequired:
+		if spec.TimeZone == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("timeZone"),
+				fmt.Sprintf("timeZone is required when %s is set", scheduleField)))
+		}
+	case timeZoneForbidden:
+		if spec.TimeZone != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("timeZone"),
+				fmt.Sprintf("timeZone must be empty when %s is set", scheduleField)))
+		}
+	}
+	return allErrs
+}
+
+func validateSolarSchedule(solarSchedule *appsv1beta1.SolarSchedule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch solarSchedule.Event {
+	case appsv1beta1.SunriseSolarEvent, appsv1beta1.SunsetSolarEvent:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("event"), solarSchedule.Event,
+			[]string{string(appsv1beta1.SunriseSolarEvent), string(appsv1beta1.SunsetSolarEvent)}))
+	}
+	if _, err := advancedcronjob.ParseCoordinate(solarSchedule.Latitude, 90); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("latitude"), solarSchedule.Latitude, err.Error()))
+	}
+	if _, err := advancedcronjob.ParseCoordinate(solarSchedule.Longitude, 180); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("longitude"), solarSchedule.Longitude, err.Error()))
+	}
+	if solarSchedule.Offset != nil && (solarSchedule.Offset.Duration < -MaxSolarScheduleOffset || solarSchedule.Offset.Duration > MaxSolarScheduleOffset) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("offset"), solarSchedule.Offset.Duration.String(),
+			fmt.Sprintf("offset must be within %v before or after the event", MaxSolarScheduleOffset)))
+	}
+	return allErrs
+}
+
+// validateFireDensity rejects the schedules firing more than maxFiresPerHour times in an hour combined,
+// sampled by advancedcronjob.PeakFiresPerHour from now, 0 means no limit.
+func validateFireDensity(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, now time.Time, maxFiresPerHour int) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if maxFiresPerHour <= 0 || spec.RunAt != nil {
+		return allErrs
+	}
+	schedules, err := advancedcronjob.Schedules(&appsv1beta1.AdvancedCronJob{Spec: *spec})
+	if err != nil {
+		// the invalid schedule or time zone is reported by the other validations
+		return allErrs
+	}
+	if peak := advancedcronjob.PeakFiresPerHour(schedules, now); peak > maxFiresPerHour {
+		schedulePath := fldPath.Child("schedule")
+		if spec.ScheduleExpression != nil {
+			schedulePath = fldPath.Child("scheduleExpression")
+		}
+		allErrs = append(allErrs, field.Forbidden(schedulePath,
+			fmt.Sprintf("%d schedule(s) fire up to %d times per hour combined, exceeding the limit of %d", len(schedules), peak, maxFiresPerHour)))
+	}
+	return allErrs
+}
+
+// validateDailyFires rejects the schedules firing more than maxFiresPerDay times in the 24 hours from now combined,
+// counted by advancedcronjob.FiresPerDay, 0 means no limit.
+func validateDailyFires(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, now time.Time, maxFiresPerDay int) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if maxFiresPerDay <= 0 || spec.RunAt != nil {
+		return allErrs
+	}
+	schedules, err := advancedcronjob.Schedules(&appsv1beta1.AdvancedCronJob{Spec: *spec})
+	if err != nil {
+		// the invalid schedule or time zone is reported by the other validations
+		return allErrs
+	}
+	if fires := advancedcronjob.FiresPerDay(schedules, now); fires > maxFiresPerDay {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child(activeScheduleField(spec)),
+			fmt.Sprintf("%d schedule(s) fire %d times in the next 24 hours combined, exceeding the limit of %d per day", len(schedules), fires, maxFiresPerDay)))
+	}
+	return allErrs
+}
+
+// validateCronSchedule safely validates a cron schedule expression, handling potential panics
+func validateCronSchedule(schedule string) error {
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				// If panic occurs, convert it to an error
+				err = fmt.Errorf("invalid cron schedule: %v", r)
+			}
+		}()
+
+		normalized, normalizeErr := advancedcronjob.NormalizeSchedule(schedule)
+		if normalizeErr != nil {
+			err = normalizeErr
+			return
+		}
+		if stepErr := validateStepValues(normalized); stepErr != nil {
+			err = stepErr
+			return
+		}
+		_, parseErr := cron.ParseStandard(normalized)
+		err = parseErr
+	}()
+
+	return err
+}
+
+// cronFieldNames are the names of the fields of a standard cron schedule, in order.
+var cronFieldNames = []string{"minute", "hour", "day-of-month", "month", "day-of-week"}
+
+// validateStepValues rejects the zero or negative step values, e.g. */0 or 1-5/-1, which fail the parsing
+// with an error not naming the field.
+func validateStepValues(schedule string) error {
+	fields := strings.Fields(schedule)
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "TZ=") || strings.HasPrefix(fields[0], "CRON_TZ=")) {
+		fields = fields[1:]
+	}
+	if len(fields) != len(cronFieldNames) {
+		// descriptors and the wrong number of fields are reported by the parser
+		return nil
+	}
+	for i, f := range fields {
+		for _, expr := range strings.Split(f, ",") {
+			_, step, found := strings.Cut(expr, "/")
+			if !found {
+				continue
+			}
+			if n, err := strconv.Atoi(step); err == nil && n <= 0 {
+				return fmt.Errorf("step value must be positive in field '%s'", cronFieldNames[i])
+			}
+		}
+	}
+	return nil
+}
+
+// validateScheduleExpression parses the schedule expression and requires it to fire at least once after now,
+// so that the exclusions can not silently disable the AdvancedCronJob.
+func validateScheduleExpression(expression string, timeZone *string, fldPath *field.Path, now time.Time) field.ErrorList {
+	allErrs := field.ErrorList{}
+	expr, err := advancedcronjob.ParseScheduleExpression(expression)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, expression, err.Error()))
+	}
+	if strings.Contains(expr.Schedule, "TZ") && timeZone != nil {
+		return append(allErrs, field.Invalid(fldPath, expression, "cannot use both timeZone field and TZ or CRON_TZ in scheduleExpression"))
+	}
+	sched, err := expr.Compile(timeZone)
+	if err != nil {
+		// the invalid time zone is reported by validateTimeZone
+		return allErrs
+	}
+	if sched.Next(now).IsZero() {
+		allErrs = append(allErrs, field.Invalid(fldPath, expression, "scheduleExpression never fires, all the fire times are excluded"))
+	}
+	return allErrs
+}
+
+// scheduleWarnings returns the advisory warnings of a valid schedule for the runs after now, which do not reject
+// the AdvancedCronJob.
+func scheduleWarnings(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, now time.Time) []string {
+	var warnings []string
+	resolved, err := advancedcronjob.ResolveSchedule(&appsv1beta1.AdvancedCronJob{Spec: *spec})
+	if err != nil {
+		// the invalid schedule or time zone is reported by the other validations
+		return warnings
+	}
+	schedulePath := fldPath.Child(activeScheduleField(spec))
+	if restrictsDayOfMonthAndDayOfWeek(resolved.Schedule) {
+		warnings = append(warnings, fmt.Sprintf("%s: both day-of-month and day-of-week are restricted in %q, "+
+			"the job runs on the days matching either of them rather than both", schedulePath, resolved.Schedule))
+	}
+	warnings = append(warnings, leapDayWarnings(spec, schedulePath, now)...)
+	return warnings
+}
+
+// leapDayWarnings warns about the schedules firing only on February 29, which run once every four years rather
+// than annually, with the next fire time computed by the cron parser after now.
+func leapDayWarnings(spec *appsv1beta1.AdvancedCronJobSpec, schedulePath *field.Path, now time.Time) []string {
+	var warnings []string
+	if spec.RunAt != nil || spec.SolarSchedule != nil {
+		return warnings
+	}
+	schedules, err := advancedcronjob.Schedules(&appsv1beta1.AdvancedCronJob{Spec: *spec})
+	if err != nil {
+		// the invalid schedule or time zone is reported by the other validations
+		return warnings
+	}
+	isLeapDay := func(t time.Time) bool {
+		return t.Month() == time.February && t.Day() == 29
+	}
+	for _, sched := range schedules {
+		next := sched.Next(now)
+		// the cron parser gives up searching after a few years, so the leap day after next may not be found
+		if after := sched.Next(next); next.IsZero() || !isLeapDay(next) || !after.IsZero() && !isLeapDay(after) {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("%s: fires only on February 29, which occurs in leap years only, "+
+			"the next run is at %s", schedulePath, next.Format(time.RFC3339)))
+	}
+	return warnings
+}
+
+// restrictsDayOfMonthAndDayOfWeek reports whether neither the day-of-month nor the day-of-week field of the
+// resolved schedule starts with * or ?, in which case the cron parser matches the days of either field.
+func restrictsDayOfMonthAndDayOfWeek(schedule string) bool {
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return false
+	}
+	unrestricted := func(field string) bool {
+		return strings.HasPrefix(field, "*") || strings.HasPrefix(field, "?")
+	}
+	return !unrestricted(fields[2]) && !unrestricted(fields[4])
+}
+
+// validateTimeZone validates spec.timeZone, which must be one of allowedTimeZones if it is not empty.
+func validateTimeZone(timeZone *string, fldPath *field.Path, allowedTimeZones []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if timeZone == nil {
+		return allErrs
+	}
+
+	// reject the obviously malformed names fast, before they are looked up in the time zone database
+	if len(*timeZone) > timeZoneMaxLen {
+		allErrs = append(allErrs, field.TooLong(fldPath, "", timeZoneMaxLen))
+		return allErrs
+	}
+	if len(*timeZone) > 0 && !validTimeZoneRegex.MatchString(*timeZone) {
+		allErrs = append(allErrs, field.Invalid(fldPath, *timeZone,
+			"timeZone must be a time zone name as defined in https://www.iana.org/time-zones, consisting of letters, digits, '/', '_', '-' or '+'"))
+		return allErrs
+	}
+
+	// resolve spec.timeZone alone, the time zone embedded in the schedule is checked by the cron parser
+	acj := &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{TimeZone: timeZone}}
+	loc, err := webhookutil.ResolveLocation(acj)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, timeZone, err.Error()))
+		return allErrs
+	}
+	if len(allowedTimeZones) > 0 && !isAllowedTimeZone(loc, allowedTimeZones) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, *timeZone, allowedTimeZones))
+	}
+
+	return allErrs
+}
+
+// isAllowedTimeZone reports whether the location is one of allowedTimeZones, compared by the canonical
+// names of the loaded locations.
+func isAllowedTimeZone(loc *time.Location, allowedTimeZones []string) bool {
+	for _, tz := range allowedTimeZones {
+		if allowed, err := time.LoadLocation(strings.TrimSpace(tz)); err == nil && allowed.String() == loc.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEnforcedTimeZone rejects any time zone other than the enforced one, no matter it is
+// set by spec.timeZone or embedded in spec.schedule, compared by the names of the resolved locations.
+// Leaving the time zone unset is rejected too, unless the enforced one is the local time zone of
+// kruise-controller-manager. Empty enforcedTimeZone means no restriction.
+func validateEnforcedTimeZone(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, enforcedTimeZone string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(enforcedTimeZone) == 0 {
+		return allErrs
+	}
+
+	enforced, err := webhookutil.LoadLocation(strings.TrimSpace(enforcedTimeZone))
+	if err != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("timeZone"),
+			fmt.Sprintf("the enforced time zone %q of the cluster policy can not be loaded: %v", enforcedTimeZone, err)))
+		return allErrs
+	}
+	violates := func(tz string) bool {
+		loc, err := webhookutil.LoadLocation(tz)
+		return err != nil || loc.String() != enforced.String()
+	}
+
+	if spec.TimeZone != nil && (strings.EqualFold(*spec.TimeZone, "Local") || violates(*spec.TimeZone)) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("timeZone"),
+			fmt.Sprintf("time zone %q violates the cluster policy, only %q is allowed", *spec.TimeZone, enforcedTimeZone)))
+	}
+	if tz, ok := webhookutil.ParseEmbeddedTimeZone(spec.Schedule); ok && violates(tz) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("schedule"),
+			fmt.Sprintf("time zone %q violates the cluster policy, only %q is allowed", tz, enforcedTimeZone)))
+	}
+	if spec.ScheduleExpression != nil {
+		if tz, ok := webhookutil.ParseEmbeddedTimeZone(strings.TrimSpace(*spec.ScheduleExpression)); ok && violates(tz) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("scheduleExpression"),
+				fmt.Sprintf("time zone %q violates the cluster policy, only %q is allowed", tz, enforcedTimeZone)))
+		}
+	}
+	if loc, err := webhookutil.ResolveLocation(&appsv1beta1.AdvancedCronJob{Spec: *spec}); err == nil && loc == time.Local && !isLocalLocation(enforced) {
+		allErrs = append(allErrs, field.Required(fldPath.Child("timeZone"),
+			fmt.Sprintf("the local time zone of kruise-controller-manager violates the cluster policy, %q must be set", enforcedTimeZone)))
+	}
+	return allErrs
+}
+
+// isLocalLocation reports whether the location keeps the same time as localLocation. time.Local is not
+// named after its zone, so the zones are compared by their names and offsets in winter and in summer.
+func isLocalLocation(loc *time.Location) bool {
+	if loc.String() == localLocation.String() {
+		return true
+	}
+	for _, month := range []time.Month{time.January, time.July} {
+		t := time.Date(2025, month, 1, 0, 0, 0, 0, time.UTC)
+		name, offset := t.In(loc).Zone()
+		localName, localOffset := t.In(localLocation).Zone()
+		if name != localName || offset != localOffset {
+			return false
+		}
+	}
+	return true
+}
+
+func validateAdvancedCronJobSpecTemplate(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, now time.Time, normalizer ImageRefNormalizer, config *ValidationConfig) ([]string, field.ErrorList) {
+	var warnings []string
+	allErrs := field.ErrorList{}
+	templateCount := 0
+	interval := frequentScheduleInterval(spec, now, config.MinIntervalWithoutDeadline)
+	if spec.Template.JobTemplate != nil {
+		templateCount++
+		jobWarnings, jobErrs := validateJobTemplateSpec(spec.Template.JobTemplate, interval, fldPath, config)
+		warnings = append(warnings, jobWarnings...)
+		allErrs = append(allErrs, jobErrs...)
+		allErrs = append(allErrs, validateShortJobParallelism(spec, fldPath.Child("template", "jobTemplate", "spec"), now, config.MaxShortJobParallelism)...)
+	}
+
+	if spec.Template.BroadcastJobTemplate != nil {
+		templateCount++
+		brJobWarnings, brJobErrs := validateBroadcastJobTemplateSpec(spec.Template.BroadcastJobTemplate, interval, fldPath, config)
+		warnings = append(warnings, brJobWarnings...)
+		allErrs = append(allErrs, brJobErrs...)
+	}
+
+	allErrs = append(allErrs, validateTemplateKindFields(&spec.Template, fldPath.Child("template"))...)
+
+	if spec.Template.ImageListPullJobTemplate != nil {
+		templateCount++
+		allErrs = append(allErrs, ValidateConcurrencyForKind(spec.ConcurrencyPolicy, appsv1beta1.ImageListPullJobTemplate)...)
+		ilpJobWarnings, ilpJobErrs := validateImageListPullJobTemplateSpec(spec.Template.ImageListPullJobTemplate, fldPath.Child("template").Child("imageListPullJobTemplate"), normalizer, config)
+		warnings = append(warnings, ilpJobWarnings...)
+		allErrs = append(allErrs, ilpJobErrs...)
+	}
+
+	if templateCount == 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("template"),
+			"spec must have one template, either JobTemplate or BroadcastJobTemplate or ImageListPullJobTemplate should be provided"))
+	} else if templateCount > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("template"),
+			"spec can have only one template, either JobTemplate or BroadcastJobTemplate or ImageListPullJobTemplate should be provided"))
+	}
+	return warnings, allErrs
+}
+
+// supportedConcurrencyPolicies are the concurrencyPolicies supported by the kinds of template which do not
+// support all of them.
+var supportedConcurrencyPolicies = map[appsv1beta1.TemplateKind][]appsv1beta1.ConcurrencyPolicy{
+	appsv1beta1.ImageListPullJobTemplate: {appsv1beta1.ReplaceConcurrent, appsv1beta1.ForbidConcurrent},
+}
+
+// restrictedConcurrencyPolicies are the concurrencyPolicies supported by the kinds of template in the clusters
+// they are restricted in, see advancedcronjob.RestrictsBroadcastJobAllowConcurrent.
+var restrictedConcurrencyPolicies = map[appsv1beta1.TemplateKind][]appsv1beta1.ConcurrencyPolicy{
+	appsv1beta1.BroadcastJobTemplate: {appsv1beta1.ReplaceConcurrent, appsv1beta1.ForbidConcurrent},
+}
+
+// ValidateCompletionPolicyBounds rejects the activeDeadlineSeconds of the completion policy exceeding
+// MaxActiveDeadLineSeconds or shorter than the timeoutSeconds of the pull policy, which may be nil for the
+// templates pulling no images, and the ttlSecondsAfterFinished, as the finished runs are cleaned up by the
+// history limits of the AdvancedCronJob.
+func ValidateCompletionPolicyBounds(cp *appsv1beta1.CompletionPolicy, pullPolicy *appsv1beta1.PullPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if cp.ActiveDeadlineSeconds != nil && *cp.ActiveDeadlineSeconds > MaxActiveDeadLineSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("activeDeadlineSeconds"), cp.ActiveDeadlineSeconds,
+			fmt.Sprintf("activeDeadlineSeconds must be less than %d, current value is: %d", MaxActiveDeadLineSeconds, *cp.ActiveDeadlineSeconds)))
+	}
+	if cp.ActiveDeadlineSeconds != nil && pullPolicy != nil && pullPolicy.TimeoutSeconds != nil && int64(*pullPolicy.TimeoutSeconds) > *cp.ActiveDeadlineSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("activeDeadlineSeconds"), cp.ActiveDeadlineSeconds,
+			fmt.Sprintf("completionPolicy.activeDeadlineSeconds must be greater than pullPolicy.timeoutSeconds(%d)", *pullPolicy.TimeoutSeconds)))
+	}
+	if cp.TTLSecondsAfterFinished != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttlSecondsAfterFinished"), cp.TTLSecondsAfterFinished,
+			"ttlSecondsAfterFinished is not supported in advancedCronJob"))
+	}
+	return allErrs
+}
+
+// validatePullPolicyTimeout rejects the timeoutSeconds of the pull policy exceeding MaxActiveDeadLineSeconds,
+// whether the activeDeadlineSeconds of the completion policy is set or not, since a single pull can not outlast
+// the longest run allowed.
+func validatePullPolicyTimeout(pullPolicy *appsv1beta1.PullPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if pullPolicy == nil || pullPolicy.TimeoutSeconds == nil {
+		return allErrs
+	}
+	if int64(*pullPolicy.TimeoutSeconds) > MaxActiveDeadLineSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *pullPolicy.TimeoutSeconds,
+			fmt.Sprintf("timeoutSeconds must be less than or equal to the max activeDeadlineSeconds %d, current value is: %d",
+				MaxActiveDeadLineSeconds, *pullPolicy.TimeoutSeconds)))
+	}
+	return allErrs
+}
+
+// unsupportedConcurrencyPolicyReasons explain why a kind of template does not support a concurrencyPolicy.
+var unsupportedConcurrencyPolicyReasons = map[appsv1beta1.TemplateKind]map[appsv1beta1.ConcurrencyPolicy]string{
+	appsv1beta1.ImageListPullJobTemplate: {
+		appsv1beta1.AllowConcurrent: "cluster-wide pulls must not overlap",
+	},
+	appsv1beta1.BroadcastJobTemplate: {
+		appsv1beta1.AllowConcurrent: "overlapping runs add pods to every node of the cluster",
+	},
+}
+
+// ValidateConcurrencyForKind rejects the concurrencyPolicy not supported by the kind of template, with the reason.
+func ValidateConcurrencyForKind(policy appsv1beta1.ConcurrencyPolicy, kind appsv1beta1.TemplateKind) field.ErrorList {
+	supported, ok := supportedConcurrencyPolicies[kind]
+	if !ok {
+		return field.ErrorList{}
+	}
+	return validateSupportedConcurrency(policy, kind, supported)
+}
+
+// validateSupportedConcurrency rejects the concurrencyPolicy not in the supported ones of the kind of template,
+// with the reason in unsupportedConcurrencyPolicyReasons if any.
+func validateSupportedConcurrency(policy appsv1beta1.ConcurrencyPolicy, kind appsv1beta1.TemplateKind, supported []appsv1beta1.ConcurrencyPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if slices.Contains(supported, policy) {
+		return allErrs
+	}
+	detail := fmt.Sprintf("%s does not support %s because %s", kind, policy, unsupportedConcurrencyPolicyReasons[kind][policy])
+	if _, ok := unsupportedConcurrencyPolicyReasons[kind][policy]; !ok {
+		names := make([]string, 0, len(supported))
+		for _, p := range supported {
+			names = append(names, string(p))
+		}
+		detail = fmt.Sprintf("%s supports only %s, but current value is: %q", kind, strings.Join(names, " or "), policy)
+	}
+	allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "concurrencyPolicy"), policy, detail))
+	return allErrs
+}
+
+// validateTemplateKindFields rejects the fields of the Job or BroadcastJob template which conflict with the kind of
+// the template, e.g. a pod restartPolicy of Always, which are otherwise only rejected when the children are created.
+func validateTemplateKindFields(template *appsv1beta1.CronJobTemplate, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	validateRestartPolicy := func(restartPolicy v1.RestartPolicy, fldPath *field.Path) {
+		if restartPolicy == v1.RestartPolicyAlways {
+			allErrs = append(allErrs, field.NotSupported(fldPath, restartPolicy,
+				[]string{string(v1.RestartPolicyOnFailure), string(v1.RestartPolicyNever)}))
+		}
+	}
+	if jobTemplate := template.JobTemplate; jobTemplate != nil {
+		specPath := fldPath.Child("jobTemplate", "spec")
+		validateRestartPolicy(jobTemplate.Spec.Template.Spec.RestartPolicy, specPath.Child("template", "spec", "restartPolicy"))
+		if jobTemplate.Spec.Selector != nil && (jobTemplate.Spec.ManualSelector == nil || !*jobTemplate.Spec.ManualSelector) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("selector"), jobTemplate.Spec.Selector,
+				"`selector` will be auto-generated for the Jobs, unless manualSelector is true"))
+		}
+	}
+	if brJobTemplate := template.BroadcastJobTemplate; brJobTemplate != nil {
+		specPath := fldPath.Child("broadcastJobTemplate", "spec")
+		validateRestartPolicy(brJobTemplate.Spec.Template.Spec.RestartPolicy, specPath.Child("template", "spec", "restartPolicy"))
+		if completionPolicy := brJobTemplate.Spec.CompletionPolicy; completionPolicy.Type == appsv1beta1.Never {
+			if completionPolicy.TTLSecondsAfterFinished != nil {
+				allErrs = append(allErrs, field.Forbidden(specPath.Child("completionPolicy", "ttlSecondsAfterFinished"),
+					"only works with completionPolicy type Always"))
+			}
+			if completionPolicy.ActiveDeadlineSeconds != nil {
+				allErrs = append(allErrs, field.Forbidden(specPath.Child("completionPolicy", "activeDeadlineSeconds"),
+					"only works with completionPolicy type Always"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateJobTemplateSpec validates the Job template, and warns about its missing deadline if the schedules fire
+// every frequentInterval, see frequentScheduleInterval.
+func validateJobTemplateSpec(jobSpec *batchv1.JobTemplateSpec, frequentInterval time.Duration, fldPath *field.Path, config *ValidationConfig) ([]string, field.ErrorList) {
+	allErrs := field.ErrorList{}
+	coreTemplate, err := convertPodTemplateSpec(&jobSpec.Spec.Template)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Root(), jobSpec.Spec.Template, fmt.Sprintf("Convert_v1_PodTemplateSpec_To_core_PodTemplateSpec failed: %v", err)))
+		return nil, allErrs
+	}
+	allErrs = append(allErrs, apivalidation.ValidatePodTemplateSpec(coreTemplate, fldPath.Child("template"), webhookutil.DefaultPodValidationOptions)...)
+	templatePath := fldPath.Child("template", "jobTemplate", "spec", "template")
+	podSpecPath := templatePath.Child("spec")
+	if utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobRequireResourceRequests) {
+		allErrs = append(allErrs, validateResourceRequests(&coreTemplate.Spec, podSpecPath)...)
+	}
+	if utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobLimitRunResources) {
+		pods := int32(1)
+		if jobSpec.Spec.Parallelism != nil {
+			pods = *jobSpec.Spec.Parallelism
+		}
+		allErrs = append(allErrs, validateRunResourceRequests(&coreTemplate.Spec, pods, podSpecPath, config.MaxRunResourceRequests)...)
+	}
+	allErrs = append(allErrs, validateTerminationGracePeriod(&coreTemplate.Spec, podSpecPath, config.MaxTerminationGracePeriodSeconds)...)
+	if utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobSecurityContextBaseline) {
+		allErrs = append(allErrs, validateSecurityContextBaseline(&coreTemplate.Spec, config.SecurityContextBaseline, podSpecPath)...)
+	}
+	allErrs = append(allErrs, validateVolumeReferences(&coreTemplate.Spec, podSpecPath)...)
+	allErrs = append(allErrs, validateJobScale(&jobSpec.Spec, fldPath.Child("template", "jobTemplate", "spec"), config.MaxJobCompletions, config.MaxJobParallelism)...)
+	warnings, deprecatedErrs := validateDeprecatedFields(coreTemplate, templatePath, config.DeprecatedFieldSeverity)
+	allErrs = append(allErrs, deprecatedErrs...)
+	warnings = append(warnings, duplicateImagePullSecretWarnings(&coreTemplate.Spec, podSpecPath)...)
+	warnings = append(warnings, missingDeadlineWarnings(frequentInterval, jobSpec.Spec.ActiveDeadlineSeconds, &coreTemplate.Spec, fldPath.Child("template", "jobTemplate", "spec", "activeDeadlineSeconds"))...)
+	return warnings, allErrs
+}
+
+// validateJobScale rejects the completions and parallelism of a Job template exceeding maxJobCompletions
+// and maxJobParallelism, 0 means no limit.
+func validateJobScale(jobSpec *batchv1.JobSpec, fldPath *field.Path, maxJobCompletions, maxJobParallelism int) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if maxJobCompletions > 0 && jobSpec.Completions != nil && int(*jobSpec.Completions) > maxJobCompletions {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("completions"), *jobSpec.Completions,
+			fmt.Sprintf("must be less than or equal to %d", maxJobCompletions)))
+	}
+	if maxJobParallelism > 0 && jobSpec.Parallelism != nil && int(*jobSpec.Parallelism) > maxJobParallelism {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("parallelism"), *jobSpec.Parallelism,
+			fmt.Sprintf("must be less than or equal to %d", maxJobParallelism)))
+	}
+	return allErrs
+}
+
+// validateTerminationGracePeriod rejects the pod template whose terminationGracePeriodSeconds exceeds
+// maxTerminationGracePeriodSeconds, as the pods of a run being replaced or cleaned up may linger that long
+// and hold up the next run under concurrencyPolicy Replace, 0 means no limit.
+func validateTerminationGracePeriod(podSpec *core.PodSpec, fldPath *field.Path, maxTerminationGracePeriodSeconds int) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if maxTerminationGracePeriodSeconds > 0 && podSpec.TerminationGracePeriodSeconds != nil &&
+		*podSpec.TerminationGracePeriodSeconds > int64(maxTerminationGracePeriodSeconds) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("terminationGracePeriodSeconds"), *podSpec.TerminationGracePeriodSeconds,
+			fmt.Sprintf("must be less than or equal to %d", maxTerminationGracePeriodSeconds)))
+	}
+	return allErrs
+}
+
+// volumeReference is a ConfigMap, Secret or PersistentVolumeClaim referenced by name in a volume of a pod template.
+type volumeReference struct {
+	kind     string
+	name     string
+	optional bool
+	path     *field.Path
+}
+
+// volumeReferences returns the ConfigMaps, Secrets and PersistentVolumeClaims referenced by the volumes, including
+// the sources of the projected volumes.
+func volumeReferences(volumes []core.Volume, fldPath *field.Path) []volumeReference {
+	var refs []volumeReference
+	for i, volume := range volumes {
+		volumePath := fldPath.Index(i)
+		switch {
+		case volume.ConfigMap != nil:
+			refs = append(refs, volumeReference{kind: "ConfigMap", name: volume.ConfigMap.Name,
+				optional: volume.ConfigMap.Optional != nil && *volume.ConfigMap.Optional, path: volumePath.Child("configMap", "name")})
+		case volume.Secret != nil:
+			refs = append(refs, volumeReference{kind: "Secret", name: volume.Secret.SecretName,
+				optional: volume.Secret.Optional != nil && *volume.Secret.Optional, path: volumePath.Child("secret", "secretName")})
+		case volume.PersistentVolumeClaim != nil:
+			refs = append(refs, volumeReference{kind: "PersistentVolumeClaim", name: volume.PersistentVolumeClaim.ClaimName,
+				path: volumePath.Child("persistentVolumeClaim", "claimName")})
+		case volume.Projected != nil:
+			for j, source := range volume.Projected.Sources {
+				sourcePath := volumePath.Child("projected", "sources").Index(j)
+				if source.ConfigMap != nil {
+					refs = append(refs, volumeReference{kind: "ConfigMap", name: source.ConfigMap.Name,
+						optional: source.ConfigMap.Optional != nil && *source.ConfigMap.Optional, path: sourcePath.Child("configMap", "name")})
+				}
+				if source.Secret != nil {
+					refs = append(refs, volumeReference{kind: "Secret", name: source.Secret.Name,
+						optional: source.Secret.Optional != nil && *source.Secret.Optional, path: sourcePath.Child("secret", "name")})
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// validateVolumeReferences rejects the names of the ConfigMaps, Secrets and PersistentVolumeClaims referenced by the
+// volumes of the pod template which are not valid DNS-1123 subdomains, as the pods of the runs could never start.
+// The empty names are reported by the pod template validation.
+func validateVolumeReferences(podSpec *core.PodSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, ref := range volumeReferences(podSpec.Volumes, fldPath.Child("volumes")) {
+		if len(ref.name) == 0 {
+			continue
+		}
+		for _, msg := range validationutil.IsDNS1123Subdomain(ref.name) {
+			allErrs = append(allErrs, field.Invalid(ref.path, ref.name, msg))
+		}
+	}
+	return allErrs
+}
+
+// validateBroadcastJobFailurePolicy rejects the unknown failurePolicy types of a BroadcastJob template, and the
+// restartLimit that is negative or exceeds maxBroadcastJobRestartLimit, 0 means no limit.
+func validateBroadcastJobFailurePolicy(failurePolicy *appsv1beta1.FailurePolicy, fldPath *field.Path, maxBroadcastJobRestartLimit int) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch failurePolicy.Type {
+	case "", appsv1beta1.FailurePolicyTypeFailFast, appsv1beta1.FailurePolicyTypeContinue, appsv1beta1.FailurePolicyTypePause:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), failurePolicy.Type,
+			[]string{string(appsv1beta1.FailurePolicyTypeFailFast), string(appsv1beta1.FailurePolicyTypeContinue), string(appsv1beta1.FailurePolicyTypePause)}))
+	}
+	if failurePolicy.RestartLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("restartLimit"), failurePolicy.RestartLimit, "must be greater than or equal to 0"))
+	} else if maxBroadcastJobRestartLimit > 0 && int(failurePolicy.RestartLimit) > maxBroadcastJobRestartLimit {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("restartLimit"), failurePolicy.RestartLimit,
+			fmt.Sprintf("must be less than or equal to %d", maxBroadcastJobRestartLimit)))
+	}
+	return allErrs
+}
+
+// validateShortJobParallelism rejects the Job templates under concurrencyPolicy Forbid whose parallelism exceeds
+// maxShortJobParallelism while their activeDeadlineSeconds is shorter than the interval of the schedules, as
+// every run starts such a burst of pods, 0 means no limit. The interval is sampled from now.
+func validateShortJobParallelism(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path, now time.Time, maxShortJobParallelism int) field.ErrorList {
+	allErrs := field.ErrorList{}
+	jobSpec := &spec.Template.JobTemplate.Spec
+	if maxShortJobParallelism <= 0 || spec.ConcurrencyPolicy != appsv1beta1.ForbidConcurrent ||
+		jobSpec.Parallelism == nil || int(*jobSpec.Parallelism) <= maxShortJobParallelism || jobSpec.ActiveDeadlineSeconds == nil {
+		return allErrs
+	}
+	schedules, err := advancedcronjob.Schedules(&appsv1beta1.AdvancedCronJob{Spec: *spec})
+	if err != nil {
+		// the invalid schedule or time zone is reported by the other validations
+		return allErrs
 	}
-	if spec.FailedJobsHistoryLimit != nil {
-		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*spec.FailedJobsHistoryLimit), fldPath.Child("failedJobsHistoryLimit"))...)
+	interval := advancedcronjob.MinFireInterval(schedules, now)
+	if activeDeadline := time.Duration(*jobSpec.ActiveDeadlineSeconds) * time.Second; interval > 0 && activeDeadline < interval {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("parallelism"),
+			fmt.Sprintf("parallelism %d exceeds the limit of %d for the jobs under concurrencyPolicy Forbid whose activeDeadlineSeconds %d is shorter than the schedule interval %s",
+				*jobSpec.Parallelism, maxShortJobParallelism, *jobSpec.ActiveDeadlineSeconds, interval)))
 	}
-	allErrs = append(allErrs, validateTimeZone(spec.TimeZone, fldPath.Child("timeZone"))...)
 	return allErrs
 }
 
-func validateAdvancedCronJobSpecSchedule(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path) field.ErrorList {
+// validateBroadcastJobTemplateSpec validates the BroadcastJob template, and warns about its missing deadline if the
+// schedules fire every frequentInterval, see frequentScheduleInterval.
+func validateBroadcastJobTemplateSpec(brJobSpec *appsv1beta1.BroadcastJobTemplateSpec, frequentInterval time.Duration, fldPath *field.Path, config *ValidationConfig) ([]string, field.ErrorList) {
 	allErrs := field.ErrorList{}
-	if len(spec.Schedule) == 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"),
-			spec.Schedule,
-			"schedule cannot be empty, please provide valid cron schedule."))
+	coreTemplate, err := convertPodTemplateSpec(&brJobSpec.Spec.Template)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Root(), brJobSpec.Spec.Template, fmt.Sprintf("Convert_v1_PodTemplateSpec_To_core_PodTemplateSpec failed: %v", err)))
+		return nil, allErrs
 	}
-
-	// Use a helper function to safely parse cron expressions and handle panics
-	if err := validateCronSchedule(spec.Schedule); err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"),
-			spec.Schedule, err.Error()))
+	allErrs = append(allErrs, apivalidation.ValidatePodTemplateSpec(coreTemplate, fldPath.Child("template"), webhookutil.DefaultPodValidationOptions)...)
+	templatePath := fldPath.Child("template", "broadcastJobTemplate", "spec", "template")
+	podSpecPath := templatePath.Child("spec")
+	if utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobRequireResourceRequests) {
+		allErrs = append(allErrs, validateResourceRequests(&coreTemplate.Spec, podSpecPath)...)
 	}
-	if strings.Contains(spec.Schedule, "TZ") && spec.TimeZone != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"),
-			spec.Schedule, "cannot use both timeZone field and TZ or CRON_TZ in schedule"))
+	if utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobLimitRunResources) {
+		// a BroadcastJob runs a pod on each node, whose number is unknown here, so the budget is per pod
+		allErrs = append(allErrs, validateRunResourceRequests(&coreTemplate.Spec, 1, podSpecPath, config.MaxRunResourceRequests)...)
 	}
-	return allErrs
+	allErrs = append(allErrs, validateTerminationGracePeriod(&coreTemplate.Spec, podSpecPath, config.MaxTerminationGracePeriodSeconds)...)
+	if utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobSecurityContextBaseline) {
+		allErrs = append(allErrs, validateSecurityContextBaseline(&coreTemplate.Spec, config.SecurityContextBaseline, podSpecPath)...)
+	}
+	allErrs = append(allErrs, validateVolumeReferences(&coreTemplate.Spec, podSpecPath)...)
+	allErrs = append(allErrs, validateBroadcastJobFailurePolicy(&brJobSpec.Spec.FailurePolicy, fldPath.Child("template", "broadcastJobTemplate", "spec", "failurePolicy"), config.MaxBroadcastJobRestartLimit)...)
+	warnings, deprecatedErrs := validateDeprecatedFields(coreTemplate, templatePath, config.DeprecatedFieldSeverity)
+	allErrs = append(allErrs, deprecatedErrs...)
+	warnings = append(warnings, duplicateImagePullSecretWarnings(&coreTemplate.Spec, podSpecPath)...)
+	warnings = append(warnings, missingDeadlineWarnings(frequentInterval, brJobSpec.Spec.CompletionPolicy.ActiveDeadlineSeconds, &coreTemplate.Spec,
+		fldPath.Child("template", "broadcastJobTemplate", "spec", "completionPolicy", "activeDeadlineSeconds"))...)
+	return warnings, allErrs
 }
 
-// validateCronSchedule safely validates a cron schedule expression, handling potential panics
-func validateCronSchedule(schedule string) error {
-	var err error
-	func() {
-		defer func() {
-			if r := recover(); r != nil {
-				// If panic occurs, convert it to an error
-				err = fmt.Errorf("invalid cron schedule: %v", r)
-			}
-		}()
+// frequentScheduleInterval returns the interval of the schedules under concurrencyPolicy Allow after now if it is
+// shorter than minIntervalWithoutDeadline, as the runs without a deadline may pile up, or zero otherwise.
+func frequentScheduleInterval(spec *appsv1beta1.AdvancedCronJobSpec, now time.Time, minIntervalWithoutDeadline time.Duration) time.Duration {
+	if minIntervalWithoutDeadline <= 0 || spec.ConcurrencyPolicy != appsv1beta1.AllowConcurrent || spec.RunAt != nil {
+		return 0
+	}
+	schedules, err := advancedcronjob.Schedules(&appsv1beta1.AdvancedCronJob{Spec: *spec})
+	if err != nil {
+		// the invalid schedule or time zone is reported by the other validations
+		return 0
+	}
+	if interval := advancedcronjob.MinFireInterval(schedules, now); interval > 0 && interval < minIntervalWithoutDeadline {
+		return interval
+	}
+	return 0
+}
 
-		_, parseErr := cron.ParseStandard(schedule)
-		err = parseErr
-	}()
+// missingDeadlineWarnings warns about the template setting no activeDeadlineSeconds, neither at deadlinePath nor in
+// the pod template, whose schedules fire every frequentInterval.
+func missingDeadlineWarnings(frequentInterval time.Duration, deadline *int64, podSpec *core.PodSpec, deadlinePath *field.Path) []string {
+	var warnings []string
+	if frequentInterval <= 0 || deadline != nil || podSpec.ActiveDeadlineSeconds != nil {
+		return warnings
+	}
+	warnings = append(warnings, fmt.Sprintf("%s: not set while the schedules fire every %s under concurrencyPolicy Allow, "+
+		"the runs that do not finish may pile up, consider bounding their runtime", deadlinePath, frequentInterval))
+	return warnings
+}
 
-	return err
+// duplicateImagePullSecretWarnings warns about the imagePullSecrets listed more than once in the pod template,
+// which are harmless but likely copy-paste errors.
+func duplicateImagePullSecretWarnings(podSpec *core.PodSpec, fldPath *field.Path) []string {
+	var warnings []string
+	seen := sets.New[string]()
+	for i, secret := range podSpec.ImagePullSecrets {
+		if seen.Has(secret.Name) {
+			warnings = append(warnings, fmt.Sprintf("%s: duplicate imagePullSecret %q", fldPath.Child("imagePullSecrets").Index(i), secret.Name))
+			continue
+		}
+		seen.Insert(secret.Name)
+	}
+	return warnings
 }
 
-func validateTimeZone(timeZone *string, fldPath *field.Path) field.ErrorList {
+// validateResourceRequests requires every container of the pod template to request cpu and memory.
+func validateResourceRequests(podSpec *core.PodSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	if timeZone == nil {
-		return allErrs
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		requestsPath := fldPath.Child("containers").Index(i).Child("resources").Child("requests")
+		for _, resourceName := range []core.ResourceName{core.ResourceCPU, core.ResourceMemory} {
+			if _, ok := container.Resources.Requests[resourceName]; !ok {
+				allErrs = append(allErrs, field.Required(requestsPath.Key(string(resourceName)),
+					fmt.Sprintf("container %s must request %s for scheduled jobs", container.Name, resourceName)))
+			}
+		}
 	}
+	return allErrs
+}
 
-	if len(*timeZone) == 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath, timeZone, "timeZone must be nil or non-empty string"))
-		return allErrs
+// validateRunResourceRequests rejects the pod template whose resource requests of the pods of a run exceed
+// maxRunResourceRequests, the resources not in it are not limited.
+func validateRunResourceRequests(podSpec *core.PodSpec, pods int32, fldPath *field.Path, maxRunResourceRequests core.ResourceList) field.ErrorList {
+	allErrs := field.ErrorList{}
+	podRequests := podResourceRequests(podSpec)
+	for _, resourceName := range sets.List(sets.KeySet(maxRunResourceRequests)) {
+		request, ok := podRequests[resourceName]
+		if !ok {
+			continue
+		}
+		runRequest := request.DeepCopy()
+		runRequest.Mul(int64(pods))
+		if budget := maxRunResourceRequests[resourceName]; runRequest.Cmp(budget) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("containers"),
+				fmt.Sprintf("a run requests %s of %s by %d pod(s), exceeding the budget of %s", runRequest.String(), resourceName, pods, budget.String())))
+		}
 	}
+	return allErrs
+}
 
-	if strings.EqualFold(*timeZone, "Local") {
-		allErrs = append(allErrs, field.Invalid(fldPath, timeZone, "timeZone must be an explicit time zone as defined in https://www.iana.org/time-zones"))
+// podResourceRequests returns the resource requests of a pod, the sum of the requests of the containers or the
+// largest request of the init containers which run one by one before them, whichever is larger.
+func podResourceRequests(podSpec *core.PodSpec) core.ResourceList {
+	requests := core.ResourceList{}
+	for i := range podSpec.Containers {
+		for name, quantity := range podSpec.Containers[i].Resources.Requests {
+			sum := requests[name]
+			sum.Add(quantity)
+			requests[name] = sum
+		}
 	}
-
-	if _, err := time.LoadLocation(*timeZone); err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath, timeZone, err.Error()))
+	for i := range podSpec.InitContainers {
+		for name, quantity := range podSpec.InitContainers[i].Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
 	}
+	return requests
+}
 
-	return allErrs
+// securityContextRule is a rule of the security context baseline of the containers of Job and BroadcastJob templates.
+type securityContextRule string
+
+const (
+	// runAsNonRootRule requires the containers to run as non-root, by runAsNonRoot of the container or the pod,
+	// and with no runAsUser 0.
+	runAsNonRootRule securityContextRule = "RunAsNonRoot"
+	// noPrivilegeEscalationRule requires the containers to set allowPrivilegeEscalation false.
+	noPrivilegeEscalationRule securityContextRule = "NoPrivilegeEscalation"
+	// readOnlyRootFilesystemRule requires the containers to set readOnlyRootFilesystem true.
+	readOnlyRootFilesystemRule securityContextRule = "ReadOnlyRootFilesystem"
+)
+
+var securityContextRules = []securityContextRule{runAsNonRootRule, noPrivilegeEscalationRule, readOnlyRootFilesystemRule}
+
+// parseSecurityContextBaseline parses the comma-separated rules of the security context baseline,
+// e.g. RunAsNonRoot,NoPrivilegeEscalation.
+func parseSecurityContextBaseline(value string) ([]securityContextRule, error) {
+	var rules []securityContextRule
+	if len(value) == 0 {
+		return rules, nil
+	}
+	for _, item := range strings.Split(value, ",") {
+		rule := securityContextRule(strings.TrimSpace(item))
+		if !slices.Contains(securityContextRules, rule) {
+			return nil, fmt.Errorf("unknown security context rule %q, valid rules are: %v", rule, securityContextRules)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
 }
 
-func validateAdvancedCronJobSpecTemplate(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path) field.ErrorList {
+// validateSecurityContextBaseline requires the init containers and containers of the pod template to meet the rules,
+// with the errors on the fields of the security context of each container to set.
+func validateSecurityContextBaseline(podSpec *core.PodSpec, rules []securityContextRule, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	templateCount := 0
-	if spec.Template.JobTemplate != nil {
-		templateCount++
-		allErrs = append(allErrs, validateJobTemplateSpec(spec.Template.JobTemplate, fldPath)...)
+	podSecurityContext := podSpec.SecurityContext
+	if podSecurityContext == nil {
+		podSecurityContext = &core.PodSecurityContext{}
 	}
-
-	if spec.Template.BroadcastJobTemplate != nil {
-		templateCount++
-		allErrs = append(allErrs, validateBroadcastJobTemplateSpec(spec.Template.BroadcastJobTemplate, fldPath)...)
+	for _, containers := range []struct {
+		name       string
+		containers []core.Container
+	}{{"initContainers", podSpec.InitContainers}, {"containers", podSpec.Containers}} {
+		for i := range containers.containers {
+			container := &containers.containers[i]
+			securityContext := container.SecurityContext
+			if securityContext == nil {
+				securityContext = &core.SecurityContext{}
+			}
+			scPath := fldPath.Child(containers.name).Index(i).Child("securityContext")
+			for _, rule := range rules {
+				switch rule {
+				case runAsNonRootRule:
+					allErrs = append(allErrs, validateRunAsNonRoot(container.Name, securityContext, podSecurityContext, scPath, fldPath.Child("securityContext"))...)
+				case noPrivilegeEscalationRule:
+					if securityContext.AllowPrivilegeEscalation == nil || *securityContext.AllowPrivilegeEscalation {
+						allErrs = append(allErrs, field.Invalid(scPath.Child("allowPrivilegeEscalation"), securityContext.AllowPrivilegeEscalation,
+							fmt.Sprintf("container %s must set allowPrivilegeEscalation to false for scheduled jobs", container.Name)))
+					}
+				case readOnlyRootFilesystemRule:
+					if securityContext.ReadOnlyRootFilesystem == nil || !*securityContext.ReadOnlyRootFilesystem {
+						allErrs = append(allErrs, field.Invalid(scPath.Child("readOnlyRootFilesystem"), securityContext.ReadOnlyRootFilesystem,
+							fmt.Sprintf("container %s must set readOnlyRootFilesystem to true for scheduled jobs", container.Name)))
+					}
+				}
+			}
+		}
 	}
+	return allErrs
+}
 
-	if spec.Template.ImageListPullJobTemplate != nil {
-		templateCount++
-		switch spec.ConcurrencyPolicy {
-		case appsv1beta1.ReplaceConcurrent, appsv1beta1.ForbidConcurrent:
-		default:
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("spec").Child("concurrencyPolicy"), spec.ConcurrencyPolicy, fmt.Sprintf("concurrencyPolicy should be Replace or Forbid, but current value is: %s", spec.ConcurrencyPolicy)))
+// validateRunAsNonRoot requires the container to run as non-root. The runAsNonRoot and runAsUser of the container
+// take precedence over the ones of the pod, and the error is on the field taking effect.
+func validateRunAsNonRoot(name string, securityContext *core.SecurityContext, podSecurityContext *core.PodSecurityContext, scPath, podSCPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case securityContext.RunAsNonRoot != nil:
+		if !*securityContext.RunAsNonRoot {
+			allErrs = append(allErrs, field.Invalid(scPath.Child("runAsNonRoot"), false,
+				fmt.Sprintf("container %s must run as non-root for scheduled jobs", name)))
+		}
+	case podSecurityContext.RunAsNonRoot != nil:
+		if !*podSecurityContext.RunAsNonRoot {
+			allErrs = append(allErrs, field.Invalid(podSCPath.Child("runAsNonRoot"), false,
+				fmt.Sprintf("container %s must run as non-root for scheduled jobs, set runAsNonRoot to true in the pod or the container", name)))
 		}
-		allErrs = append(allErrs, validateImageListPullJobTemplateSpec(spec.Template.ImageListPullJobTemplate, fldPath.Child("template").Child("imageListPullJobTemplate"))...)
+	default:
+		allErrs = append(allErrs, field.Required(scPath.Child("runAsNonRoot"),
+			fmt.Sprintf("container %s must run as non-root for scheduled jobs, set runAsNonRoot to true in the pod or the container", name)))
 	}
-
-	if templateCount == 0 {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("template"),
-			"spec must have one template, either JobTemplate or BroadcastJobTemplate or ImageListPullJobTemplate should be provided"))
-	} else if templateCount > 1 {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("template"),
-			"spec can have only one template, either JobTemplate or BroadcastJobTemplate or ImageListPullJobTemplate should be provided"))
+	switch {
+	case securityContext.RunAsUser != nil:
+		if *securityContext.RunAsUser == 0 {
+			allErrs = append(allErrs, field.Invalid(scPath.Child("runAsUser"), 0,
+				fmt.Sprintf("container %s must not run as root user 0 for scheduled jobs", name)))
+		}
+	case podSecurityContext.RunAsUser != nil && *podSecurityContext.RunAsUser == 0:
+		allErrs = append(allErrs, field.Invalid(podSCPath.Child("runAsUser"), 0,
+			fmt.Sprintf("container %s must not run as root user 0 for scheduled jobs", name)))
 	}
 	return allErrs
 }
 
-func validateJobTemplateSpec(jobSpec *batchv1.JobTemplateSpec, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
-	coreTemplate, err := convertPodTemplateSpec(&jobSpec.Spec.Template)
+// hoursWindow is a window of the hours of a day, from start inclusive to end exclusive in minutes since midnight,
+// which wraps around midnight if start is after end.
+type hoursWindow struct {
+	start, end int
+}
+
+// parseHoursWindow parses the window of the hours of a day, e.g. 22:00-06:00.
+func parseHoursWindow(value string) (*hoursWindow, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	startValue, endValue, ok := strings.Cut(value, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid hours window %q, should be HH:MM-HH:MM", value)
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(startValue))
 	if err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Root(), jobSpec.Spec.Template, fmt.Sprintf("Convert_v1_PodTemplateSpec_To_core_PodTemplateSpec failed: %v", err)))
-		return allErrs
+		return nil, fmt.Errorf("invalid start of hours window %q: %v", value, err)
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(endValue))
+	if err != nil {
+		return nil, fmt.Errorf("invalid end of hours window %q: %v", value, err)
+	}
+	w := &hoursWindow{start: start.Hour()*60 + start.Minute(), end: end.Hour()*60 + end.Minute()}
+	if w.start == w.end {
+		return nil, fmt.Errorf("hours window %q is empty", value)
 	}
-	return append(allErrs, apivalidation.ValidatePodTemplateSpec(coreTemplate, fldPath.Child("template"), webhookutil.DefaultPodValidationOptions)...)
+	return w, nil
 }
 
-func validateBroadcastJobTemplateSpec(brJobSpec *appsv1beta1.BroadcastJobTemplateSpec, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
-	coreTemplate, err := convertPodTemplateSpec(&brJobSpec.Spec.Template)
-	if err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Root(), brJobSpec.Spec.Template, fmt.Sprintf("Convert_v1_PodTemplateSpec_To_core_PodTemplateSpec failed: %v", err)))
-		return allErrs
+func (w *hoursWindow) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if w.start < w.end {
+		return minute >= w.start && minute < w.end
+	}
+	return minute >= w.start || minute < w.end
+}
+
+func (w *hoursWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.start/60, w.start%60, w.end/60, w.end%60)
+}
+
+// parseResourceList parses the comma-separated resource quantities, e.g. cpu=8,memory=16Gi.
+func parseResourceList(value string) (core.ResourceList, error) {
+	if len(value) == 0 {
+		return nil, nil
 	}
-	return append(allErrs, apivalidation.ValidatePodTemplateSpec(coreTemplate, fldPath.Child("template"), webhookutil.DefaultPodValidationOptions)...)
+	resources := core.ResourceList{}
+	for _, pair := range strings.Split(value, ",") {
+		name, quantity, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid resource %q, should be name=quantity", pair)
+		}
+		q, err := resource.ParseQuantity(strings.TrimSpace(quantity))
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity of resource %s: %v", name, err)
+		}
+		resources[core.ResourceName(strings.TrimSpace(name))] = q
+	}
+	return resources, nil
 }
 
-func validateImageListPullJobTemplateSpec(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec, fldPath *field.Path) field.ErrorList {
+// validateImageListPullJobTemplateSpec returns the advisory warnings of the template only if it is valid.
+func validateImageListPullJobTemplateSpec(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec, fldPath *field.Path, normalizer ImageRefNormalizer, config *ValidationConfig) ([]string, field.ErrorList) {
 	allErrs := field.ErrorList{}
 	if ilpJobSpec.Spec.Selector != nil {
 		if ilpJobSpec.Spec.Selector.MatchLabels != nil || ilpJobSpec.Spec.Selector.MatchExpressions != nil {
 			if ilpJobSpec.Spec.Selector.Names != nil {
-				return append(allErrs, field.Invalid(fldPath.Child("spec").Child("selector"), ilpJobSpec.Spec.Selector, "can not set both names and labelSelector in this spec.selector"))
+				return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("selector"), ilpJobSpec.Spec.Selector, "can not set both names and labelSelector in this spec.selector"))
 			}
 			if _, err := metav1.LabelSelectorAsSelector(&ilpJobSpec.Spec.Selector.LabelSelector); err != nil {
-				return append(allErrs, field.Invalid(fldPath.Child("spec").Child("selector").Child("labelSelector"), ilpJobSpec.Spec.Selector.LabelSelector, fmt.Sprintf("invalid selector: %v", err)))
+				return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("selector").Child("labelSelector"), ilpJobSpec.Spec.Selector.LabelSelector, fmt.Sprintf("invalid selector: %v", err)))
 			}
 		}
 		if ilpJobSpec.Spec.Selector.Names != nil {
 			names := sets.NewString(ilpJobSpec.Spec.Selector.Names...)
 			if names.Len() != len(ilpJobSpec.Spec.Selector.Names) {
-				return append(allErrs, field.Invalid(fldPath.Child("spec").Child("selector").Child("names"), ilpJobSpec.Spec.Selector.Names, "duplicated name in selector names"))
+				return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("selector").Child("names"), ilpJobSpec.Spec.Selector.Names, "duplicated name in selector names"))
 			}
 		}
 	}
 
 	if ilpJobSpec.Spec.PodSelector != nil {
 		if ilpJobSpec.Spec.Selector != nil {
-			return append(allErrs, field.Invalid(fldPath.Child("spec"), ilpJobSpec.Spec, "can not set both selector and podSelector"))
+			return nil, append(allErrs, field.Invalid(fldPath.Child("spec"), ilpJobSpec.Spec, "can not set both selector and podSelector"))
 		}
 		if _, err := metav1.LabelSelectorAsSelector(&ilpJobSpec.Spec.PodSelector.LabelSelector); err != nil {
-			return append(allErrs, field.Invalid(fldPath.Child("spec").Child("podSelector").Child("labelSelector"), ilpJobSpec.Spec.PodSelector.LabelSelector, fmt.Sprintf("invalid selector: %v", err)))
+			return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("podSelector").Child("labelSelector"), ilpJobSpec.Spec.PodSelector.LabelSelector, fmt.Sprintf("invalid selector: %v", err)))
 		}
 	}
 
-	if len(ilpJobSpec.Spec.Images) == 0 {
-		return append(allErrs, field.Invalid(fldPath.Child("spec").Child("images"), ilpJobSpec.Spec.Images, "image can not be empty"))
+	if errs := validateImageSources(ilpJobSpec, fldPath.Child("spec")); len(errs) > 0 {
+		return nil, append(allErrs, errs...)
 	}
 
 	if len(ilpJobSpec.Spec.Images) > 255 {
-		return append(allErrs, field.Invalid(fldPath.Child("spec").Child("images"), ilpJobSpec.Spec.Images, "the maximum number of images cannot > 255"))
+		return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("images"), ilpJobSpec.Spec.Images, "the maximum number of images cannot > 255"))
+	}
+
+	if maxImagesAndSelectorNames := config.MaxImagesAndSelectorNames; maxImagesAndSelectorNames > 0 {
+		var nameCount int
+		if ilpJobSpec.Spec.Selector != nil {
+			nameCount = len(ilpJobSpec.Spec.Selector.Names)
+		}
+		if imageCount := len(ilpJobSpec.Spec.Images); imageCount+nameCount > maxImagesAndSelectorNames {
+			return nil, append(allErrs, field.Forbidden(fldPath.Child("spec"),
+				fmt.Sprintf("%d images and %d selector names exceed the limit of %d combined", imageCount, nameCount, maxImagesAndSelectorNames)))
+		}
 	}
 
 	for i := 0; i < len(ilpJobSpec.Spec.Images); i++ {
 		for j := i + 1; j < len(ilpJobSpec.Spec.Images); j++ {
 			if ilpJobSpec.Spec.Images[i] == ilpJobSpec.Spec.Images[j] {
-				return append(allErrs, field.Invalid(fldPath.Child("spec").Child("images"), ilpJobSpec.Spec.Images, "images cannot have duplicate values"))
+				return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("images"), ilpJobSpec.Spec.Images, "images cannot have duplicate values"))
 			}
 		}
 	}
 
-	for _, image := range ilpJobSpec.Spec.Images {
-		if _, err := daemonutil.NormalizeImageRef(image); err != nil {
-			return append(allErrs, field.Invalid(fldPath.Child("spec").Child("images"), ilpJobSpec.Spec.Images, fmt.Sprintf("invalid image %s: %v", image, err)))
+	registries := sets.NewString()
+	namedRefs := make([]reference.Named, 0, len(ilpJobSpec.Spec.Images))
+	for i, image := range ilpJobSpec.Spec.Images {
+		if uppercase := uppercaseRepositoryComponents(image); len(uppercase) > 0 {
+			return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("images").Index(i), image,
+				fmt.Sprintf("repository path components must be lowercase, but found %s, only the registry host can contain uppercase characters", strings.Join(uppercase, ", "))))
+		}
+		namedRef, err := normalizer.NormalizeImageRef(image)
+		if err != nil {
+			return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("images"), ilpJobSpec.Spec.Images, fmt.Sprintf("invalid image %s: %v", image, err)))
+		}
+		registries.Insert(reference.Domain(namedRef))
+		namedRefs = append(namedRefs, namedRef)
+	}
+	if utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobLimitImageRegistries) && registries.Len() > config.MaxImageRegistries {
+		return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("images"), ilpJobSpec.Spec.Images,
+			fmt.Sprintf("images can be pulled from at most %d registries, but found %d: %s", config.MaxImageRegistries, registries.Len(), strings.Join(registries.List(), ", "))))
+	}
+	if utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobRejectMixedImageRefs) {
+		if errs := validateMixedImageReferences(ilpJobSpec.Spec.Images, namedRefs, fldPath.Child("spec").Child("images")); len(errs) > 0 {
+			return nil, append(allErrs, errs...)
 		}
 	}
 
+	if errs := validatePullPolicyTimeout(ilpJobSpec.Spec.PullPolicy, fldPath.Child("spec").Child("pullPolicy")); len(errs) > 0 {
+		return nil, append(allErrs, errs...)
+	}
+	if err := advancedcronjob.ValidateCompletionPolicyType(ilpJobSpec.Spec.CompletionPolicy.Type); err != nil {
+		return nil, append(allErrs, field.Invalid(fldPath.Child("spec").Child("completionPolicy").Child("type"), ilpJobSpec.Spec.CompletionPolicy.Type, err.Error()))
+	}
 	switch ilpJobSpec.Spec.CompletionPolicy.Type {
 	case appsv1beta1.Always:
 		// is a no-op here. No need to do parameter dependency verification in this type.
-		if ilpJobSpec.Spec.CompletionPolicy.ActiveDeadlineSeconds != nil && *ilpJobSpec.Spec.CompletionPolicy.ActiveDeadlineSeconds > MaxActiveDeadLineSeconds {
-			return append(allErrs, field.Invalid(fldPath.Child("spec").Child("completionPolicy").Child("activeDeadlineSeconds"), ilpJobSpec.Spec.CompletionPolicy.ActiveDeadlineSeconds, fmt.Sprintf("activeDeadlineSeconds must be less than %d, current value is: %d", MaxActiveDeadLineSeconds, *ilpJobSpec.Spec.CompletionPolicy.ActiveDeadlineSeconds)))
+		if errs := ValidateCompletionPolicyBounds(&ilpJobSpec.Spec.CompletionPolicy, ilpJobSpec.Spec.PullPolicy, fldPath.Child("spec").Child("completionPolicy")); len(errs) > 0 {
+			return nil, append(allErrs, errs...)
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return nil, allErrs
+	}
+	warnings := append(imagePullBudgetWarnings(ilpJobSpec, fldPath, config.MinImagePullBudgetSeconds), emptySelectorWarnings(ilpJobSpec, fldPath)...)
+	warnings = append(warnings, clusterWidePullWarnings(ilpJobSpec, fldPath, config.MaxClusterWideImages)...)
+	return append(warnings, nodeOSWarnings(ilpJobSpec, fldPath)...), allErrs
+}
+
+// validateMixedImageReferences rejects the images referencing the same repository by both tag and digest, which
+// is ambiguous about the image to pull. The references are grouped by the repositories of the normalized namedRefs,
+// e.g. nginx:1.25 and docker.io/library/nginx@sha256:... are mixed.
+func validateMixedImageReferences(images []string, namedRefs []reference.Named, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	taggedImages := map[string]string{}
+	digestedImages := map[string]string{}
+	for i, namedRef := range namedRefs {
+		repository := namedRef.Name()
+		if _, ok := namedRef.(reference.Digested); ok {
+			if tagged, ok := taggedImages[repository]; ok {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), images[i],
+					fmt.Sprintf("repository %s is referenced by both digest and tag in %s", repository, tagged)))
+				continue
+			}
+			if _, ok := digestedImages[repository]; !ok {
+				digestedImages[repository] = images[i]
+			}
+			continue
 		}
-		if ilpJobSpec.Spec.CompletionPolicy.ActiveDeadlineSeconds != nil && ilpJobSpec.Spec.PullPolicy != nil && ilpJobSpec.Spec.PullPolicy.TimeoutSeconds != nil && int64(*ilpJobSpec.Spec.PullPolicy.TimeoutSeconds) > *ilpJobSpec.Spec.CompletionPolicy.ActiveDeadlineSeconds {
-			return append(allErrs, field.Invalid(fldPath.Child("spec").Child("completionPolicy").Child("activeDeadlineSeconds"), ilpJobSpec.Spec.CompletionPolicy.ActiveDeadlineSeconds, fmt.Sprintf("completionPolicy.activeDeadlineSeconds must be greater than pullPolicy.timeoutSeconds(%d)", *ilpJobSpec.Spec.PullPolicy.TimeoutSeconds)))
+		if digested, ok := digestedImages[repository]; ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), images[i],
+				fmt.Sprintf("repository %s is referenced by both tag and digest in %s", repository, digested)))
+			continue
 		}
-		if ilpJobSpec.Spec.CompletionPolicy.TTLSecondsAfterFinished != nil {
-			return append(allErrs, field.Invalid(fldPath.Child("spec").Child("completionPolicy").Child("ttlSecondsAfterFinished"), ilpJobSpec.Spec.CompletionPolicy.TTLSecondsAfterFinished, fmt.Sprintf("ttlSecondsAfterFinished is not supported in advancedCronJob")))
+		if _, ok := taggedImages[repository]; !ok {
+			taggedImages[repository] = images[i]
 		}
-	default:
-		return append(allErrs, field.Invalid(fldPath.Child("spec").Child("completionPolicy").Child("type"), ilpJobSpec.Spec.CompletionPolicy.Type, fmt.Sprintf("completionPolicy should be Always, but current value is: %s", ilpJobSpec.Spec.CompletionPolicy.Type)))
 	}
-
 	return allErrs
 }
 
+// emptySelectorWarnings warns about the selector or podSelector of an ImageListPullJob template which is set
+// but empty, so that it matches everything and the images may be pulled more broadly than intended.
+func emptySelectorWarnings(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec, fldPath *field.Path) []string {
+	var warnings []string
+	if selector := ilpJobSpec.Spec.Selector; selector != nil && len(selector.Names) == 0 && isEmptyLabelSelector(&selector.LabelSelector) {
+		warnings = append(warnings, fmt.Sprintf("%s: the selector matches all the nodes, the images are pulled on every node of the cluster",
+			fldPath.Child("spec", "selector")))
+	}
+	if podSelector := ilpJobSpec.Spec.PodSelector; podSelector != nil && isEmptyLabelSelector(&podSelector.LabelSelector) {
+		warnings = append(warnings, fmt.Sprintf("%s: the podSelector matches all the pods, the images are pulled on every node running a pod in the namespace",
+			fldPath.Child("spec", "podSelector")))
+	}
+	return warnings
+}
+
+// clusterWidePullWarnings warns about the ImageListPullJob template pulling more than maxClusterWideImages images
+// on every node, with no selector or an empty one, which may fill up the disks of the nodes, 0 means no warning.
+func clusterWidePullWarnings(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec, fldPath *field.Path, maxClusterWideImages int) []string {
+	var warnings []string
+	if maxClusterWideImages <= 0 || len(ilpJobSpec.Spec.Images) <= maxClusterWideImages || !pullsOnEveryNode(ilpJobSpec) {
+		return warnings
+	}
+	warnings = append(warnings, fmt.Sprintf("%s: %d images are pulled on every node of the cluster, which may run the nodes out of disk, "+
+		"consider staging the pulls over several jobs or targeting a subset of the nodes by the selector",
+		fldPath.Child("spec", "images"), len(ilpJobSpec.Spec.Images)))
+	return warnings
+}
+
+// nodeOSWarnings warns about the ImageListPullJob template pulling on every node, as the pulls of the platform-specific
+// images fail on the nodes of the other operating systems in a cluster mixing linux and windows nodes.
+func nodeOSWarnings(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec, fldPath *field.Path) []string {
+	var warnings []string
+	if !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobNodeOSWarning) || !pullsOnEveryNode(ilpJobSpec) {
+		return warnings
+	}
+	warnings = append(warnings, fmt.Sprintf("%s: not set, the images are pulled on the nodes of every operating system and fail on the nodes "+
+		"their platforms do not match, consider selecting the nodes by the %s label", fldPath.Child("spec", "selector"), v1.LabelOSStable))
+	return warnings
+}
+
+// pullsOnEveryNode reports whether the ImageListPullJob template sets no selector or podSelector, or an empty one.
+func pullsOnEveryNode(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec) bool {
+	if ilpJobSpec.Spec.PodSelector != nil {
+		return false
+	}
+	selector := ilpJobSpec.Spec.Selector
+	return selector == nil || (len(selector.Names) == 0 && isEmptyLabelSelector(&selector.LabelSelector))
+}
+
+func isEmptyLabelSelector(selector *metav1.LabelSelector) bool {
+	return len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0
+}
+
+// uppercaseRepositoryComponents returns the components of the repository path of the image which contain
+// uppercase characters, forbidden by the OCI distribution spec. The registry host is case-insensitive, it is
+// told apart from the path in the same way as reference.ParseNormalizedNamed.
+func uppercaseRepositoryComponents(image string) []string {
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	components := strings.Split(name, "/")
+	if domain := components[0]; len(components) > 1 &&
+		(strings.ContainsAny(domain, ".:") || domain == "localhost" || strings.ToLower(domain) != domain) {
+		components = components[1:]
+	}
+
+	var uppercase []string
+	for _, component := range components {
+		if strings.ToLower(component) != component {
+			uppercase = append(uppercase, component)
+		}
+	}
+	return uppercase
+}
+
+// imageSource is a field of the ImageListPullJob template which the images to pull come from.
+type imageSource struct {
+	name string
+	set  func(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec) bool
+}
+
+// imageSources are the sources of the images to pull, at least one of which must be set.
+var imageSources = []imageSource{
+	{
+		name: "images",
+		set: func(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec) bool {
+			return len(ilpJobSpec.Spec.Images) > 0
+		},
+	},
+}
+
+// validateImageSources requires at least one of the imageSources to be set, with a single error naming all of them,
+// so that the message stays accurate as the sources expand.
+func validateImageSources(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := make([]string, 0, len(imageSources))
+	for _, source := range imageSources {
+		if source.set(ilpJobSpec) {
+			return allErrs
+		}
+		names = append(names, source.name)
+	}
+	return append(allErrs, field.Required(fldPath.Child(imageSources[0].name), "must set "+strings.Join(names, " or ")))
+}
+
+// imagePullBudgetWarnings warns when activeDeadlineSeconds leaves less than minImagePullBudgetSeconds
+// to pull each image, with which the ImageListPullJob is unlikely to finish in time.
+func imagePullBudgetWarnings(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec, fldPath *field.Path, minImagePullBudgetSeconds int) []string {
+	var warnings []string
+	if !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobImagePullBudgetWarning) {
+		return warnings
+	}
+	deadline := ilpJobSpec.Spec.CompletionPolicy.ActiveDeadlineSeconds
+	imageCount := int64(len(ilpJobSpec.Spec.Images))
+	if deadline == nil || imageCount == 0 {
+		return warnings
+	}
+	if *deadline/imageCount < int64(minImagePullBudgetSeconds) {
+		warnings = append(warnings, fmt.Sprintf("%s: %d seconds for %d images leaves less than %d seconds to pull each image, the job is unlikely to finish in time",
+			fldPath.Child("spec", "completionPolicy", "activeDeadlineSeconds"), *deadline, imageCount, minImagePullBudgetSeconds))
+	}
+	return warnings
+}
+
 func convertPodTemplateSpec(template *v1.PodTemplateSpec) (*core.PodTemplateSpec, error) {
 	coreTemplate := &core.PodTemplateSpec{}
 	if err := corev1.Convert_v1_PodTemplateSpec_To_core_PodTemplateSpec(template.DeepCopy(), coreTemplate, nil); err != nil {
@@ -286,27 +1877,153 @@ func validateAdvancedCronJobName(name string, prefix bool) (allErrs []string) {
 	return allErrs
 }
 
-func (h *AdvancedCronJobCreateUpdateHandler) validateAdvancedCronJobUpdate(obj, oldObj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+func (h *AdvancedCronJobCreateUpdateHandler) validateAdvancedCronJobUpdate(obj, oldObj *appsv1beta1.AdvancedCronJob) ([]string, field.ErrorList) {
 	allErrs := apivalidation.ValidateObjectMetaUpdate(&obj.ObjectMeta, &oldObj.ObjectMeta, field.NewPath("metadata"))
-	allErrs = append(allErrs, validateAdvancedCronJobSpec(&obj.Spec, field.NewPath("spec"))...)
+	warnings, specErrs := validateAdvancedCronJobSpec(&obj.Spec, field.NewPath("spec"), h.now(), h.imageRefNormalizer(), h.config())
+	allErrs = append(allErrs, specErrs...)
+	allErrs = append(allErrs, h.validateSchedulePolicies(obj, oldObj)...)
+	allErrs = append(allErrs, validateRunAtUpdate(obj, oldObj, h.now())...)
+	allErrs = append(allErrs, h.validateScheduleEditCooldown(obj, oldObj)...)
+	allErrs = append(allErrs, validateReservedAnnotations(obj.Annotations, oldObj.Annotations, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateScheduleModifiedAnnotation(obj.Annotations, field.NewPath("metadata", "annotations"), h.now())...)
+	allErrs = append(allErrs, validateTriggerAnnotation(obj)...)
+	allErrs = append(allErrs, h.validateOwnerReferences(obj.OwnerReferences, field.NewPath("metadata", "ownerReferences"))...)
+	if len(specErrs) == 0 {
+		warnings = append(warnings, h.imminentRunWarnings(obj, oldObj)...)
+	}
+	warnings = append(warnings, completionPolicyChangeWarnings(obj, oldObj)...)
 
 	advanceCronJob := obj.DeepCopy()
 	advanceCronJob.Spec.Schedule = oldObj.Spec.Schedule
+	advanceCronJob.Spec.ScheduleExpression = oldObj.Spec.ScheduleExpression
+	advanceCronJob.Spec.RunAt = oldObj.Spec.RunAt
+	advanceCronJob.Spec.SolarSchedule = oldObj.Spec.SolarSchedule
+	advanceCronJob.Spec.SkipDates = oldObj.Spec.SkipDates
 	advanceCronJob.Spec.ConcurrencyPolicy = oldObj.Spec.ConcurrencyPolicy
 	advanceCronJob.Spec.SuccessfulJobsHistoryLimit = oldObj.Spec.SuccessfulJobsHistoryLimit
 	advanceCronJob.Spec.FailedJobsHistoryLimit = oldObj.Spec.FailedJobsHistoryLimit
 	advanceCronJob.Spec.StartingDeadlineSeconds = oldObj.Spec.StartingDeadlineSeconds
+	advanceCronJob.Spec.DeadlinePolicy = oldObj.Spec.DeadlinePolicy
 	advanceCronJob.Spec.Paused = oldObj.Spec.Paused
 	advanceCronJob.Spec.TimeZone = oldObj.Spec.TimeZone
 	if oldObj.Spec.Template.ImageListPullJobTemplate != nil {
 		advanceCronJob.Spec.Template.ImageListPullJobTemplate = oldObj.Spec.Template.ImageListPullJobTemplate
 	}
 	if !apiequality.Semantic.DeepEqual(advanceCronJob.Spec, oldObj.Spec) {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "updates to advancedcronjob spec for fields other than 'imageListPullJobTemplate', 'schedule', 'concurrencyPolicy', 'successfulJobsHistoryLimit', 'failedJobsHistoryLimit', 'startingDeadlineSeconds', 'timeZone' and 'paused' are forbidden"))
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "updates to advancedcronjob spec for fields other than 'imageListPullJobTemplate', 'schedule', 'scheduleExpression', 'runAt', 'solarSchedule', 'skipDates', 'concurrencyPolicy', 'successfulJobsHistoryLimit', 'failedJobsHistoryLimit', 'startingDeadlineSeconds', 'deadlinePolicy', 'timeZone' and 'paused' are forbidden"))
+	}
+	allErrs = append(allErrs, h.validatePolicies(obj)...)
+	return warnings, allErrs
+}
+
+// validateScheduleEditCooldown rejects the modification of the schedule within scheduleEditCooldown from the last
+// one, recorded in the annotation by the mutating webhook, so that a storm of edits does not reschedule the runs
+// over and over.
+func (h *AdvancedCronJobCreateUpdateHandler) validateScheduleEditCooldown(obj, oldObj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+	allErrs := field.ErrorList{}
+	scheduleEditCooldown := h.config().ScheduleEditCooldown
+	if !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobScheduleEditCooldown) || scheduleEditCooldown <= 0 ||
+		!advancedcronjob.ScheduleChanged(obj, oldObj, h.now()) {
+		return allErrs
+	}
+	lastModified, err := time.Parse(time.RFC3339, oldObj.Annotations[appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation])
+	if err != nil {
+		// the schedule has not been modified since the cooldown was enabled
+		return allErrs
+	}
+	if remaining := lastModified.Add(scheduleEditCooldown).Sub(h.now()); remaining > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", activeScheduleField(&obj.Spec)),
+			fmt.Sprintf("the schedule was modified at %s, it can be modified again in %s, after the cooldown of %s",
+				lastModified.Format(time.RFC3339), remaining.Round(time.Second), scheduleEditCooldown)))
 	}
 	return allErrs
 }
 
+// imminentRunWarnings warns when the change of schedule, scheduleExpression, solarSchedule or timeZone drops the next run of
+// the old schedule, which is within startingDeadlineSeconds from now and may have been expected by the user.
+func (h *AdvancedCronJobCreateUpdateHandler) imminentRunWarnings(obj, oldObj *appsv1beta1.AdvancedCronJob) []string {
+	var warnings []string
+	if obj.Spec.StartingDeadlineSeconds == nil || (oldObj.Spec.Paused != nil && *oldObj.Spec.Paused) {
+		return warnings
+	}
+	if obj.Spec.Schedule == oldObj.Spec.Schedule && apiequality.Semantic.DeepEqual(obj.Spec.ScheduleExpression, oldObj.Spec.ScheduleExpression) &&
+		apiequality.Semantic.DeepEqual(obj.Spec.SolarSchedule, oldObj.Spec.SolarSchedule) && apiequality.Semantic.DeepEqual(obj.Spec.TimeZone, oldObj.Spec.TimeZone) {
+		return warnings
+	}
+	oldSchedules, err := advancedcronjob.Schedules(oldObj)
+	if err != nil {
+		return warnings
+	}
+	schedules, err := advancedcronjob.Schedules(obj)
+	if err != nil {
+		return warnings
+	}
+
+	now := h.now()
+	imminentRun := nextFireTime(oldSchedules, now)
+	deadline := now.Add(time.Duration(*obj.Spec.StartingDeadlineSeconds) * time.Second)
+	if imminentRun.IsZero() || imminentRun.After(deadline) {
+		return warnings
+	}
+	if next := nextFireTime(schedules, now); !next.Equal(imminentRun) {
+		schedulePath := field.NewPath("spec", "schedule")
+		if obj.Spec.ScheduleExpression != nil {
+			schedulePath = field.NewPath("spec", "scheduleExpression")
+		} else if obj.Spec.SolarSchedule != nil {
+			schedulePath = field.NewPath("spec", "solarSchedule")
+		}
+		warnings = append(warnings, fmt.Sprintf("%s: the run at %s of the previous schedule, which is within startingDeadlineSeconds from now, "+
+			"is dropped by this change", schedulePath, imminentRun.Format(time.RFC3339)))
+	}
+	return warnings
+}
+
+// immediateRunWarnings warns that the AdvancedCronJob being created fires within a minute, so its first run may
+// start right after the creation, which is surprising when the schedule matches the minute of the creation.
+// The one-time runAt is not warned about, it is required to be later than the creation by minRunAtDelay.
+func (h *AdvancedCronJobCreateUpdateHandler) immediateRunWarnings(obj *appsv1beta1.AdvancedCronJob) []string {
+	var warnings []string
+	if obj.Spec.RunAt != nil || (obj.Spec.Paused != nil && *obj.Spec.Paused) {
+		return warnings
+	}
+	schedules, err := advancedcronjob.Schedules(obj)
+	if err != nil {
+		return warnings
+	}
+	now := h.now()
+	if next := nextFireTime(schedules, now); !next.IsZero() && next.Sub(now) < time.Minute {
+		warnings = append(warnings, fmt.Sprintf("%s: the first run at %s is within a minute of the creation, the AdvancedCronJob may run immediately",
+			field.NewPath("spec", activeScheduleField(&obj.Spec)), next.Format(time.RFC3339)))
+	}
+	return warnings
+}
+
+// completionPolicyChangeWarnings warns that the change of the completionPolicy type of the ImageListPullJob
+// template applies only to the ImageListPullJobs of future runs, the in-flight ones keep the old policy.
+func completionPolicyChangeWarnings(obj, oldObj *appsv1beta1.AdvancedCronJob) []string {
+	var warnings []string
+	template, oldTemplate := obj.Spec.Template.ImageListPullJobTemplate, oldObj.Spec.Template.ImageListPullJobTemplate
+	if template == nil || oldTemplate == nil || template.Spec.CompletionPolicy.Type == oldTemplate.Spec.CompletionPolicy.Type {
+		return warnings
+	}
+	fldPath := field.NewPath("spec", "template", "imageListPullJobTemplate", "spec", "completionPolicy", "type")
+	warnings = append(warnings, fmt.Sprintf("%s: the change from %q to %q applies only to the ImageListPullJobs of future runs, "+
+		"%d active ImageListPullJob(s) keep the old policy", fldPath, oldTemplate.Spec.CompletionPolicy.Type,
+		template.Spec.CompletionPolicy.Type, len(oldObj.Status.Active)))
+	return warnings
+}
+
+// nextFireTime returns the earliest fire time of the schedules after t, or the zero time if none of them fires.
+func nextFireTime(schedules []cron.Schedule, t time.Time) time.Time {
+	var next time.Time
+	for _, sched := range schedules {
+		if n := sched.Next(t); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
+
 func (h *AdvancedCronJobCreateUpdateHandler) decodeAdvancedCronJob(req admission.Request, obj *appsv1beta1.AdvancedCronJob) error {
 	switch req.AdmissionRequest.Resource.Version {
 	case appsv1beta1.GroupVersion.Version:
@@ -355,21 +2072,190 @@ func (h *AdvancedCronJobCreateUpdateHandler) Handle(ctx context.Context, req adm
 	if err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
 	}
+	// validate the effective values, which may be filled by the defaulting after the validation,
+	// e.g. the pullPolicy.timeoutSeconds checked against completionPolicy.activeDeadlineSeconds.
+	defaults.SetDefaultsAdvancedCronJob(obj, false)
+	var warnings []string
+	var allErrs field.ErrorList
 	switch req.AdmissionRequest.Operation {
 	case admissionv1.Create:
-		if allErrs := h.validateAdvancedCronJob(obj); len(allErrs) > 0 {
-			return admission.Errored(http.StatusUnprocessableEntity, allErrs.ToAggregate())
+		warnings, allErrs = h.validateAdvancedCronJob(obj)
+		if len(allErrs) == 0 {
+			allErrs = append(h.validateGroupUniqueness(ctx, obj), h.validateRestrictedConcurrency(ctx, obj, nil)...)
 		}
 	case admissionv1.Update:
 		oldObj := &appsv1beta1.AdvancedCronJob{}
 		if err := h.decodeAdvancedCronJobFromRaw(req.AdmissionRequest.OldObject, req.AdmissionRequest.Resource.Version, oldObj); err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
 		}
+		defaults.SetDefaultsAdvancedCronJob(oldObj, false)
+
+		warnings, allErrs = h.validateAdvancedCronJobUpdate(obj, oldObj)
+		if len(allErrs) == 0 {
+			allErrs = h.validateRestrictedConcurrency(ctx, obj, oldObj)
+		}
+	}
+	if len(allErrs) > 0 {
+		h.audit(req, warnings, allErrs)
+		return h.invalidResponse(obj, allErrs).WithWarnings(warnings...)
+	}
+
+	warnings = append(warnings, h.priorityClassWarnings(ctx, &obj.Spec, field.NewPath("spec"))...)
+	warnings = append(warnings, h.apiResourceWarnings(&obj.Spec, field.NewPath("spec"))...)
+	warnings = append(warnings, h.prePullWarnings(ctx, obj, field.NewPath("spec"))...)
+	warnings = append(warnings, h.volumeReferenceWarnings(ctx, obj, field.NewPath("spec"))...)
+	h.audit(req, warnings, allErrs)
+	return admission.ValidationResponse(true, "").WithWarnings(warnings...)
+}
+
+// priorityClassWarnings warns about the priorityClassName of the Job or BroadcastJob pod template whose
+// PriorityClass does not exist, with which the pods of the scheduled jobs would fail to be created.
+func (h *AdvancedCronJobCreateUpdateHandler) priorityClassWarnings(ctx context.Context, spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path) []string {
+	var warnings []string
+	if h.Client == nil {
+		return warnings
+	}
+
+	podSpec, podSpecPath := templatePodSpec(spec, fldPath)
+	if podSpec == nil || len(podSpec.PriorityClassName) == 0 {
+		return warnings
+	}
+
+	priorityClass := &schedulingv1.PriorityClass{}
+	err := h.Client.Get(ctx, types.NamespacedName{Name: podSpec.PriorityClassName}, priorityClass)
+	if apierrors.IsNotFound(err) {
+		warnings = append(warnings, fmt.Sprintf("%s: PriorityClass %q not found, the pods of the scheduled jobs will be rejected until it is created",
+			podSpecPath.Child("priorityClassName"), podSpec.PriorityClassName))
+	} else if err != nil {
+		klog.ErrorS(err, "Failed to get PriorityClass", "priorityClassName", podSpec.PriorityClassName)
+	}
+	return warnings
+}
+
+// prePullWarnings warns about the images of the Job or BroadcastJob pod template not pulled by any ImageListPullJob,
+// or ImageListPullJob template of an AdvancedCronJob, in the namespace, as the pre-pulled images may have drifted from
+// the template. A namespace pre-pulling no images is not warned about.
+func (h *AdvancedCronJobCreateUpdateHandler) prePullWarnings(ctx context.Context, obj *appsv1beta1.AdvancedCronJob, fldPath *field.Path) []string {
+	var warnings []string
+	if h.Client == nil || !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobPrePullWarning) {
+		return warnings
+	}
+	podSpec, podSpecPath := templatePodSpec(&obj.Spec, fldPath)
+	if podSpec == nil {
+		return warnings
+	}
+
+	normalizer := h.imageRefNormalizer()
+	pulled := sets.NewString()
+	ilpJobs := &appsv1beta1.ImageListPullJobList{}
+	if err := h.Client.List(ctx, ilpJobs, client.InNamespace(obj.Namespace)); err != nil {
+		klog.ErrorS(err, "Failed to list ImageListPullJobs", "namespace", obj.Namespace)
+		return warnings
+	}
+	for i := range ilpJobs.Items {
+		pulled.Insert(normalizedImageRefs(normalizer, ilpJobs.Items[i].Spec.Images)...)
+	}
+	acjs := &appsv1beta1.AdvancedCronJobList{}
+	if err := h.Client.List(ctx, acjs, client.InNamespace(obj.Namespace)); err != nil {
+		klog.ErrorS(err, "Failed to list AdvancedCronJobs", "namespace", obj.Namespace)
+		return warnings
+	}
+	for i := range acjs.Items {
+		if ilpJobTemplate := acjs.Items[i].Spec.Template.ImageListPullJobTemplate; ilpJobTemplate != nil {
+			pulled.Insert(normalizedImageRefs(normalizer, ilpJobTemplate.Spec.Images)...)
+		}
+	}
+	if pulled.Len() == 0 {
+		return warnings
+	}
+
+	for _, containers := range []struct {
+		name       string
+		containers []v1.Container
+	}{{"initContainers", podSpec.InitContainers}, {"containers", podSpec.Containers}} {
+		for i, container := range containers.containers {
+			if refs := normalizedImageRefs(normalizer, []string{container.Image}); len(refs) == 0 || pulled.Has(refs[0]) {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("%s: image %s is not pulled by any ImageListPullJob in namespace %s, "+
+				"the pre-pulled images may have drifted from the template", podSpecPath.Child(containers.name).Index(i).Child("image"), container.Image, obj.Namespace))
+		}
+	}
+	return warnings
+}
+
+// normalizedImageRefs returns the references of the images normalized by the normalizer, skipping the invalid ones.
+func normalizedImageRefs(normalizer ImageRefNormalizer, images []string) []string {
+	refs := make([]string, 0, len(images))
+	for _, image := range images {
+		if namedRef, err := normalizer.NormalizeImageRef(image); err == nil {
+			refs = append(refs, namedRef.String())
+		}
+	}
+	return refs
+}
+
+// volumeReferenceWarnings warns about the ConfigMaps, Secrets and PersistentVolumeClaims referenced by the volumes of
+// the Job or BroadcastJob template which are not found in the namespace, the optional ones are not checked.
+func (h *AdvancedCronJobCreateUpdateHandler) volumeReferenceWarnings(ctx context.Context, obj *appsv1beta1.AdvancedCronJob, fldPath *field.Path) []string {
+	var warnings []string
+	if h.Client == nil || !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobVolumeRefWarning) {
+		return warnings
+	}
+	podSpec, podSpecPath := templatePodSpec(&obj.Spec, fldPath)
+	if podSpec == nil {
+		return warnings
+	}
+	coreTemplate, err := convertPodTemplateSpec(&v1.PodTemplateSpec{Spec: *podSpec})
+	if err != nil {
+		// reported by the template validation
+		return warnings
+	}
 
-		if allErrs := h.validateAdvancedCronJobUpdate(obj, oldObj); len(allErrs) > 0 {
-			return admission.Errored(http.StatusUnprocessableEntity, allErrs.ToAggregate())
+	for _, ref := range volumeReferences(coreTemplate.Spec.Volumes, podSpecPath.Child("volumes")) {
+		if ref.optional || len(ref.name) == 0 {
+			continue
+		}
+		var object client.Object
+		switch ref.kind {
+		case "ConfigMap":
+			object = &v1.ConfigMap{}
+		case "Secret":
+			object = &v1.Secret{}
+		default:
+			object = &v1.PersistentVolumeClaim{}
+		}
+		err := h.Client.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: ref.name}, object)
+		if apierrors.IsNotFound(err) {
+			warnings = append(warnings, fmt.Sprintf("%s: %s %q not found in namespace %s, the pods of the scheduled jobs can not start until it is created",
+				ref.path, ref.kind, ref.name, obj.Namespace))
+		} else if err != nil {
+			klog.ErrorS(err, "Failed to get volume reference", "kind", ref.kind, "name", ref.name, "namespace", obj.Namespace)
 		}
 	}
+	return warnings
+}
+
+// templatePodSpec returns the pod spec of the Job or BroadcastJob template and its path, or nil for the other templates.
+func templatePodSpec(spec *appsv1beta1.AdvancedCronJobSpec, fldPath *field.Path) (*v1.PodSpec, *field.Path) {
+	switch {
+	case spec.Template.JobTemplate != nil:
+		return &spec.Template.JobTemplate.Spec.Template.Spec, fldPath.Child("template", "jobTemplate", "spec", "template", "spec")
+	case spec.Template.BroadcastJobTemplate != nil:
+		return &spec.Template.BroadcastJobTemplate.Spec.Template.Spec, fldPath.Child("template", "broadcastJobTemplate", "spec", "template", "spec")
+	}
+	return nil, nil
+}
 
-	return admission.ValidationResponse(true, "")
+func (h *AdvancedCronJobCreateUpdateHandler) invalidResponse(obj *appsv1beta1.AdvancedCronJob, allErrs field.ErrorList) admission.Response {
+	if !h.StructuredReport {
+		return admission.Errored(http.StatusUnprocessableEntity, allErrs.ToAggregate())
+	}
+	statusErr := apierrors.NewInvalid(appsv1beta1.GroupVersion.WithKind(appsv1beta1.AdvancedCronJobKind).GroupKind(), obj.Name, allErrs)
+	return admission.Response{
+		AdmissionResponse: admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result:  &statusErr.ErrStatus,
+		},
+	}
 }
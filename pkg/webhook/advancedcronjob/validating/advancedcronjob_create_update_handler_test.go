@@ -19,23 +19,40 @@ package validating
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/distribution/reference"
 	admissionv1 "k8s.io/api/admission/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/kubernetes/pkg/apis/core"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/openkruise/kruise/apis"
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/controller/advancedcronjob"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 )
 
 func TestValidateCronJobSpec(t *testing.T) {
@@ -44,7 +61,7 @@ func TestValidateCronJobSpec(t *testing.T) {
 			Containers: []v1.Container{
 				{Name: "foo", Image: "foo:latest", TerminationMessagePolicy: v1.TerminationMessageReadFile, ImagePullPolicy: v1.PullIfNotPresent},
 			},
-			RestartPolicy:                 v1.RestartPolicyAlways,
+			RestartPolicy:                 v1.RestartPolicyOnFailure,
 			DNSPolicy:                     v1.DNSDefault,
 			TerminationGracePeriodSeconds: &[]int64{v1.DefaultTerminationGracePeriodSeconds}[0],
 		},
@@ -99,10 +116,72 @@ func TestValidateCronJobSpec(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		"check history limits are valid": {
+			acj: &appsv1beta1.AdvancedCronJobSpec{
+				Schedule:                   "0 * * * *",
+				ConcurrencyPolicy:          appsv1beta1.AllowConcurrent,
+				SuccessfulJobsHistoryLimit: int32Ptr(0),
+				FailedJobsHistoryLimit:     int32Ptr(10),
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: validPodTemplateSpec,
+						},
+					},
+				},
+			},
+		},
+		"check history limits are negative": {
+			acj: &appsv1beta1.AdvancedCronJobSpec{
+				Schedule:                   "0 * * * *",
+				ConcurrencyPolicy:          appsv1beta1.AllowConcurrent,
+				SuccessfulJobsHistoryLimit: int32Ptr(-1),
+				FailedJobsHistoryLimit:     int32Ptr(-1),
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: validPodTemplateSpec,
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"check deadlinePolicy is valid": {
+			acj: &appsv1beta1.AdvancedCronJobSpec{
+				Schedule:                "0 * * * *",
+				ConcurrencyPolicy:       appsv1beta1.AllowConcurrent,
+				StartingDeadlineSeconds: int64Ptr(60),
+				DeadlinePolicy:          appsv1beta1.RunImmediatelyDeadlinePolicy,
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: validPodTemplateSpec,
+						},
+					},
+				},
+			},
+		},
+		"check deadlinePolicy is unknown": {
+			acj: &appsv1beta1.AdvancedCronJobSpec{
+				Schedule:                "0 * * * *",
+				ConcurrencyPolicy:       appsv1beta1.AllowConcurrent,
+				StartingDeadlineSeconds: int64Ptr(60),
+				DeadlinePolicy:          "RunLater",
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: validPodTemplateSpec,
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for k, v := range cases {
-		errs := validateAdvancedCronJobSpec(v.acj, field.NewPath("spec"))
+		_, errs := validateAdvancedCronJobSpec(v.acj, field.NewPath("spec"), time.Now(), DefaultImageRefNormalizer, DefaultValidationConfig())
 		if len(errs) > 0 && !v.expectErr {
 			t.Errorf("unexpected error for %s: %v", k, errs)
 		} else if len(errs) == 0 && v.expectErr {
@@ -111,956 +190,4419 @@ func TestValidateCronJobSpec(t *testing.T) {
 	}
 }
 
-func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
-	utilruntime.Must(apis.AddToScheme(scheme.Scheme))
-
-	tests := []struct {
-		name           string
-		request        admission.Request
-		expectedResult bool
-		expectedError  bool
-	}{
-		{
-			name: "create valid v1beta1 AdvancedCronJob",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule: "0 0 * * *",
-								Template: appsv1beta1.CronJobTemplate{
-									JobTemplate: &batchv1.JobTemplateSpec{
-										Spec: batchv1.JobSpec{
-											Template: createValidPodTemplateSpec(),
-										},
-									},
-								},
-							},
-						}),
+func TestValidateDeadlinePolicyUpdate(t *testing.T) {
+	newObj := func(policy appsv1beta1.DeadlinePolicy) *appsv1beta1.AdvancedCronJob {
+		return &appsv1beta1.AdvancedCronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: appsv1beta1.AdvancedCronJobSpec{
+				Schedule:                "0 * * * *",
+				ConcurrencyPolicy:       appsv1beta1.AllowConcurrent,
+				StartingDeadlineSeconds: int64Ptr(60),
+				DeadlinePolicy:          policy,
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: createValidPodTemplateSpec(),
+						},
 					},
 				},
 			},
-			expectedResult: true,
-			expectedError:  false,
+		}
+	}
+	handler := &AdvancedCronJobCreateUpdateHandler{}
+
+	if _, errs := handler.validateAdvancedCronJobUpdate(newObj(appsv1beta1.RunImmediatelyDeadlinePolicy), newObj(appsv1beta1.SkipDeadlinePolicy)); len(errs) > 0 {
+		t.Errorf("expected no error for switching deadlinePolicy, got %v", errs)
+	}
+	if _, errs := handler.validateAdvancedCronJobUpdate(newObj(appsv1beta1.SkipDeadlinePolicy), newObj("")); len(errs) > 0 {
+		t.Errorf("expected no error for defaulting deadlinePolicy on update, got %v", errs)
+	}
+	if _, errs := handler.validateAdvancedCronJobUpdate(newObj("RunLater"), newObj(appsv1beta1.SkipDeadlinePolicy)); len(errs) == 0 {
+		t.Errorf("expected error for updating deadlinePolicy to an unknown value")
+	}
+}
+
+func TestValidateEnforcedTimeZone(t *testing.T) {
+	defer func(loc *time.Location) { localLocation = loc }(localLocation)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		enforced  string
+		local     *time.Location
+		schedule  string
+		timeZone  *string
+		expectErr bool
+	}{
+		{
+			name:     "no policy",
+			schedule: "TZ=Asia/Shanghai 0 * * * *",
 		},
 		{
-			name: "create invalid v1beta1 AdvancedCronJob with empty schedule",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-invalid",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule: "",
-								Template: appsv1beta1.CronJobTemplate{
-									JobTemplate: &batchv1.JobTemplateSpec{
-										Spec: batchv1.JobSpec{
-											Template: v1.PodTemplateSpec{
-												Spec: v1.PodSpec{
-													Containers: []v1.Container{
-														{
-															Name:  "test",
-															Image: "nginx:latest",
-														},
-													},
-													RestartPolicy: v1.RestartPolicyOnFailure,
-												},
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: false,
-			expectedError:  true,
+			name:     "policy with nothing set",
+			enforced: "UTC",
+			schedule: "0 * * * *",
 		},
 		{
-			name: "create invalid v1beta1 AdvancedCronJob with invalid schedule",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-invalid-schedule",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule: "invalid-cron",
-								Template: appsv1beta1.CronJobTemplate{
-									JobTemplate: &batchv1.JobTemplateSpec{
-										Spec: batchv1.JobSpec{
-											Template: v1.PodTemplateSpec{
-												Spec: v1.PodSpec{
-													Containers: []v1.Container{
-														{
-															Name:  "test",
-															Image: "nginx:latest",
-														},
-													},
-													RestartPolicy: v1.RestartPolicyOnFailure,
-												},
-											},
-										},
-									},
-								},
-							},
-						}),
+			name:      "policy with nothing set in another local time zone",
+			enforced:  "UTC",
+			local:     newYork,
+			schedule:  "0 * * * *",
+			expectErr: true,
+		},
+		{
+			name:     "policy padded with spaces",
+			enforced: " UTC ",
+			schedule: "0 * * * *",
+			timeZone: pointer.String("UTC"),
+		},
+		{
+			name:      "policy with Local timeZone",
+			enforced:  "UTC",
+			schedule:  "0 * * * *",
+			timeZone:  pointer.String("Local"),
+			expectErr: true,
+		},
+		{
+			name:     "policy with matched timeZone",
+			enforced: "UTC",
+			schedule: "0 * * * *",
+			timeZone: pointer.String("UTC"),
+		},
+		{
+			name:      "policy with mismatched timeZone",
+			enforced:  "UTC",
+			schedule:  "0 * * * *",
+			timeZone:  pointer.String("America/New_York"),
+			expectErr: true,
+		},
+		{
+			name:     "policy with matched TZ",
+			enforced: "UTC",
+			schedule: "TZ=UTC 0 * * * *",
+		},
+		{
+			name:      "policy with mismatched TZ",
+			enforced:  "UTC",
+			schedule:  "TZ=Asia/Shanghai 0 * * * *",
+			expectErr: true,
+		},
+		{
+			name:      "policy with mismatched CRON_TZ",
+			enforced:  "UTC",
+			schedule:  "CRON_TZ=Asia/Shanghai 0 * * * *",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			localLocation = time.UTC
+			if tc.local != nil {
+				localLocation = tc.local
+			}
+			spec := &appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule, TimeZone: tc.timeZone}
+			errs := validateEnforcedTimeZone(spec, field.NewPath("spec"), tc.enforced)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateScheduleExpression(t *testing.T) {
+	cases := []struct {
+		name       string
+		schedule   string
+		expression string
+		timeZone   *string
+		expectErr  bool
+	}{
+		{
+			name:       "valid expression",
+			expression: "0 9 * * MON-FRI except last FRI or date 2025-12-25",
+		},
+		{
+			name:       "valid expression with timeZone",
+			expression: "0 9 * * * except lastday",
+			timeZone:   pointer.String("Asia/Shanghai"),
+		},
+		{
+			name:       "both schedule and expression",
+			schedule:   "0 9 * * *",
+			expression: "0 9 * * * except lastday",
+			expectErr:  true,
+		},
+		{
+			name:       "invalid cron schedule",
+			expression: "0 9 * * except lastday",
+			expectErr:  true,
+		},
+		{
+			name:       "invalid exclusion",
+			expression: "0 9 * * * except weekends",
+			expectErr:  true,
+		},
+		{
+			name:       "both TZ and timeZone",
+			expression: "TZ=UTC 0 9 * * * except lastday",
+			timeZone:   pointer.String("UTC"),
+			expectErr:  true,
+		},
+		{
+			name:       "dense schedule excluding a whole day",
+			expression: "* * * * * except day 1",
+		},
+		{
+			name:       "never fires",
+			expression: "0 9 1 * * except day 1",
+			expectErr:  true,
+		},
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &appsv1beta1.AdvancedCronJobSpec{
+				Schedule:           tc.schedule,
+				ScheduleExpression: pointer.String(tc.expression),
+				TimeZone:           tc.timeZone,
+			}
+			errs := validateAdvancedCronJobSpecSchedule(spec, field.NewPath("spec"), now)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateTriggerAnnotation(t *testing.T) {
+	newObj := func(paused, triggered bool) *appsv1beta1.AdvancedCronJob {
+		obj := &appsv1beta1.AdvancedCronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-acj",
+				Namespace:       "default",
+				ResourceVersion: "1",
+			},
+			Spec: appsv1beta1.AdvancedCronJobSpec{
+				Schedule:          "0 * * * *",
+				ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+				Paused:            boolPtr(paused),
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: createValidPodTemplateSpec(),
+						},
 					},
 				},
 			},
-			expectedResult: false,
-			expectedError:  true,
+		}
+		if triggered {
+			obj.Annotations = map[string]string{appsv1beta1.AdvancedCronJobTriggerAnnotation: "2025-10-10T09:00:00Z"}
+		}
+		return obj
+	}
+	handler := &AdvancedCronJobCreateUpdateHandler{}
+
+	// create
+	if _, errs := handler.validateAdvancedCronJob(newObj(false, true)); len(errs) > 0 {
+		t.Errorf("expected no error for triggered AdvancedCronJob, got %v", errs)
+	}
+	if _, errs := handler.validateAdvancedCronJob(newObj(true, true)); len(errs) == 0 {
+		t.Errorf("expected error for triggered and paused AdvancedCronJob")
+	}
+
+	// trigger a paused AdvancedCronJob
+	if _, errs := handler.validateAdvancedCronJobUpdate(newObj(true, true), newObj(true, false)); len(errs) == 0 {
+		t.Errorf("expected error for triggering a paused AdvancedCronJob")
+	}
+	// pause a triggered AdvancedCronJob
+	if _, errs := handler.validateAdvancedCronJobUpdate(newObj(true, true), newObj(false, true)); len(errs) == 0 {
+		t.Errorf("expected error for pausing a triggered AdvancedCronJob")
+	}
+	// pause and remove the trigger
+	if _, errs := handler.validateAdvancedCronJobUpdate(newObj(true, false), newObj(false, true)); len(errs) > 0 {
+		t.Errorf("expected no error for pausing and removing the trigger, got %v", errs)
+	}
+}
+
+func TestValidateReservedAnnotations(t *testing.T) {
+	cases := []struct {
+		name           string
+		annotations    map[string]string
+		oldAnnotations map[string]string
+		expectErr      bool
+	}{
+		{name: "no annotations"},
+		{name: "unreserved annotation", annotations: map[string]string{"example.com/trigger": "now"}},
+		{name: "valid trigger", annotations: map[string]string{appsv1beta1.AdvancedCronJobTriggerAnnotation: "2025-10-10T09:00:00Z"}},
+		{name: "valid schedule-modified with offset", annotations: map[string]string{appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation: "2025-10-10T09:00:00+08:00"}},
+		{name: "tzdata-version is not a time", annotations: map[string]string{appsv1beta1.AdvancedCronJobTZDataVersionAnnotation: "2025b"}},
+		{name: "empty trigger", annotations: map[string]string{appsv1beta1.AdvancedCronJobTriggerAnnotation: ""}, expectErr: true},
+		{name: "trigger without time zone", annotations: map[string]string{appsv1beta1.AdvancedCronJobTriggerAnnotation: "2025-10-10 09:00:00"}, expectErr: true},
+		{name: "malformed schedule-modified", annotations: map[string]string{appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation: "yesterday"}, expectErr: true},
+		{name: "tzdata-version with a patch letter", annotations: map[string]string{appsv1beta1.AdvancedCronJobTZDataVersionAnnotation: "2024aa"}},
+		{name: "malformed tzdata-version", annotations: map[string]string{appsv1beta1.AdvancedCronJobTZDataVersionAnnotation: "latest"}, expectErr: true},
+		{name: "empty tzdata-version", annotations: map[string]string{appsv1beta1.AdvancedCronJobTZDataVersionAnnotation: ""}, expectErr: true},
+		{name: "unknown reserved annotation", annotations: map[string]string{"advancedcronjob.kruise.io/triger": "2025-10-10T09:00:00Z"}, expectErr: true},
+		{
+			name:           "unknown reserved annotation kept on update",
+			annotations:    map[string]string{"advancedcronjob.kruise.io/owner": "team-a", "app": "demo"},
+			oldAnnotations: map[string]string{"advancedcronjob.kruise.io/owner": "team-b"},
 		},
 		{
-			name: "create invalid v1beta1 AdvancedCronJob with no template",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-no-template",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule: "0 0 * * *",
-								Template: appsv1beta1.CronJobTemplate{},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: false,
-			expectedError:  true,
+			name:           "unknown reserved annotation added on update",
+			annotations:    map[string]string{"advancedcronjob.kruise.io/owner": "team-a", "advancedcronjob.kruise.io/triger": "2025-10-10T09:00:00Z"},
+			oldAnnotations: map[string]string{"advancedcronjob.kruise.io/owner": "team-a"},
+			expectErr:      true,
 		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := validateReservedAnnotations(c.annotations, c.oldAnnotations, field.NewPath("metadata", "annotations"))
+			if c.expectErr != (len(errs) > 0) {
+				t.Errorf("expected error %v, got %v", c.expectErr, errs)
+			}
+		})
+	}
+}
+
+func TestUppercaseRepositoryComponents(t *testing.T) {
+	cases := []struct {
+		image    string
+		expected []string
+	}{
+		{image: "nginx:1.25"},
+		{image: "Registry.Example.com:5000/library/nginx:Latest"},
+		{image: "localhost/team/app@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+		{image: "MyRepo/App", expected: []string{"App"}},
+		{image: "myrepo/App:v1", expected: []string{"App"}},
+		{image: "docker.io/MyOrg/MyApp:v1", expected: []string{"MyOrg", "MyApp"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.image, func(t *testing.T) {
+			if uppercase := uppercaseRepositoryComponents(tc.image); !reflect.DeepEqual(uppercase, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, uppercase)
+			}
+		})
+	}
+}
+
+func TestValidateSpecSize(t *testing.T) {
+	spec := &appsv1beta1.AdvancedCronJobSpec{
+		Schedule: "0 0 * * *",
+		Template: appsv1beta1.CronJobTemplate{ImageListPullJobTemplate: createValidImageListPullJobTemplateSpec()},
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("failed to marshal the spec: %v", err)
+	}
+
+	cases := []struct {
+		name      string
+		max       int
+		expectErr bool
+	}{
 		{
-			name: "create invalid v1beta1 AdvancedCronJob with both templates",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-both-templates",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule: "0 0 * * *",
-								Template: appsv1beta1.CronJobTemplate{
-									JobTemplate: &batchv1.JobTemplateSpec{
-										Spec: batchv1.JobSpec{
-											Template: v1.PodTemplateSpec{
-												Spec: v1.PodSpec{
-													Containers: []v1.Container{
-														{
-															Name:  "test",
-															Image: "nginx:latest",
-														},
-													},
-													RestartPolicy: v1.RestartPolicyOnFailure,
-												},
-											},
-										},
-									},
-									BroadcastJobTemplate: &appsv1beta1.BroadcastJobTemplateSpec{
-										Spec: appsv1beta1.BroadcastJobSpec{
-											Template: v1.PodTemplateSpec{
-												Spec: v1.PodSpec{
-													Containers: []v1.Container{
-														{
-															Name:  "test",
-															Image: "nginx:latest",
-														},
-													},
-													RestartPolicy: v1.RestartPolicyOnFailure,
-												},
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: false,
-			expectedError:  true,
+			name: "no limit",
 		},
 		{
-			name: "create v1alpha1 AdvancedCronJob",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1alpha1.GroupVersion.Group,
-						Version:  appsv1alpha1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Alpha1JSON(t, &appsv1alpha1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-v1alpha1",
-								Namespace: "default",
-							},
-							Spec: appsv1alpha1.AdvancedCronJobSpec{
-								Schedule: "0 0 * * *",
-								Template: appsv1alpha1.CronJobTemplate{
-									BroadcastJobTemplate: &appsv1alpha1.BroadcastJobTemplateSpec{
-										Spec: appsv1alpha1.BroadcastJobSpec{
-											Template: createValidPodTemplateSpec(),
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: true,
-			expectedError:  false,
+			name: "at the limit",
+			max:  len(data),
 		},
 		{
-			name: "update v1beta1 AdvancedCronJob with forbidden field change",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Update,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-update",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule: "0 0 * * *",
-								Template: appsv1beta1.CronJobTemplate{
-									JobTemplate: &batchv1.JobTemplateSpec{
-										Spec: batchv1.JobSpec{
-											Template: createValidPodTemplateSpec(),
-										},
-									},
-								},
-							},
-						}),
-					},
-					OldObject: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-update",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule: "0 0 * * *",
-								Template: appsv1beta1.CronJobTemplate{
-									JobTemplate: &batchv1.JobTemplateSpec{
-										Spec: batchv1.JobSpec{
-											Template: createValidPodTemplateSpec(),
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: false,
-			expectedError:  true,
+			name:      "exceed the limit",
+			max:       len(data) - 1,
+			expectErr: true,
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateSpecSize(spec, field.NewPath("spec"), tc.max)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+			if tc.expectErr && !strings.Contains(errs[0].Error(), fmt.Sprintf("is %d bytes", len(data))) {
+				t.Errorf("expected the size in the error, got %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSkipDates(t *testing.T) {
+	cases := []struct {
+		name         string
+		skipDates    []string
+		expectedErrs field.ErrorList
+	}{
 		{
-			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-v1beta1",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												PullSecrets: nil,
-												Selector: &appsv1beta1.ImagePullJobNodeSelector{
-													Names: []string{
-														"node1",
-													},
-												},
-												PodSelector: nil,
-												Parallelism: nil,
-												PullPolicy:  nil,
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                  appsv1beta1.Always,
-													ActiveDeadlineSeconds: int64Ptr(100),
-												},
-												SandboxConfig:   nil,
-												ImagePullPolicy: "",
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
+			name:      "valid",
+			skipDates: []string{"2025-12-25", "2026-01-01"},
+		},
+		{
+			name:      "invalid date",
+			skipDates: []string{"2025-12-25", "2025/12/26", "2025-02-30"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "skipDates").Index(1), "2025/12/26", "must be a date in YYYY-MM-DD"),
+				field.Invalid(field.NewPath("spec", "skipDates").Index(2), "2025-02-30", "must be a date in YYYY-MM-DD"),
 			},
-			expectedResult: true,
-			expectedError:  false,
 		},
 		{
-			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate forbidden field",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-v1beta1",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												Selector: &appsv1beta1.ImagePullJobNodeSelector{
-													Names: []string{
-														"node1",
-													},
-												},
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                    appsv1beta1.Always,
-													TTLSecondsAfterFinished: int32Ptr(100),
-												},
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
+			name:      "duplicate date",
+			skipDates: []string{"2025-12-25", "2026-01-01", "2025-12-25"},
+			expectedErrs: field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "skipDates").Index(2), "2025-12-25"),
 			},
-			expectedResult: true,
-			expectedError:  true,
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateSkipDates(tc.skipDates, field.NewPath("spec", "skipDates"))
+			if len(tc.expectedErrs) == 0 {
+				tc.expectedErrs = field.ErrorList{}
+			}
+			if !reflect.DeepEqual(errs, tc.expectedErrs) {
+				t.Errorf("expected %v, got %v", tc.expectedErrs, errs)
+			}
+		})
+	}
+}
+
+func TestValidateStepValues(t *testing.T) {
+	cases := []struct {
+		schedule    string
+		expectedErr string
+	}{
+		{schedule: "*/5 * * * *"},
+		{schedule: "0 9-17/2 * * 1-5"},
+		{schedule: "@every 1h"},
+		{schedule: "*/0 * * * *", expectedErr: "step value must be positive in field 'minute'"},
+		{schedule: "0 0 * * 1-5/0", expectedErr: "step value must be positive in field 'day-of-week'"},
+		{schedule: "CRON_TZ=UTC 0 1,2/-1 * * *", expectedErr: "step value must be positive in field 'hour'"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.schedule, func(t *testing.T) {
+			err := validateCronSchedule(tc.schedule)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("expected %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateHiddenCharacters(t *testing.T) {
+	cases := []struct {
+		name               string
+		schedule           string
+		scheduleExpression *string
+		expectedErr        string
+	}{
 		{
-			name: "update v1beta1 AdvancedCronJob ImageListPullJobTemplate",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Update,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:            "test-acj-update",
-								Namespace:       "default",
-								ResourceVersion: "xxxx",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:                   "1 0 * * *",
-								TimeZone:                   strPtr("Asia/Shanghai"),
-								StartingDeadlineSeconds:    int64Ptr(100),
-								ConcurrencyPolicy:          appsv1beta1.ForbidConcurrent,
-								Paused:                     boolPtr(true),
-								SuccessfulJobsHistoryLimit: int32Ptr(5),
-								FailedJobsHistoryLimit:     int32Ptr(4),
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-												"nginx:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												PullSecrets: []string{"secret1"},
-												Selector: &appsv1beta1.ImagePullJobNodeSelector{
-													Names: []string{
-														"node1",
-														"node2",
-													},
-												},
-												PodSelector: nil,
-												Parallelism: &intstr.IntOrString{
-													Type:   intstr.Int,
-													IntVal: 2,
-												},
-												PullPolicy: &appsv1beta1.PullPolicy{
-													TimeoutSeconds: int32Ptr(30),
-													BackoffLimit:   int32Ptr(1),
-												},
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                  appsv1beta1.Always,
-													ActiveDeadlineSeconds: int64Ptr(200),
-												},
-												SandboxConfig:   nil,
-												ImagePullPolicy: appsv1beta1.PullAlways,
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-					OldObject: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								ResourceVersion: "xxxx",
-								Name:            "test-acj-update",
-								Namespace:       "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												PullSecrets: nil,
-												Selector: &appsv1beta1.ImagePullJobNodeSelector{
-													Names: []string{
-														"node1",
-													},
-												},
-												PodSelector: nil,
-												Parallelism: &intstr.IntOrString{
-													Type:   intstr.Int,
-													IntVal: 1,
-												},
-												PullPolicy: nil,
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                  appsv1beta1.Always,
-													ActiveDeadlineSeconds: int64Ptr(100),
-												},
-												SandboxConfig:   nil,
-												ImagePullPolicy: appsv1beta1.PullIfNotPresent,
-											},
-										},
-									},
-								},
-							},
-						}),
+			name:     "plain schedule",
+			schedule: "0 9 * * 1-5",
+		},
+		{
+			name:     "tab and no-break space",
+			schedule: "0\t9 * *\u00a0*",
+		},
+		{
+			name:        "zero-width space",
+			schedule:    "0 9\u200b * * *",
+			expectedErr: "U+200B at byte 3",
+		},
+		{
+			name:        "control character",
+			schedule:    "0 9 * * *\x00",
+			expectedErr: "U+0000 at byte 9",
+		},
+		{
+			name:               "byte order mark in scheduleExpression",
+			scheduleExpression: strPtr("\ufeff0 9 * * *"),
+			expectedErr:        "U+FEFF at byte 0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule, ScheduleExpression: tc.scheduleExpression}
+			errs := validateAdvancedCronJobSpecSchedule(spec, field.NewPath("spec"), time.Now())
+			if tc.expectedErr == "" {
+				if len(errs) > 0 {
+					t.Fatalf("expected no error, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Detail, tc.expectedErr) {
+				t.Fatalf("expected a hidden character error with %q, got %v", tc.expectedErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateEmbeddedTimeZones(t *testing.T) {
+	cases := []struct {
+		name      string
+		schedule  string
+		expectErr bool
+	}{
+		{name: "no time zone", schedule: "0 * * * *"},
+		{name: "one time zone", schedule: "TZ=Asia/Shanghai 0 * * * *"},
+		{name: "TZ and CRON_TZ", schedule: "TZ=Asia/Shanghai CRON_TZ=UTC 0 * * * *", expectErr: true},
+		{name: "the same time zone twice", schedule: "CRON_TZ=UTC CRON_TZ=UTC 0 * * * *", expectErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateAdvancedCronJobSpecSchedule(&appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule}, field.NewPath("spec"), time.Now())
+			if !tc.expectErr {
+				if len(errs) > 0 {
+					t.Fatalf("expected no error, got %v", errs)
+				}
+				return
+			}
+			// the ambiguous time zones are reported instead of the error of the cron parser
+			if len(errs) != 1 || !strings.Contains(errs[0].Detail, "only one TZ= or CRON_TZ= prefix is allowed") {
+				t.Fatalf("expected an error about the time zones, got %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSolarSchedule(t *testing.T) {
+	sunset := func(offset time.Duration, latitude, longitude string) *appsv1beta1.SolarSchedule {
+		return &appsv1beta1.SolarSchedule{
+			Event:     appsv1beta1.SunsetSolarEvent,
+			Offset:    &metav1.Duration{Duration: offset},
+			Latitude:  latitude,
+			Longitude: longitude,
+		}
+	}
+
+	cases := []struct {
+		name          string
+		enabled       bool
+		schedule      string
+		solarSchedule *appsv1beta1.SolarSchedule
+		expectedErrs  []string
+	}{
+		{
+			name:          "gate disabled",
+			solarSchedule: sunset(30*time.Minute, "52.52", "13.405"),
+			expectedErrs:  []string{"spec.solarSchedule"},
+		},
+		{
+			name:          "valid",
+			enabled:       true,
+			solarSchedule: sunset(30*time.Minute, "52.52", "13.405"),
+		},
+		{
+			name:          "with schedule",
+			enabled:       true,
+			schedule:      "0 * * * *",
+			solarSchedule: sunset(30*time.Minute, "52.52", "13.405"),
+			expectedErrs:  []string{"spec.schedule"},
+		},
+		{
+			name:    "unknown event",
+			enabled: true,
+			solarSchedule: &appsv1beta1.SolarSchedule{
+				Event:     "noon",
+				Latitude:  "52.52",
+				Longitude: "13.405",
+			},
+			expectedErrs: []string{"spec.solarSchedule.event"},
+		},
+		{
+			name:          "coordinates out of range",
+			enabled:       true,
+			solarSchedule: sunset(0, "-90.5", "180.5"),
+			expectedErrs:  []string{"spec.solarSchedule.latitude", "spec.solarSchedule.longitude"},
+		},
+		{
+			name:          "coordinates not decimal",
+			enabled:       true,
+			solarSchedule: sunset(0, "52°31'N", ""),
+			expectedErrs:  []string{"spec.solarSchedule.latitude", "spec.solarSchedule.longitude"},
+		},
+		{
+			name:          "offset too large",
+			enabled:       true,
+			solarSchedule: sunset(-13*time.Hour, "52.52", "13.405"),
+			expectedErrs:  []string{"spec.solarSchedule.offset"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobSolarSchedule, tc.enabled)()
+			spec := &appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule, SolarSchedule: tc.solarSchedule}
+			errs := validateAdvancedCronJobSpecSchedule(spec, field.NewPath("spec"), time.Now())
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			if len(fields) != len(tc.expectedErrs) {
+				t.Fatalf("expected errors of %v, got %v", tc.expectedErrs, errs)
+			}
+			for i := range fields {
+				if fields[i] != tc.expectedErrs[i] {
+					t.Errorf("expected errors of %v, got %v", tc.expectedErrs, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateScheduleTimeZone(t *testing.T) {
+	solarSchedule := &appsv1beta1.SolarSchedule{Event: appsv1beta1.SunriseSolarEvent, Latitude: "52.52", Longitude: "13.405"}
+	runAt := &metav1.Time{Time: time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)}
+
+	cases := []struct {
+		name      string
+		spec      appsv1beta1.AdvancedCronJobSpec
+		expectErr bool
+	}{
+		{
+			name: "schedule without timeZone",
+			spec: appsv1beta1.AdvancedCronJobSpec{Schedule: "0 * * * *"},
+		},
+		{
+			name: "schedule with timeZone",
+			spec: appsv1beta1.AdvancedCronJobSpec{Schedule: "0 * * * *", TimeZone: strPtr("Asia/Shanghai")},
+		},
+		{
+			name: "scheduleExpression with timeZone",
+			spec: appsv1beta1.AdvancedCronJobSpec{ScheduleExpression: strPtr("0 * * * *"), TimeZone: strPtr("Asia/Shanghai")},
+		},
+		{
+			name: "solarSchedule with timeZone",
+			spec: appsv1beta1.AdvancedCronJobSpec{SolarSchedule: solarSchedule, TimeZone: strPtr("Europe/Berlin")},
+		},
+		{
+			name:      "solarSchedule without timeZone",
+			spec:      appsv1beta1.AdvancedCronJobSpec{SolarSchedule: solarSchedule},
+			expectErr: true,
+		},
+		{
+			name: "runAt without timeZone",
+			spec: appsv1beta1.AdvancedCronJobSpec{RunAt: runAt},
+		},
+		{
+			name:      "runAt with timeZone",
+			spec:      appsv1beta1.AdvancedCronJobSpec{RunAt: runAt, TimeZone: strPtr("Asia/Shanghai")},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateScheduleTimeZone(&tc.spec, field.NewPath("spec"))
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+			for _, err := range errs {
+				if err.Field != "spec.timeZone" {
+					t.Errorf("expected error on spec.timeZone, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRunAt(t *testing.T) {
+	now := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	newObj := func(runAt time.Time, lastScheduleTime *time.Time) *appsv1beta1.AdvancedCronJob {
+		obj := &appsv1beta1.AdvancedCronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-acj",
+				Namespace:       "default",
+				ResourceVersion: "1",
+			},
+			Spec: appsv1beta1.AdvancedCronJobSpec{
+				RunAt:             &metav1.Time{Time: runAt},
+				ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: createValidPodTemplateSpec(),
+						},
 					},
 				},
 			},
-			expectedResult: true,
-			expectedError:  false,
+		}
+		if lastScheduleTime != nil {
+			obj.Status.LastScheduleTime = &metav1.Time{Time: *lastScheduleTime}
+		}
+		return obj
+	}
+	handler := &AdvancedCronJobCreateUpdateHandler{Clock: clocktesting.NewFakePassiveClock(now)}
+
+	// create
+	if _, errs := handler.validateAdvancedCronJob(newObj(now.Add(time.Hour), nil)); len(errs) > 0 {
+		t.Errorf("expected no error for runAt in the future, got %v", errs)
+	}
+	if _, errs := handler.validateAdvancedCronJob(newObj(now.Add(-time.Hour), nil)); len(errs) == 0 {
+		t.Errorf("expected error for runAt in the past")
+	}
+	if _, errs := handler.validateAdvancedCronJob(newObj(now.Add(time.Second), nil)); len(errs) == 0 {
+		t.Errorf("expected error for runAt within minRunAtDelay")
+	}
+	withSchedule := newObj(now.Add(time.Hour), nil)
+	withSchedule.Spec.Schedule = "0 * * * *"
+	if _, errs := handler.validateAdvancedCronJob(withSchedule); len(errs) == 0 {
+		t.Errorf("expected error for both runAt and schedule")
+	}
+
+	// update
+	firedAt := now.Add(-time.Minute)
+	if _, errs := handler.validateAdvancedCronJobUpdate(newObj(now.Add(-time.Hour), nil), newObj(now.Add(time.Hour), nil)); len(errs) > 0 {
+		t.Errorf("expected no error for moving runAt before it has fired, got %v", errs)
+	}
+	if _, errs := handler.validateAdvancedCronJobUpdate(newObj(now.Add(-time.Hour), &firedAt), newObj(firedAt, &firedAt)); len(errs) == 0 {
+		t.Errorf("expected error for moving runAt into the past after it has fired")
+	}
+	if _, errs := handler.validateAdvancedCronJobUpdate(newObj(now.Add(time.Hour), &firedAt), newObj(firedAt, &firedAt)); len(errs) > 0 {
+		t.Errorf("expected no error for moving runAt into the future after it has fired, got %v", errs)
+	}
+}
+
+func TestValidateRunAtStartingDeadline(t *testing.T) {
+	created := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	maxSeconds := int64(maxRunAtStartingDeadline / time.Second)
+
+	cases := []struct {
+		name                    string
+		runAt                   time.Time
+		startingDeadlineSeconds *int64
+		expectedErrs            int
+	}{
+		{
+			name:  "no deadline",
+			runAt: created.Add(time.Minute),
+		},
+		{
+			name:                    "deadline within the wait",
+			runAt:                   created.Add(time.Hour),
+			startingDeadlineSeconds: int64Ptr(60),
+		},
+		{
+			name:                    "deadline equal to the wait",
+			runAt:                   created.Add(time.Hour),
+			startingDeadlineSeconds: int64Ptr(3600),
+		},
+		{
+			name:                    "deadline one second longer than the wait",
+			runAt:                   created.Add(time.Hour),
+			startingDeadlineSeconds: int64Ptr(3601),
+			expectedErrs:            1,
+		},
+		{
+			name:                    "deadline equal to the max",
+			runAt:                   created.Add(2 * maxRunAtStartingDeadline),
+			startingDeadlineSeconds: int64Ptr(maxSeconds),
+		},
+		{
+			name:                    "deadline one second longer than the max",
+			runAt:                   created.Add(2 * maxRunAtStartingDeadline),
+			startingDeadlineSeconds: int64Ptr(maxSeconds + 1),
+			expectedErrs:            1,
+		},
+		{
+			name:                    "deadline longer than both the max and the wait",
+			runAt:                   created.Add(time.Hour),
+			startingDeadlineSeconds: int64Ptr(maxSeconds + 1),
+			expectedErrs:            2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateRunAtStartingDeadline(tc.runAt, tc.startingDeadlineSeconds, created)
+			if len(errs) != tc.expectedErrs {
+				t.Fatalf("expected %d errors, got %v", tc.expectedErrs, errs)
+			}
+		})
+	}
+}
+
+func TestValidateAllowedTimeZones(t *testing.T) {
+	cases := []struct {
+		name      string
+		allowed   []string
+		timeZone  *string
+		expectErr bool
+	}{
+		{
+			name:     "no allowed list",
+			timeZone: pointer.String("Asia/Shanghai"),
+		},
+		{
+			name:     "allowed",
+			allowed:  []string{"UTC", " Asia/Shanghai"},
+			timeZone: pointer.String("Asia/Shanghai"),
+		},
+		{
+			name:      "not allowed",
+			allowed:   []string{"UTC", "Asia/Shanghai"},
+			timeZone:  pointer.String("America/New_York"),
+			expectErr: true,
+		},
+		{
+			name:     "timeZone not set",
+			allowed:  []string{"UTC"},
+			timeZone: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateTimeZone(tc.timeZone, field.NewPath("spec", "timeZone"), tc.allowed)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+			if tc.expectErr && !strings.Contains(errs[0].Error(), `"Asia/Shanghai"`) {
+				t.Errorf("expected the allowed time zones in the error, got %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateTimeZoneFormat(t *testing.T) {
+	cases := []struct {
+		name         string
+		timeZone     string
+		expectedType field.ErrorType
+	}{
+		{
+			name:     "zone with a sign",
+			timeZone: "Etc/GMT+5",
+		},
+		{
+			name:     "long zone",
+			timeZone: "America/Argentina/ComodRivadavia",
+		},
+		{
+			name:         "too long",
+			timeZone:     strings.Repeat("A", 5000),
+			expectedType: field.ErrorTypeTooLong,
+		},
+		{
+			name:         "whitespace",
+			timeZone:     "Asia/Shang hai",
+			expectedType: field.ErrorTypeInvalid,
+		},
+		{
+			name:         "path traversal",
+			timeZone:     "../../etc/passwd",
+			expectedType: field.ErrorTypeInvalid,
+		},
+		{
+			name:         "empty",
+			timeZone:     "",
+			expectedType: field.ErrorTypeInvalid,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateTimeZone(&tc.timeZone, field.NewPath("spec", "timeZone"), nil)
+			if tc.expectedType == "" {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Type != tc.expectedType {
+				t.Fatalf("expected an error of type %s, got %v", tc.expectedType, errs)
+			}
+		})
+	}
+}
+
+func TestCompletionPolicyChangeWarnings(t *testing.T) {
+	// allow Never as a completionPolicy type supported in the future
+	advancedcronjob.AllowedCompletionPolicyTypes.Insert(appsv1beta1.Never)
+	defer advancedcronjob.AllowedCompletionPolicyTypes.Delete(appsv1beta1.Never)
+
+	newObj := func(completionPolicyType appsv1beta1.CompletionPolicyType) *appsv1beta1.AdvancedCronJob {
+		return &appsv1beta1.AdvancedCronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-acj",
+				Namespace:       "default",
+				ResourceVersion: "1",
+			},
+			Spec: appsv1beta1.AdvancedCronJobSpec{
+				Schedule:          "0 0 * * *",
+				ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+				Template: appsv1beta1.CronJobTemplate{
+					ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+						Spec: appsv1beta1.ImageListPullJobSpec{
+							Images: []string{"busybox:latest"},
+							ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+								CompletionPolicy: appsv1beta1.CompletionPolicy{Type: completionPolicyType},
+							},
+						},
+					},
+				},
+			},
+			Status: appsv1beta1.AdvancedCronJobStatus{
+				Active: []v1.ObjectReference{{Kind: "ImageListPullJob", Namespace: "default", Name: "test-acj-1"}},
+			},
+		}
+	}
+	activeJob := &appsv1beta1.ImageListPullJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-acj-1", Namespace: "default"},
+		Spec: appsv1beta1.ImageListPullJobSpec{
+			Images: []string{"busybox:latest"},
+			ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+				CompletionPolicy: appsv1beta1.CompletionPolicy{Type: appsv1beta1.Always},
+			},
+		},
+	}
+	testScheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(testScheme))
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(activeJob.DeepCopy()).Build(),
+	}
+
+	cases := []struct {
+		name          string
+		obj           *appsv1beta1.AdvancedCronJob
+		oldObj        *appsv1beta1.AdvancedCronJob
+		expectWarning bool
+	}{
+		{
+			name:          "completionPolicy type changed",
+			obj:           newObj(appsv1beta1.Never),
+			oldObj:        newObj(appsv1beta1.Always),
+			expectWarning: true,
+		},
+		{
+			name:   "completionPolicy type unchanged",
+			obj:    newObj(appsv1beta1.Always),
+			oldObj: newObj(appsv1beta1.Always),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings, errs := handler.validateAdvancedCronJobUpdate(tc.obj, tc.oldObj)
+			if len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+			found := false
+			for _, warning := range warnings {
+				if strings.Contains(warning, "applies only to the ImageListPullJobs of future runs, 1 active ImageListPullJob(s) keep the old policy") {
+					found = true
+				}
+			}
+			if found != tc.expectWarning {
+				t.Fatalf("expected warning %v, got %v", tc.expectWarning, warnings)
+			}
+
+			// the in-flight ImageListPullJob is left as it is
+			job := &appsv1beta1.ImageListPullJob{}
+			if err := handler.Client.Get(context.TODO(), client.ObjectKeyFromObject(activeJob), job); err != nil {
+				t.Fatalf("failed to get ImageListPullJob: %v", err)
+			}
+			if !reflect.DeepEqual(job.Spec, activeJob.Spec) {
+				t.Errorf("expected ImageListPullJob not mutated, got %+v", job.Spec)
+			}
+		})
+	}
+}
+
+func TestValidateScheduleEditCooldown(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobScheduleEditCooldown, true)()
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Clock:  clocktesting.NewFakePassiveClock(time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)),
+		Config: &ValidationConfig{ScheduleEditCooldown: 5 * time.Minute},
+	}
+	newObj := func(schedule, lastModified string) *appsv1beta1.AdvancedCronJob {
+		obj := &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{Schedule: schedule}}
+		if lastModified != "" {
+			obj.Annotations = map[string]string{appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation: lastModified}
+		}
+		return obj
+	}
+
+	cases := []struct {
+		name          string
+		obj           *appsv1beta1.AdvancedCronJob
+		oldObj        *appsv1beta1.AdvancedCronJob
+		expectedError string
+	}{
+		{
+			name:   "never modified",
+			obj:    newObj("*/5 * * * *", "2025-10-10T09:00:00Z"),
+			oldObj: newObj("0 0 * * *", ""),
+		},
+		{
+			name:   "modified after the cooldown",
+			obj:    newObj("*/5 * * * *", "2025-10-10T09:00:00Z"),
+			oldObj: newObj("0 0 * * *", "2025-10-10T08:55:00Z"),
+		},
+		{
+			name:   "modified within the cooldown",
+			obj:    newObj("*/5 * * * *", "2025-10-10T09:00:00Z"),
+			oldObj: newObj("0 0 * * *", "2025-10-10T08:58:30Z"),
+			expectedError: "spec.schedule: Forbidden: the schedule was modified at 2025-10-10T08:58:30Z, " +
+				"it can be modified again in 3m30s, after the cooldown of 5m0s",
+		},
+		{
+			name:   "schedule not modified",
+			obj:    newObj("0 0 * * *", "2025-10-10T08:58:30Z"),
+			oldObj: newObj("0 0 * * *", "2025-10-10T08:58:30Z"),
+		},
+		{
+			name:   "schedule rewritten without changing the fire times",
+			obj:    newObj("@daily", "2025-10-10T08:58:30Z"),
+			oldObj: newObj("0 0 * * *", "2025-10-10T08:58:30Z"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := handler.validateScheduleEditCooldown(tc.obj, tc.oldObj)
+			if tc.expectedError == "" {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Error() != tc.expectedError {
+				t.Fatalf("expected error %q, got %v", tc.expectedError, errs)
+			}
+		})
+	}
+}
+
+func TestValidateScheduleModifiedAnnotation(t *testing.T) {
+	now := time.Date(2025, 10, 10, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{name: "in the past", value: "2025-10-10T08:58:30Z"},
+		{name: "now", value: "2025-10-10T09:00:00Z"},
+		{name: "in the future", value: "2099-01-01T00:00:00Z", expectErr: true},
+		{name: "in the future with offset", value: "2025-10-10T10:00:00-08:00", expectErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			annotations := map[string]string{appsv1beta1.AdvancedCronJobScheduleModifiedAnnotation: tc.value}
+			errs := validateScheduleModifiedAnnotation(annotations, field.NewPath("metadata", "annotations"), now)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Errorf("expected error %v, got %v", tc.expectErr, errs)
+			}
+		})
+	}
+}
+
+func TestImminentRunWarnings(t *testing.T) {
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Clock: clocktesting.NewFakePassiveClock(time.Date(2025, 10, 10, 8, 59, 30, 0, time.UTC)),
+	}
+	newObj := func(schedule string, startingDeadlineSeconds *int64, paused bool) *appsv1beta1.AdvancedCronJob {
+		return &appsv1beta1.AdvancedCronJob{
+			Spec: appsv1beta1.AdvancedCronJobSpec{
+				Schedule:                schedule,
+				TimeZone:                pointer.String("UTC"),
+				StartingDeadlineSeconds: startingDeadlineSeconds,
+				Paused:                  boolPtr(paused),
+			},
+		}
+	}
+
+	cases := []struct {
+		name          string
+		obj           *appsv1beta1.AdvancedCronJob
+		oldObj        *appsv1beta1.AdvancedCronJob
+		expectWarning bool
+	}{
+		{
+			name:          "imminent run dropped",
+			obj:           newObj("30 9 * * *", int64Ptr(60), false),
+			oldObj:        newObj("0 9 * * *", int64Ptr(60), false),
+			expectWarning: true,
+		},
+		{
+			name:   "imminent run kept",
+			obj:    newObj("0 9 * * ?", int64Ptr(60), false),
+			oldObj: newObj("0 9 * * *", int64Ptr(60), false),
+		},
+		{
+			name:   "next run beyond startingDeadlineSeconds",
+			obj:    newObj("30 9 * * *", int64Ptr(10), false),
+			oldObj: newObj("0 9 * * *", int64Ptr(10), false),
+		},
+		{
+			name:   "no startingDeadlineSeconds",
+			obj:    newObj("30 9 * * *", nil, false),
+			oldObj: newObj("0 9 * * *", nil, false),
+		},
+		{
+			name:   "paused",
+			obj:    newObj("30 9 * * *", int64Ptr(60), true),
+			oldObj: newObj("0 9 * * *", int64Ptr(60), true),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings := handler.imminentRunWarnings(tc.obj, tc.oldObj)
+			if tc.expectWarning != (len(warnings) > 0) {
+				t.Fatalf("expected warning %v, got %v", tc.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestImmediateRunWarnings(t *testing.T) {
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Clock: clocktesting.NewFakePassiveClock(time.Date(2025, 10, 10, 8, 59, 30, 0, time.UTC)),
+	}
+	newObj := func(schedule string, paused bool) *appsv1beta1.AdvancedCronJob {
+		return &appsv1beta1.AdvancedCronJob{
+			Spec: appsv1beta1.AdvancedCronJobSpec{
+				Schedule: schedule,
+				TimeZone: pointer.String("UTC"),
+				Paused:   boolPtr(paused),
+			},
+		}
+	}
+
+	cases := []struct {
+		name          string
+		obj           *appsv1beta1.AdvancedCronJob
+		expectWarning bool
+	}{
+		{
+			name:          "first run within a minute",
+			obj:           newObj("0 9 * * *", false),
+			expectWarning: true,
+		},
+		{
+			name:          "every minute",
+			obj:           newObj("* * * * *", false),
+			expectWarning: true,
+		},
+		{
+			name: "first run beyond a minute",
+			obj:  newObj("1 9 * * *", false),
+		},
+		{
+			name: "paused",
+			obj:  newObj("0 9 * * *", true),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings := handler.immediateRunWarnings(tc.obj)
+			if tc.expectWarning != (len(warnings) > 0) {
+				t.Fatalf("expected warning %v, got %v", tc.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestScheduleWarnings(t *testing.T) {
+	cases := []struct {
+		schedule           string
+		scheduleExpression *string
+		expectWarning      bool
+	}{
+		{schedule: "0 0 * * *"},
+		{schedule: "0 0 1 * *"},
+		{schedule: "0 0 * * MON"},
+		{schedule: "0 0 */2 * MON"},
+		{schedule: "0 0 ? * MON"},
+		{schedule: "@monthly"},
+		{schedule: "0 0 1 * 1", expectWarning: true},
+		{schedule: "0 0 1,15 * MON-FRI", expectWarning: true},
+		{schedule: "TZ=UTC 0 0 1 * MON", expectWarning: true},
+		{schedule: "0 0 29 2 *", expectWarning: true},
+		{scheduleExpression: pointer.String("0 9 * * MON-FRI except lastday")},
+		{scheduleExpression: pointer.String("0 9 1 * MON except date 2025-12-01"), expectWarning: true},
+	}
+
+	for _, tc := range cases {
+		spec := &appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule, ScheduleExpression: tc.scheduleExpression}
+		warnings := scheduleWarnings(spec, field.NewPath("spec"), time.Date(2025, 10, 16, 0, 0, 0, 0, time.UTC))
+		if tc.expectWarning != (len(warnings) > 0) {
+			t.Errorf("schedule %q expression %v: expected warning %v, got %v", tc.schedule, tc.scheduleExpression, tc.expectWarning, warnings)
+		}
+	}
+}
+
+func TestLeapDayWarnings(t *testing.T) {
+	now := time.Date(2025, 10, 16, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name          string
+		spec          appsv1beta1.AdvancedCronJobSpec
+		expectWarning string
+	}{
+		{name: "annual", spec: appsv1beta1.AdvancedCronJobSpec{Schedule: "0 0 28 2 *", TimeZone: pointer.String("UTC")}},
+		{name: "february", spec: appsv1beta1.AdvancedCronJobSpec{Schedule: "0 0 * 2 *", TimeZone: pointer.String("UTC")}},
+		{name: "several months", spec: appsv1beta1.AdvancedCronJobSpec{Schedule: "0 0 29 1,2 *", TimeZone: pointer.String("UTC")}},
+		{name: "day of week", spec: appsv1beta1.AdvancedCronJobSpec{Schedule: "0 0 29 2 MON", TimeZone: pointer.String("UTC")}},
+		{
+			name:          "leap day",
+			spec:          appsv1beta1.AdvancedCronJobSpec{Schedule: "30 8 29 2 *", TimeZone: pointer.String("Asia/Shanghai")},
+			expectWarning: "spec.schedule: fires only on February 29, which occurs in leap years only, the next run is at 2028-02-29T08:30:00+08:00",
+		},
+		{
+			name:          "leap day in expression",
+			spec:          appsv1beta1.AdvancedCronJobSpec{ScheduleExpression: pointer.String("TZ=UTC 0 0 29 2 * except date 2032-02-29")},
+			expectWarning: "spec.schedule: fires only on February 29, which occurs in leap years only, the next run is at 2028-02-29T00:00:00Z",
+		},
+		{name: "run at", spec: appsv1beta1.AdvancedCronJobSpec{RunAt: &metav1.Time{Time: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings := leapDayWarnings(&tc.spec, field.NewPath("spec", "schedule"), now)
+			if tc.expectWarning == "" {
+				if len(warnings) > 0 {
+					t.Fatalf("expected no warning, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || warnings[0] != tc.expectWarning {
+				t.Fatalf("expected warning %q, got %v", tc.expectWarning, warnings)
+			}
+		})
+	}
+
+	// the handler computes the next run after the time of its clock
+	obj := &appsv1beta1.AdvancedCronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-acj", Namespace: "default"},
+		Spec: appsv1beta1.AdvancedCronJobSpec{
+			Schedule:          "0 0 29 2 *",
+			TimeZone:          pointer.String("UTC"),
+			ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+			Template: appsv1beta1.CronJobTemplate{
+				JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: createValidPodTemplateSpec()}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		now     time.Time
+		nextRun string
+	}{
+		{now: now, nextRun: "2028-02-29T00:00:00Z"},
+		{now: time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC), nextRun: "2032-02-29T00:00:00Z"},
+	} {
+		handler := &AdvancedCronJobCreateUpdateHandler{Clock: clocktesting.NewFakePassiveClock(tc.now)}
+		warnings, _ := handler.validateAdvancedCronJob(obj)
+		expectWarning := "spec.schedule: fires only on February 29, which occurs in leap years only, the next run is at " + tc.nextRun
+		found := false
+		for _, warning := range warnings {
+			if warning == expectWarning {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("at %s: expected warning %q, got %v", tc.now, expectWarning, warnings)
+		}
+	}
+}
+
+func TestValidatePriorityClassName(t *testing.T) {
+	newSpec := func(priorityClassName string) *appsv1beta1.AdvancedCronJobSpec {
+		template := createValidPodTemplateSpec()
+		template.Spec.PriorityClassName = priorityClassName
+		return &appsv1beta1.AdvancedCronJobSpec{
+			Schedule:          "0 * * * *",
+			ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+			Template: appsv1beta1.CronJobTemplate{
+				JobTemplate: &batchv1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: template,
+					},
+				},
+			},
+		}
+	}
+
+	// the format of priorityClassName is validated along with the pod template
+	if _, errs := validateAdvancedCronJobSpec(newSpec("Low_Priority"), field.NewPath("spec"), time.Now(), DefaultImageRefNormalizer, DefaultValidationConfig()); len(errs) == 0 {
+		t.Errorf("expected error for invalid priorityClassName")
+	}
+	if _, errs := validateAdvancedCronJobSpec(newSpec("low-priority"), field.NewPath("spec"), time.Now(), DefaultImageRefNormalizer, DefaultValidationConfig()); len(errs) > 0 {
+		t.Errorf("unexpected error for valid priorityClassName: %v", errs)
+	}
+
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&schedulingv1.PriorityClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "low-priority"},
+			Value:      100,
+		}).Build(),
+	}
+	cases := []struct {
+		priorityClassName string
+		expectWarning     bool
+	}{
+		{priorityClassName: ""},
+		{priorityClassName: "low-priority"},
+		{priorityClassName: "lower-priority", expectWarning: true},
+	}
+	for _, tc := range cases {
+		warnings := handler.priorityClassWarnings(context.TODO(), newSpec(tc.priorityClassName), field.NewPath("spec"))
+		if tc.expectWarning != (len(warnings) > 0) {
+			t.Errorf("priorityClassName %q: expected warning %v, got %v", tc.priorityClassName, tc.expectWarning, warnings)
+		}
+	}
+}
+
+func TestPrePullWarnings(t *testing.T) {
+	ilpJob := &appsv1beta1.ImageListPullJob{ObjectMeta: metav1.ObjectMeta{Name: "prepull", Namespace: "default"}}
+	ilpJob.Spec.Images = []string{"nginx:1.25"}
+	ilpCronJob := &appsv1beta1.AdvancedCronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "prepull-cron", Namespace: "default"},
+		Spec: appsv1beta1.AdvancedCronJobSpec{
+			Template: appsv1beta1.CronJobTemplate{ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{}},
+		},
+	}
+	ilpCronJob.Spec.Template.ImageListPullJobTemplate.Spec.Images = []string{"docker.io/library/busybox:1.36"}
+	testScheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(testScheme))
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(ilpJob, ilpCronJob).Build(),
+	}
+	newObj := func(namespace string) *appsv1beta1.AdvancedCronJob {
+		template := createValidPodTemplateSpec()
+		template.Spec.InitContainers = []v1.Container{{Name: "init", Image: "busybox:1.36"}}
+		template.Spec.Containers = []v1.Container{
+			{Name: "web", Image: "docker.io/library/nginx:1.25"},
+			{Name: "cache", Image: "redis:7"},
+		}
+		return &appsv1beta1.AdvancedCronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-acj", Namespace: namespace},
+			Spec: appsv1beta1.AdvancedCronJobSpec{
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}},
+				},
+			},
+		}
+	}
+
+	if warnings := handler.prePullWarnings(context.TODO(), newObj("default"), field.NewPath("spec")); len(warnings) > 0 {
+		t.Errorf("expected no warning with the feature-gate disabled, got %v", warnings)
+	}
+
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobPrePullWarning, true)()
+	warnings := handler.prePullWarnings(context.TODO(), newObj("default"), field.NewPath("spec"))
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "spec.template.jobTemplate.spec.template.spec.containers[1].image: image redis:7") {
+		t.Errorf("expected a warning about redis:7 only, got %v", warnings)
+	}
+	// a namespace pre-pulling no images is not warned about
+	if warnings := handler.prePullWarnings(context.TODO(), newObj("other"), field.NewPath("spec")); len(warnings) > 0 {
+		t.Errorf("expected no warning in a namespace without ImageListPullJobs, got %v", warnings)
+	}
+}
+
+func TestValidateGroupUniqueness(t *testing.T) {
+	newObj := func(name, group, schedule string, template appsv1beta1.CronJobTemplate) *appsv1beta1.AdvancedCronJob {
+		obj := &appsv1beta1.AdvancedCronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       appsv1beta1.AdvancedCronJobSpec{Schedule: schedule, Template: template},
+		}
+		if group != "" {
+			obj.Labels = map[string]string{"team": group}
+		}
+		return obj
+	}
+	jobTemplate := appsv1beta1.CronJobTemplate{JobTemplate: &batchv1.JobTemplateSpec{}}
+	broadcastJobTemplate := appsv1beta1.CronJobTemplate{BroadcastJobTemplate: &appsv1beta1.BroadcastJobTemplateSpec{}}
+	testScheme := runtime.NewScheme()
+	utilruntime.Must(appsv1beta1.AddToScheme(testScheme))
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			newObj("nightly", "payments", "0 2 * * *", jobTemplate),
+			newObj("hourly", "payments", "@every 1h", jobTemplate),
+		).Build(),
+	}
+
+	if errs := handler.validateGroupUniqueness(context.TODO(), newObj("copy", "payments", "0 2 * * *", jobTemplate)); len(errs) > 0 {
+		t.Errorf("expected no error without the group label configured, got %v", errs)
+	}
+
+	handler.Config = &ValidationConfig{GroupLabel: "team"}
+	testCases := []struct {
+		name        string
+		obj         *appsv1beta1.AdvancedCronJob
+		expectError bool
+	}{
+		{"same schedule and kind", newObj("copy", "payments", "0 2 * * *", jobTemplate), true},
+		{"same schedule with extra spaces", newObj("copy", "payments", " 0  2 * * * ", jobTemplate), true},
+		{"same interval", newObj("copy", "payments", "TZ=Asia/Shanghai @every 1h", jobTemplate), true},
+		{"same object", newObj("nightly", "payments", "0 2 * * *", jobTemplate), false},
+		{"another schedule", newObj("copy", "payments", "0 3 * * *", jobTemplate), false},
+		{"another kind", newObj("copy", "payments", "0 2 * * *", broadcastJobTemplate), false},
+		{"another group", newObj("copy", "billing", "0 2 * * *", jobTemplate), false},
+		{"no group", newObj("copy", "", "0 2 * * *", jobTemplate), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := handler.validateGroupUniqueness(context.TODO(), tc.obj)
+			if tc.expectError != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectError, errs)
+			}
+			if tc.expectError && !strings.Contains(errs[0].Detail, "AdvancedCronJob ") {
+				t.Errorf("expected the error to point to the existing AdvancedCronJob, got %v", errs[0].Detail)
+			}
+		})
+	}
+}
+
+func TestValidateRestrictedConcurrency(t *testing.T) {
+	newObj := func(policy appsv1beta1.ConcurrencyPolicy, template appsv1beta1.CronJobTemplate) *appsv1beta1.AdvancedCronJob {
+		return &appsv1beta1.AdvancedCronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-acj", Namespace: "default"},
+			Spec:       appsv1beta1.AdvancedCronJobSpec{ConcurrencyPolicy: policy, Template: template},
+		}
+	}
+	broadcastJobTemplate := appsv1beta1.CronJobTemplate{BroadcastJobTemplate: &appsv1beta1.BroadcastJobTemplateSpec{}}
+	jobTemplate := appsv1beta1.CronJobTemplate{JobTemplate: &batchv1.JobTemplateSpec{}}
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+			&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+		).Build(),
+	}
+
+	if errs := handler.validateRestrictedConcurrency(context.TODO(), newObj(appsv1beta1.AllowConcurrent, broadcastJobTemplate), nil); len(errs) > 0 {
+		t.Errorf("expected no error with the feature-gate disabled, got %v", errs)
+	}
+
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobLimitBroadcastConcurrency, true)()
+	testCases := []struct {
+		name        string
+		maxNodes    int
+		obj         *appsv1beta1.AdvancedCronJob
+		oldObj      *appsv1beta1.AdvancedCronJob
+		expectError bool
+	}{
+		{"Allow in any cluster", 0, newObj(appsv1beta1.AllowConcurrent, broadcastJobTemplate), nil, true},
+		{"Allow in a large cluster", 1, newObj(appsv1beta1.AllowConcurrent, broadcastJobTemplate), nil, true},
+		{"Allow in a small cluster", 2, newObj(appsv1beta1.AllowConcurrent, broadcastJobTemplate), nil, false},
+		{"Forbid", 0, newObj(appsv1beta1.ForbidConcurrent, broadcastJobTemplate), nil, false},
+		{"Allow for a Job template", 0, newObj(appsv1beta1.AllowConcurrent, jobTemplate), nil, false},
+		{"changed to Allow", 0, newObj(appsv1beta1.AllowConcurrent, broadcastJobTemplate), newObj(appsv1beta1.ReplaceConcurrent, broadcastJobTemplate), true},
+		{"existing Allow", 0, newObj(appsv1beta1.AllowConcurrent, broadcastJobTemplate), newObj(appsv1beta1.AllowConcurrent, broadcastJobTemplate), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler.Config = &ValidationConfig{BroadcastJobAllowConcurrentMaxNodes: tc.maxNodes}
+			errs := handler.validateRestrictedConcurrency(context.TODO(), tc.obj, tc.oldObj)
+			if tc.expectError != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectError, errs)
+			}
+			if tc.expectError && errs[0].Detail != "BroadcastJob does not support Allow because overlapping runs add pods to every node of the cluster" {
+				t.Errorf("unexpected error detail %q", errs[0].Detail)
+			}
+		})
+	}
+}
+
+func TestValidateVolumeReferences(t *testing.T) {
+	podSpec := &core.PodSpec{Volumes: []core.Volume{
+		{Name: "config", VolumeSource: core.VolumeSource{ConfigMap: &core.ConfigMapVolumeSource{LocalObjectReference: core.LocalObjectReference{Name: "app-config"}}}},
+		{Name: "secret", VolumeSource: core.VolumeSource{Secret: &core.SecretVolumeSource{SecretName: "App_Secret"}}},
+		{Name: "data", VolumeSource: core.VolumeSource{PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{ClaimName: "data.-claim"}}},
+		{Name: "empty", VolumeSource: core.VolumeSource{ConfigMap: &core.ConfigMapVolumeSource{}}},
+		{Name: "projected", VolumeSource: core.VolumeSource{Projected: &core.ProjectedVolumeSource{Sources: []core.VolumeProjection{
+			{ConfigMap: &core.ConfigMapProjection{LocalObjectReference: core.LocalObjectReference{Name: "shared.config"}}},
+			{Secret: &core.SecretProjection{LocalObjectReference: core.LocalObjectReference{Name: "shared secret"}}},
+		}}}},
+		{Name: "scratch", VolumeSource: core.VolumeSource{EmptyDir: &core.EmptyDirVolumeSource{}}},
+	}}
+
+	var fields []string
+	for _, err := range validateVolumeReferences(podSpec, field.NewPath("spec")) {
+		fields = append(fields, err.Field)
+	}
+	expected := []string{
+		"spec.volumes[1].secret.secretName",
+		"spec.volumes[2].persistentVolumeClaim.claimName",
+		"spec.volumes[4].projected.sources[1].secret.name",
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected errors %v, got %v", expected, fields)
+	}
+
+	// the Job and BroadcastJob templates are both validated
+	podTemplate := createValidPodTemplateSpec()
+	podTemplate.Spec.Volumes = []v1.Volume{{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "App-Config"}}}}}
+	if _, errs := validateJobTemplateSpec(&batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: podTemplate}}, 0, field.NewPath("spec"), DefaultValidationConfig()); !hasErrorOn(errs, "spec.template.jobTemplate.spec.template.spec.volumes[0].configMap.name") {
+		t.Errorf("expected error on the Job template volumes, got %v", errs)
+	}
+	if _, errs := validateBroadcastJobTemplateSpec(&appsv1beta1.BroadcastJobTemplateSpec{Spec: appsv1beta1.BroadcastJobSpec{Template: podTemplate}}, 0, field.NewPath("spec"), DefaultValidationConfig()); !hasErrorOn(errs, "spec.template.broadcastJobTemplate.spec.template.spec.volumes[0].configMap.name") {
+		t.Errorf("expected error on the BroadcastJob template volumes, got %v", errs)
+	}
+}
+
+func TestVolumeReferenceWarnings(t *testing.T) {
+	configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "default"}}
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configMap).Build(),
+	}
+	template := createValidPodTemplateSpec()
+	template.Spec.Volumes = []v1.Volume{
+		{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}}}},
+		{Name: "secret", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "app-secret"}}},
+		{Name: "optional", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "optional-secret", Optional: pointer.Bool(true)}}},
+		{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+	}
+	obj := &appsv1beta1.AdvancedCronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-acj", Namespace: "default"},
+		Spec: appsv1beta1.AdvancedCronJobSpec{
+			Template: appsv1beta1.CronJobTemplate{
+				BroadcastJobTemplate: &appsv1beta1.BroadcastJobTemplateSpec{Spec: appsv1beta1.BroadcastJobSpec{Template: template}},
+			},
+		},
+	}
+
+	if warnings := handler.volumeReferenceWarnings(context.TODO(), obj, field.NewPath("spec")); len(warnings) > 0 {
+		t.Errorf("expected no warning with the feature-gate disabled, got %v", warnings)
+	}
+
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobVolumeRefWarning, true)()
+	warnings := handler.volumeReferenceWarnings(context.TODO(), obj, field.NewPath("spec"))
+	expected := []string{
+		`spec.template.broadcastJobTemplate.spec.template.spec.volumes[1].secret.secretName: Secret "app-secret" not found in namespace default, ` +
+			"the pods of the scheduled jobs can not start until it is created",
+		`spec.template.broadcastJobTemplate.spec.template.spec.volumes[3].persistentVolumeClaim.claimName: PersistentVolumeClaim "data" not found in namespace default, ` +
+			"the pods of the scheduled jobs can not start until it is created",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected warnings %v, got %v", expected, warnings)
+	}
+}
+
+func TestValidateOwnerReferences(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobValidateOwnerReferences, true)()
+
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+	}
+	cases := []struct {
+		name         string
+		ownerRef     metav1.OwnerReference
+		expectedErrs int
+	}{
+		{
+			name:     "registered controller owner",
+			ownerRef: metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "owner", UID: "1", Controller: pointer.Bool(true)},
+		},
+		{
+			name:     "unregistered owner which is not the controller",
+			ownerRef: metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Foo", Name: "owner", UID: "1"},
+		},
+		{
+			name:         "unregistered controller owner",
+			ownerRef:     metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Foo", Name: "owner", UID: "1", Controller: pointer.Bool(true)},
+			expectedErrs: 1,
+		},
+		{
+			name:         "controller owner of a misspelled kind",
+			ownerRef:     metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deploymnet", Name: "owner", UID: "1", Controller: pointer.Bool(true)},
+			expectedErrs: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := handler.validateOwnerReferences([]metav1.OwnerReference{tc.ownerRef}, field.NewPath("metadata", "ownerReferences"))
+			if len(errs) != tc.expectedErrs {
+				t.Fatalf("expected %d errors, got %v", tc.expectedErrs, errs)
+			}
+		})
+	}
+
+	// more than one controller owner is rejected along with the object meta
+	acj := &appsv1beta1.AdvancedCronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", OwnerReferences: []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: "Deployment", Name: "a", UID: "1", Controller: pointer.Bool(true)},
+			{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "b", UID: "2", Controller: pointer.Bool(true)},
+		}},
+	}
+	_, errs := handler.validateAdvancedCronJob(acj)
+	found := false
+	for _, err := range errs {
+		if err.Field == "metadata.ownerReferences" && strings.Contains(err.Detail, "Only one reference can have Controller set to true") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected error for multiple controller owners, got %v", errs)
+	}
+}
+
+func TestValidateResourceRequests(t *testing.T) {
+	withRequests := createValidPodTemplateSpec()
+	withRequests.Spec.Containers[0].Resources.Requests = v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("100m"),
+		v1.ResourceMemory: resource.MustParse("128Mi"),
+	}
+	withCPUOnly := createValidPodTemplateSpec()
+	withCPUOnly.Spec.Containers[0].Resources.Requests = v1.ResourceList{
+		v1.ResourceCPU: resource.MustParse("100m"),
+	}
+
+	cases := []struct {
+		name         string
+		enabled      bool
+		template     v1.PodTemplateSpec
+		expectedErrs int
+	}{
+		{
+			name:     "gate disabled",
+			template: createValidPodTemplateSpec(),
+		},
+		{
+			name:     "gate enabled with requests",
+			enabled:  true,
+			template: withRequests,
+		},
+		{
+			name:         "gate enabled without requests",
+			enabled:      true,
+			template:     createValidPodTemplateSpec(),
+			expectedErrs: 2,
+		},
+		{
+			name:         "gate enabled without memory request",
+			enabled:      true,
+			template:     withCPUOnly,
+			expectedErrs: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobRequireResourceRequests, tc.enabled)()
+
+			_, jobErrs := validateJobTemplateSpec(&batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: tc.template}}, 0, field.NewPath("spec"), DefaultValidationConfig())
+			if len(jobErrs) != tc.expectedErrs {
+				t.Errorf("expected %d errors for job template, got %v", tc.expectedErrs, jobErrs)
+			}
+			for _, err := range jobErrs {
+				if !strings.HasPrefix(err.Field, "spec.template.jobTemplate.spec.template.spec.containers[0].resources.requests") {
+					t.Errorf("expected error on the job template containers, got %v", err.Field)
+				}
+			}
+			_, brJobErrs := validateBroadcastJobTemplateSpec(&appsv1beta1.BroadcastJobTemplateSpec{Spec: appsv1beta1.BroadcastJobSpec{Template: tc.template}}, 0, field.NewPath("spec"), DefaultValidationConfig())
+			if len(brJobErrs) != tc.expectedErrs {
+				t.Errorf("expected %d errors for broadcastjob template, got %v", tc.expectedErrs, brJobErrs)
+			}
+			for _, err := range brJobErrs {
+				if !strings.HasPrefix(err.Field, "spec.template.broadcastJobTemplate.spec.template.spec.containers[0].resources.requests") {
+					t.Errorf("expected error on the broadcastjob template containers, got %v", err.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSecurityContextBaseline(t *testing.T) {
+	nonRoot := &core.PodSecurityContext{RunAsNonRoot: pointer.Bool(true)}
+	container := func(sc *core.SecurityContext) core.Container {
+		return core.Container{Name: "main", Image: "busybox", SecurityContext: sc}
+	}
+	cases := []struct {
+		name           string
+		rules          []securityContextRule
+		podSpec        core.PodSpec
+		expectedFields []string
+	}{
+		{
+			name:           "runAsNonRoot unset",
+			rules:          []securityContextRule{runAsNonRootRule},
+			podSpec:        core.PodSpec{Containers: []core.Container{container(nil)}},
+			expectedFields: []string{"spec.containers[0].securityContext.runAsNonRoot"},
+		},
+		{
+			name:    "runAsNonRoot of the pod",
+			rules:   []securityContextRule{runAsNonRootRule},
+			podSpec: core.PodSpec{SecurityContext: nonRoot, InitContainers: []core.Container{container(nil)}, Containers: []core.Container{container(nil)}},
+		},
+		{
+			name:  "runAsNonRoot of the pod overridden by the container",
+			rules: []securityContextRule{runAsNonRootRule},
+			podSpec: core.PodSpec{SecurityContext: nonRoot, Containers: []core.Container{
+				container(nil), container(&core.SecurityContext{RunAsNonRoot: pointer.Bool(false)}),
+			}},
+			expectedFields: []string{"spec.containers[1].securityContext.runAsNonRoot"},
+		},
+		{
+			name:  "runAsNonRoot false in the pod",
+			rules: []securityContextRule{runAsNonRootRule},
+			podSpec: core.PodSpec{SecurityContext: &core.PodSecurityContext{RunAsNonRoot: pointer.Bool(false)}, InitContainers: []core.Container{
+				container(nil),
+			}, Containers: []core.Container{container(&core.SecurityContext{RunAsNonRoot: pointer.Bool(true)})}},
+			expectedFields: []string{"spec.securityContext.runAsNonRoot"},
+		},
+		{
+			name:           "root user of the pod",
+			rules:          []securityContextRule{runAsNonRootRule},
+			podSpec:        core.PodSpec{SecurityContext: &core.PodSecurityContext{RunAsNonRoot: pointer.Bool(true), RunAsUser: pointer.Int64(0)}, Containers: []core.Container{container(nil)}},
+			expectedFields: []string{"spec.securityContext.runAsUser"},
+		},
+		{
+			name:  "root user of the pod overridden by the container",
+			rules: []securityContextRule{runAsNonRootRule},
+			podSpec: core.PodSpec{SecurityContext: &core.PodSecurityContext{RunAsNonRoot: pointer.Bool(true), RunAsUser: pointer.Int64(0)}, Containers: []core.Container{
+				container(&core.SecurityContext{RunAsUser: pointer.Int64(1000)}),
+			}},
+		},
+		{
+			name:  "privilege escalation and writable root filesystem",
+			rules: []securityContextRule{noPrivilegeEscalationRule, readOnlyRootFilesystemRule},
+			podSpec: core.PodSpec{Containers: []core.Container{
+				container(&core.SecurityContext{AllowPrivilegeEscalation: pointer.Bool(false), ReadOnlyRootFilesystem: pointer.Bool(true)}),
+				container(&core.SecurityContext{AllowPrivilegeEscalation: pointer.Bool(true)}),
+			}},
+			expectedFields: []string{
+				"spec.containers[1].securityContext.allowPrivilegeEscalation",
+				"spec.containers[1].securityContext.readOnlyRootFilesystem",
+			},
+		},
+		{
+			name:    "no rules",
+			podSpec: core.PodSpec{Containers: []core.Container{container(nil)}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fields []string
+			for _, err := range validateSecurityContextBaseline(&tc.podSpec, tc.rules, field.NewPath("spec")) {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(tc.expectedFields, fields) {
+				t.Errorf("expected errors on %v, got %v", tc.expectedFields, fields)
+			}
+		})
+	}
+
+	// the Job and BroadcastJob templates are validated against the baseline with the feature-gate enabled
+	template := createValidPodTemplateSpec()
+	if _, errs := validateJobTemplateSpec(&batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}}, 0, field.NewPath("spec"), DefaultValidationConfig()); len(errs) > 0 {
+		t.Errorf("expected no error with the feature-gate disabled, got %v", errs)
+	}
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobSecurityContextBaseline, true)()
+	if _, errs := validateJobTemplateSpec(&batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}}, 0, field.NewPath("spec"), DefaultValidationConfig()); !hasErrorOn(errs, "spec.template.jobTemplate.spec.template.spec.containers[0].securityContext.runAsNonRoot") {
+		t.Errorf("expected error on the Job template containers, got %v", errs)
+	}
+	if _, errs := validateBroadcastJobTemplateSpec(&appsv1beta1.BroadcastJobTemplateSpec{Spec: appsv1beta1.BroadcastJobSpec{Template: template}}, 0, field.NewPath("spec"), DefaultValidationConfig()); !hasErrorOn(errs, "spec.template.broadcastJobTemplate.spec.template.spec.containers[0].securityContext.runAsNonRoot") {
+		t.Errorf("expected error on the BroadcastJob template containers, got %v", errs)
+	}
+	template.Spec.SecurityContext = &v1.PodSecurityContext{RunAsNonRoot: pointer.Bool(true)}
+	if _, errs := validateJobTemplateSpec(&batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}}, 0, field.NewPath("spec"), DefaultValidationConfig()); len(errs) > 0 {
+		t.Errorf("expected no error for the Job template running as non-root, got %v", errs)
+	}
+}
+
+func TestParseSecurityContextBaseline(t *testing.T) {
+	rules, err := parseSecurityContextBaseline("RunAsNonRoot, ReadOnlyRootFilesystem")
+	if err != nil || !reflect.DeepEqual(rules, []securityContextRule{runAsNonRootRule, readOnlyRootFilesystemRule}) {
+		t.Errorf("unexpected rules %v, error %v", rules, err)
+	}
+	if rules, err := parseSecurityContextBaseline(""); err != nil || len(rules) > 0 {
+		t.Errorf("expected no rules, got %v, error %v", rules, err)
+	}
+	if _, err := parseSecurityContextBaseline("RunAsNonRoot,Privileged"); err == nil {
+		t.Errorf("expected error for the unknown rule")
+	}
+}
+
+func TestValidateRunResourceRequests(t *testing.T) {
+	config := &ValidationConfig{}
+	var err error
+	if config.MaxRunResourceRequests, err = parseResourceList("cpu=1, memory=1Gi"); err != nil {
+		t.Fatalf("failed to parse the budget: %v", err)
+	}
+
+	requests := func(cpu, memory string) v1.ResourceRequirements {
+		return v1.ResourceRequirements{Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+		}}
+	}
+	// a pod requests 800m cpu by the init container and 512Mi memory by the containers
+	template := createValidPodTemplateSpec()
+	template.Spec.Containers[0].Resources = requests("300m", "256Mi")
+	sidecar := template.Spec.Containers[0]
+	sidecar.Name = "sidecar"
+	template.Spec.Containers = append(template.Spec.Containers, sidecar)
+	initContainer := template.Spec.Containers[0]
+	initContainer.Name = "init"
+	initContainer.Resources = requests("800m", "64Mi")
+	template.Spec.InitContainers = []v1.Container{initContainer}
+
+	cases := []struct {
+		name         string
+		enabled      bool
+		parallelism  *int32
+		expectedErrs int
+	}{
+		{
+			name:        "gate disabled",
+			parallelism: int32Ptr(3),
+		},
+		{
+			name:    "a pod within the budget",
+			enabled: true,
+		},
+		{
+			name:         "cpu of two pods exceeds the budget",
+			enabled:      true,
+			parallelism:  int32Ptr(2),
+			expectedErrs: 1,
+		},
+		{
+			name:         "cpu and memory of three pods exceed the budget",
+			enabled:      true,
+			parallelism:  int32Ptr(3),
+			expectedErrs: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobLimitRunResources, tc.enabled)()
+
+			_, jobErrs := validateJobTemplateSpec(&batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template, Parallelism: tc.parallelism}}, 0, field.NewPath("spec"), config)
+			if len(jobErrs) != tc.expectedErrs {
+				t.Errorf("expected %d errors for job template, got %v", tc.expectedErrs, jobErrs)
+			}
+			for _, err := range jobErrs {
+				if err.Field != "spec.template.jobTemplate.spec.template.spec.containers" {
+					t.Errorf("expected error on the job template containers, got %v", err.Field)
+				}
+			}
+			// the budget of a BroadcastJob is per pod
+			_, brJobErrs := validateBroadcastJobTemplateSpec(&appsv1beta1.BroadcastJobTemplateSpec{Spec: appsv1beta1.BroadcastJobSpec{Template: template}}, 0, field.NewPath("spec"), config)
+			if len(brJobErrs) != 0 {
+				t.Errorf("expected no errors for broadcastjob template, got %v", brJobErrs)
+			}
+		})
+	}
+}
+
+func TestParseResourceList(t *testing.T) {
+	resources, err := parseResourceList("cpu=8,memory=16Gi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := core.ResourceList{core.ResourceCPU: resource.MustParse("8"), core.ResourceMemory: resource.MustParse("16Gi")}
+	if !apiequality.Semantic.DeepEqual(expected, resources) {
+		t.Errorf("expected %v, got %v", expected, resources)
+	}
+	if resources, err := parseResourceList(""); err != nil || resources != nil {
+		t.Errorf("expected no resources for an empty value, got %v, %v", resources, err)
+	}
+	for _, value := range []string{"cpu", "cpu=eight"} {
+		if _, err := parseResourceList(value); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
+
+func TestDuplicateImagePullSecretWarnings(t *testing.T) {
+	cases := []struct {
+		name             string
+		secrets          []string
+		expectedWarnings []string
+	}{
+		{
+			name:    "distinct secrets",
+			secrets: []string{"registry-a", "registry-b"},
+		},
+		{
+			name:    "duplicate secrets",
+			secrets: []string{"registry-a", "registry-b", "registry-a", "registry-a"},
+			expectedWarnings: []string{
+				`spec.template.broadcastJobTemplate.spec.template.spec.imagePullSecrets[2]: duplicate imagePullSecret "registry-a"`,
+				`spec.template.broadcastJobTemplate.spec.template.spec.imagePullSecrets[3]: duplicate imagePullSecret "registry-a"`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := createValidPodTemplateSpec()
+			for _, secret := range tc.secrets {
+				template.Spec.ImagePullSecrets = append(template.Spec.ImagePullSecrets, v1.LocalObjectReference{Name: secret})
+			}
+			warnings, errs := validateBroadcastJobTemplateSpec(&appsv1beta1.BroadcastJobTemplateSpec{
+				Spec: appsv1beta1.BroadcastJobSpec{Template: template},
+			}, 0, field.NewPath("spec"), DefaultValidationConfig())
+			if len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+			if !reflect.DeepEqual(tc.expectedWarnings, warnings) {
+				t.Errorf("expected warnings %v, got %v", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestAdvancedCronJobCreateUpdateHandler_Handle(t *testing.T) {
+	utilruntime.Must(apis.AddToScheme(scheme.Scheme))
+
+	tests := []struct {
+		name           string
+		request        admission.Request
+		expectedResult bool
+		expectedError  bool
+	}{
+		{
+			name: "create valid v1beta1 AdvancedCronJob",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule: "0 0 * * *",
+								Template: appsv1beta1.CronJobTemplate{
+									JobTemplate: &batchv1.JobTemplateSpec{
+										Spec: batchv1.JobSpec{
+											Template: createValidPodTemplateSpec(),
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  false,
+		},
+		{
+			name: "create invalid v1beta1 AdvancedCronJob with empty schedule",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-invalid",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule: "",
+								Template: appsv1beta1.CronJobTemplate{
+									JobTemplate: &batchv1.JobTemplateSpec{
+										Spec: batchv1.JobSpec{
+											Template: v1.PodTemplateSpec{
+												Spec: v1.PodSpec{
+													Containers: []v1.Container{
+														{
+															Name:  "test",
+															Image: "nginx:latest",
+														},
+													},
+													RestartPolicy: v1.RestartPolicyOnFailure,
+												},
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: false,
+			expectedError:  true,
+		},
+		{
+			name: "create invalid v1beta1 AdvancedCronJob with invalid schedule",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-invalid-schedule",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule: "invalid-cron",
+								Template: appsv1beta1.CronJobTemplate{
+									JobTemplate: &batchv1.JobTemplateSpec{
+										Spec: batchv1.JobSpec{
+											Template: v1.PodTemplateSpec{
+												Spec: v1.PodSpec{
+													Containers: []v1.Container{
+														{
+															Name:  "test",
+															Image: "nginx:latest",
+														},
+													},
+													RestartPolicy: v1.RestartPolicyOnFailure,
+												},
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: false,
+			expectedError:  true,
+		},
+		{
+			name: "create invalid v1beta1 AdvancedCronJob with no template",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-no-template",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule: "0 0 * * *",
+								Template: appsv1beta1.CronJobTemplate{},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: false,
+			expectedError:  true,
+		},
+		{
+			name: "create invalid v1beta1 AdvancedCronJob with both templates",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-both-templates",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule: "0 0 * * *",
+								Template: appsv1beta1.CronJobTemplate{
+									JobTemplate: &batchv1.JobTemplateSpec{
+										Spec: batchv1.JobSpec{
+											Template: v1.PodTemplateSpec{
+												Spec: v1.PodSpec{
+													Containers: []v1.Container{
+														{
+															Name:  "test",
+															Image: "nginx:latest",
+														},
+													},
+													RestartPolicy: v1.RestartPolicyOnFailure,
+												},
+											},
+										},
+									},
+									BroadcastJobTemplate: &appsv1beta1.BroadcastJobTemplateSpec{
+										Spec: appsv1beta1.BroadcastJobSpec{
+											Template: v1.PodTemplateSpec{
+												Spec: v1.PodSpec{
+													Containers: []v1.Container{
+														{
+															Name:  "test",
+															Image: "nginx:latest",
+														},
+													},
+													RestartPolicy: v1.RestartPolicyOnFailure,
+												},
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: false,
+			expectedError:  true,
+		},
+		{
+			name: "create v1alpha1 AdvancedCronJob",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1alpha1.GroupVersion.Group,
+						Version:  appsv1alpha1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Alpha1JSON(t, &appsv1alpha1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-v1alpha1",
+								Namespace: "default",
+							},
+							Spec: appsv1alpha1.AdvancedCronJobSpec{
+								Schedule: "0 0 * * *",
+								Template: appsv1alpha1.CronJobTemplate{
+									BroadcastJobTemplate: &appsv1alpha1.BroadcastJobTemplateSpec{
+										Spec: appsv1alpha1.BroadcastJobSpec{
+											Template: createValidPodTemplateSpec(),
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  false,
+		},
+		{
+			name: "update v1beta1 AdvancedCronJob with forbidden field change",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-update",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule: "0 0 * * *",
+								Template: appsv1beta1.CronJobTemplate{
+									JobTemplate: &batchv1.JobTemplateSpec{
+										Spec: batchv1.JobSpec{
+											Template: createValidPodTemplateSpec(),
+										},
+									},
+								},
+							},
+						}),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-update",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule: "0 0 * * *",
+								Template: appsv1beta1.CronJobTemplate{
+									JobTemplate: &batchv1.JobTemplateSpec{
+										Spec: batchv1.JobSpec{
+											Template: createValidPodTemplateSpec(),
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: false,
+			expectedError:  true,
+		},
+		{
+			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-v1beta1",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												PullSecrets: nil,
+												Selector: &appsv1beta1.ImagePullJobNodeSelector{
+													Names: []string{
+														"node1",
+													},
+												},
+												PodSelector: nil,
+												Parallelism: nil,
+												PullPolicy:  nil,
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                  appsv1beta1.Always,
+													ActiveDeadlineSeconds: int64Ptr(100),
+												},
+												SandboxConfig:   nil,
+												ImagePullPolicy: "",
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  false,
+		},
+		{
+			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate forbidden field",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-v1beta1",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												Selector: &appsv1beta1.ImagePullJobNodeSelector{
+													Names: []string{
+														"node1",
+													},
+												},
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                    appsv1beta1.Always,
+													TTLSecondsAfterFinished: int32Ptr(100),
+												},
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  true,
+		},
+		{
+			name: "update v1beta1 AdvancedCronJob ImageListPullJobTemplate",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:            "test-acj-update",
+								Namespace:       "default",
+								ResourceVersion: "xxxx",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:                   "1 0 * * *",
+								TimeZone:                   strPtr("Asia/Shanghai"),
+								StartingDeadlineSeconds:    int64Ptr(100),
+								ConcurrencyPolicy:          appsv1beta1.ForbidConcurrent,
+								Paused:                     boolPtr(true),
+								SuccessfulJobsHistoryLimit: int32Ptr(5),
+								FailedJobsHistoryLimit:     int32Ptr(4),
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+												"nginx:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												PullSecrets: []string{"secret1"},
+												Selector: &appsv1beta1.ImagePullJobNodeSelector{
+													Names: []string{
+														"node1",
+														"node2",
+													},
+												},
+												PodSelector: nil,
+												Parallelism: &intstr.IntOrString{
+													Type:   intstr.Int,
+													IntVal: 2,
+												},
+												PullPolicy: &appsv1beta1.PullPolicy{
+													TimeoutSeconds: int32Ptr(30),
+													BackoffLimit:   int32Ptr(1),
+												},
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                  appsv1beta1.Always,
+													ActiveDeadlineSeconds: int64Ptr(200),
+												},
+												SandboxConfig:   nil,
+												ImagePullPolicy: appsv1beta1.PullAlways,
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								ResourceVersion: "xxxx",
+								Name:            "test-acj-update",
+								Namespace:       "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												PullSecrets: nil,
+												Selector: &appsv1beta1.ImagePullJobNodeSelector{
+													Names: []string{
+														"node1",
+													},
+												},
+												PodSelector: nil,
+												Parallelism: &intstr.IntOrString{
+													Type:   intstr.Int,
+													IntVal: 1,
+												},
+												PullPolicy: nil,
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                  appsv1beta1.Always,
+													ActiveDeadlineSeconds: int64Ptr(100),
+												},
+												SandboxConfig:   nil,
+												ImagePullPolicy: appsv1beta1.PullIfNotPresent,
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  false,
+		},
+		{
+			name: "update v1beta1 AdvancedCronJob ImageListPullJobTemplate with forbidden field",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:            "test-acj-update",
+								Namespace:       "default",
+								ResourceVersion: "xxxx",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type: appsv1beta1.Never,
+												},
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								ResourceVersion: "xxxx",
+								Name:            "test-acj-update",
+								Namespace:       "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                    appsv1beta1.Always,
+													TTLSecondsAfterFinished: int32Ptr(100),
+												},
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  true,
+		},
+		{
+			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate conflict Selector",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-v1beta1",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												PullSecrets: nil,
+												Selector: &appsv1beta1.ImagePullJobNodeSelector{
+													Names: []string{
+														"node1",
+													},
+													LabelSelector: metav1.LabelSelector{
+														MatchLabels: map[string]string{
+															"key": "value",
+														},
+													},
+												},
+												PodSelector: nil,
+												Parallelism: nil,
+												PullPolicy:  nil,
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                  appsv1beta1.Always,
+													ActiveDeadlineSeconds: int64Ptr(100),
+												},
+												SandboxConfig:   nil,
+												ImagePullPolicy: "",
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  true,
+		},
+		{
+			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate conflict Selector 2",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-v1beta1",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												PullSecrets: nil,
+												Selector: &appsv1beta1.ImagePullJobNodeSelector{
+													LabelSelector: metav1.LabelSelector{
+														MatchLabels: map[string]string{
+															"key": "value",
+														},
+														MatchExpressions: []metav1.LabelSelectorRequirement{
+															{Key: "xxx"},
+														},
+													},
+												},
+												PodSelector: nil,
+												Parallelism: nil,
+												PullPolicy:  nil,
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                  appsv1beta1.Always,
+													ActiveDeadlineSeconds: int64Ptr(100),
+												},
+												SandboxConfig:   nil,
+												ImagePullPolicy: "",
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  true,
+		},
+		{
+			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate conflict PodSelector",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-v1beta1",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												PullSecrets: nil,
+												Selector: &appsv1beta1.ImagePullJobNodeSelector{
+													Names: []string{
+														"node1",
+													},
+												},
+												PodSelector: &appsv1beta1.ImagePullJobPodSelector{
+													LabelSelector: metav1.LabelSelector{
+														MatchLabels: map[string]string{
+															"key": "value",
+														},
+													},
+												},
+												Parallelism: nil,
+												PullPolicy:  nil,
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                  appsv1beta1.Always,
+													ActiveDeadlineSeconds: int64Ptr(100),
+												},
+												SandboxConfig:   nil,
+												ImagePullPolicy: "",
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  true,
+		},
+		{
+			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate conflict PodSelector 2",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-v1beta1",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												PullSecrets: nil,
+												PodSelector: &appsv1beta1.ImagePullJobPodSelector{
+													LabelSelector: metav1.LabelSelector{
+														MatchLabels: map[string]string{
+															"key": "value",
+														},
+														MatchExpressions: []metav1.LabelSelectorRequirement{
+															{Key: "xxx"},
+														},
+													},
+												},
+												Parallelism: nil,
+												PullPolicy:  nil,
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                  appsv1beta1.Always,
+													ActiveDeadlineSeconds: int64Ptr(100),
+												},
+												SandboxConfig:   nil,
+												ImagePullPolicy: "",
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  true,
+		},
+		{
+			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate bad completionPolicy",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-v1beta1",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule:          "0 0 * * *",
+								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+												"alpine:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												PullSecrets: nil,
+												Selector: &appsv1beta1.ImagePullJobNodeSelector{
+													Names: []string{
+														"node1",
+													},
+												},
+												Parallelism: nil,
+												PullPolicy:  nil,
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													Type:                    appsv1beta1.Always,
+													ActiveDeadlineSeconds:   int64Ptr(MaxActiveDeadLineSeconds + 1),
+													TTLSecondsAfterFinished: int32Ptr(100),
+												},
+												SandboxConfig:   nil,
+												ImagePullPolicy: "",
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: true,
+			expectedError:  true,
+		},
+		{
+			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate activeDeadlineSeconds shorter than defaulted timeoutSeconds",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-v1beta1",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule: "0 0 * * *",
+								Template: appsv1beta1.CronJobTemplate{
+									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
+										Spec: appsv1beta1.ImageListPullJobSpec{
+											Images: []string{
+												"busybox:latest",
+											},
+											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+												Selector: &appsv1beta1.ImagePullJobNodeSelector{
+													Names: []string{
+														"node1",
+													},
+												},
+												// pullPolicy.timeoutSeconds is defaulted to 600 along with completionPolicy.type
+												CompletionPolicy: appsv1beta1.CompletionPolicy{
+													ActiveDeadlineSeconds: int64Ptr(100),
+												},
+											},
+										},
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			expectedResult: false,
+			expectedError:  true,
+		},
+		{
+			name: "invalid JSON should return error",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: []byte("invalid json"),
+					},
+				},
+			},
+			expectedResult: false,
+			expectedError:  true,
+		},
+		{
+			name: "update with invalid OldObject JSON should return error",
+			request: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1beta1.GroupVersion.Group,
+						Version:  appsv1beta1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{
+						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-acj-update",
+								Namespace: "default",
+							},
+							Spec: appsv1beta1.AdvancedCronJobSpec{
+								Schedule: "0 0 * * *",
+								Template: appsv1beta1.CronJobTemplate{
+									JobTemplate: &batchv1.JobTemplateSpec{
+										Spec: batchv1.JobSpec{
+											Template: createValidPodTemplateSpec(),
+										},
+									},
+								},
+							},
+						}),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: []byte("invalid old object json"),
+					},
+				},
+			},
+			expectedResult: false,
+			expectedError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := admission.NewDecoder(scheme.Scheme)
+			handler := AdvancedCronJobCreateUpdateHandler{
+				Decoder: decoder,
+			}
+
+			response := handler.Handle(context.TODO(), tt.request)
+
+			if tt.expectedError {
+				if response.Allowed {
+					t.Errorf("expected error but got allowed response")
+				}
+			} else {
+				if !response.Allowed && !tt.expectedError {
+					t.Errorf("expected allowed response but got error: %s", response.Result.Message)
+				}
+			}
+		})
+	}
+}
+
+// TestAdvancedCronJobCreateUpdateHandler_V1Alpha1EdgeValues makes sure the v1alpha1 objects are validated
+// against the constraints of v1beta1 after the conversion.
+func TestAdvancedCronJobCreateUpdateHandler_V1Alpha1EdgeValues(t *testing.T) {
+	cases := []struct {
+		name          string
+		mutate        func(spec *appsv1alpha1.AdvancedCronJobSpec)
+		expectedField string
+	}{
+		{
+			name: "zero startingDeadlineSeconds",
+			mutate: func(spec *appsv1alpha1.AdvancedCronJobSpec) {
+				spec.StartingDeadlineSeconds = int64Ptr(0)
+			},
+		},
+		{
+			name: "negative startingDeadlineSeconds",
+			mutate: func(spec *appsv1alpha1.AdvancedCronJobSpec) {
+				spec.StartingDeadlineSeconds = int64Ptr(-1)
+			},
+			expectedField: "spec.startingDeadlineSeconds",
+		},
+		{
+			name: "zero history limits",
+			mutate: func(spec *appsv1alpha1.AdvancedCronJobSpec) {
+				spec.SuccessfulJobsHistoryLimit = int32Ptr(0)
+				spec.FailedJobsHistoryLimit = int32Ptr(0)
+			},
+		},
+		{
+			name: "negative successfulJobsHistoryLimit",
+			mutate: func(spec *appsv1alpha1.AdvancedCronJobSpec) {
+				spec.SuccessfulJobsHistoryLimit = int32Ptr(-1)
+			},
+			expectedField: "spec.successfulJobsHistoryLimit",
+		},
+		{
+			name: "negative failedJobsHistoryLimit",
+			mutate: func(spec *appsv1alpha1.AdvancedCronJobSpec) {
+				spec.FailedJobsHistoryLimit = int32Ptr(-1)
+			},
+			expectedField: "spec.failedJobsHistoryLimit",
+		},
+		{
+			name: "empty timeZone",
+			mutate: func(spec *appsv1alpha1.AdvancedCronJobSpec) {
+				spec.TimeZone = strPtr("")
+			},
+			expectedField: "spec.timeZone",
+		},
+		{
+			name: "Local timeZone",
+			mutate: func(spec *appsv1alpha1.AdvancedCronJobSpec) {
+				spec.TimeZone = strPtr("Local")
+			},
+			expectedField: "spec.timeZone",
+		},
+		{
+			name: "out of range schedule",
+			mutate: func(spec *appsv1alpha1.AdvancedCronJobSpec) {
+				spec.Schedule = "0 24 * * *"
+			},
+			expectedField: "spec.schedule",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &appsv1alpha1.AdvancedCronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-acj-v1alpha1",
+					Namespace: "default",
+				},
+				Spec: appsv1alpha1.AdvancedCronJobSpec{
+					Schedule: "0 0 * * *",
+					Template: appsv1alpha1.CronJobTemplate{
+						JobTemplate: &batchv1.JobTemplateSpec{
+							Spec: batchv1.JobSpec{
+								Template: createValidPodTemplateSpec(),
+							},
+						},
+					},
+				},
+			}
+			tc.mutate(&obj.Spec)
+
+			handler := AdvancedCronJobCreateUpdateHandler{
+				Decoder: admission.NewDecoder(scheme.Scheme),
+			}
+			response := handler.Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    appsv1alpha1.GroupVersion.Group,
+						Version:  appsv1alpha1.GroupVersion.Version,
+						Resource: "advancedcronjobs",
+					},
+					Object: runtime.RawExtension{Raw: createAdvancedCronJobV1Alpha1JSON(t, obj)},
+				},
+			})
+
+			if tc.expectedField == "" {
+				if !response.Allowed {
+					t.Fatalf("expected allowed response but got error: %s", response.Result.Message)
+				}
+				return
+			}
+			if response.Allowed {
+				t.Fatalf("expected the converted object to be rejected on %s", tc.expectedField)
+			}
+			if !strings.Contains(response.Result.Message, tc.expectedField) {
+				t.Errorf("expected error on %s, got %s", tc.expectedField, response.Result.Message)
+			}
+		})
+	}
+}
+
+func TestAdvancedCronJobCreateUpdateHandler_StructuredReport(t *testing.T) {
+	request := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Resource: metav1.GroupVersionResource{
+				Group:    appsv1beta1.GroupVersion.Group,
+				Version:  appsv1beta1.GroupVersion.Version,
+				Resource: "advancedcronjobs",
+			},
+			Object: runtime.RawExtension{
+				Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-acj-report",
+						Namespace: "default",
+					},
+					Spec: appsv1beta1.AdvancedCronJobSpec{
+						Schedule:                "invalid",
+						StartingDeadlineSeconds: int64Ptr(-1),
+						Template: appsv1beta1.CronJobTemplate{
+							JobTemplate: &batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: createValidPodTemplateSpec(),
+								},
+							},
+						},
+					},
+				}),
+			},
+		},
+	}
+
+	handler := AdvancedCronJobCreateUpdateHandler{
+		Decoder:          admission.NewDecoder(scheme.Scheme),
+		StructuredReport: true,
+	}
+	response := handler.Handle(context.TODO(), request)
+	if response.Allowed {
+		t.Fatalf("expected denied response")
+	}
+	if response.Result == nil || response.Result.Details == nil {
+		t.Fatalf("expected status details in response, got %v", response.Result)
+	}
+	if response.Result.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected code %d, got %d", http.StatusUnprocessableEntity, response.Result.Code)
+	}
+	fields := sets.NewString()
+	for _, cause := range response.Result.Details.Causes {
+		if len(cause.Type) == 0 || len(cause.Message) == 0 {
+			t.Errorf("expected cause with type and message, got %v", cause)
+		}
+		fields.Insert(cause.Field)
+	}
+	if !fields.HasAll("spec.schedule", "spec.startingDeadlineSeconds") {
+		t.Errorf("expected causes of spec.schedule and spec.startingDeadlineSeconds, got %v", fields.List())
+	}
+}
+
+func TestAdvancedCronJobCreateUpdateHandler_Warnings(t *testing.T) {
+	newRequest := func(startingDeadlineSeconds int64) admission.Request {
+		return admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Resource: metav1.GroupVersionResource{
+					Group:    appsv1beta1.GroupVersion.Group,
+					Version:  appsv1beta1.GroupVersion.Version,
+					Resource: "advancedcronjobs",
+				},
+				Object: runtime.RawExtension{
+					Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "test-acj-warnings",
+							Namespace: "default",
+						},
+						Spec: appsv1beta1.AdvancedCronJobSpec{
+							Schedule:                "0 9 1 * MON",
+							StartingDeadlineSeconds: int64Ptr(startingDeadlineSeconds),
+							Template: appsv1beta1.CronJobTemplate{
+								JobTemplate: &batchv1.JobTemplateSpec{
+									Spec: batchv1.JobSpec{
+										Template: createValidPodTemplateSpec(),
+									},
+								},
+							},
+						},
+					}),
+				},
+			},
+		}
+	}
+
+	handler := AdvancedCronJobCreateUpdateHandler{
+		Decoder: admission.NewDecoder(scheme.Scheme),
+		Clock:   clocktesting.NewFakePassiveClock(time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)),
+	}
+
+	// warnings are returned along with both the allowed and the denied response
+	response := handler.Handle(context.TODO(), newRequest(60))
+	if !response.Allowed || len(response.Warnings) != 1 {
+		t.Errorf("expected allowed response with one warning, got %v, %v", response.Allowed, response.Warnings)
+	}
+	response = handler.Handle(context.TODO(), newRequest(-1))
+	if response.Allowed || len(response.Warnings) != 1 {
+		t.Errorf("expected denied response with one warning, got %v, %v", response.Allowed, response.Warnings)
+	}
+}
+
+func TestValidateImagesAndSelectorNames(t *testing.T) {
+	cases := []struct {
+		name            string
+		max             int
+		withoutSelector bool
+		expectedErr     string
+	}{
+		{
+			name: "no limit",
+		},
+		{
+			name: "within the limit",
+			max:  3,
+		},
+		{
+			name:        "exceed the limit",
+			max:         2,
+			expectedErr: "2 images and 1 selector names exceed the limit of 2 combined",
+		},
+		{
+			name:            "images only",
+			max:             2,
+			withoutSelector: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := createValidImageListPullJobTemplateSpec()
+			if tc.withoutSelector {
+				spec.Spec.Selector = nil
+			}
+			config := &ValidationConfig{MaxImagesAndSelectorNames: tc.max}
+			_, errs := validateImageListPullJobTemplateSpec(spec, field.NewPath("spec", "template", "imageListPullJobTemplate"), DefaultImageRefNormalizer, config)
+			if tc.expectedErr == "" {
+				if len(errs) > 0 {
+					t.Fatalf("expected no error, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Detail, tc.expectedErr) {
+				t.Fatalf("expected error %q, got %v", tc.expectedErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateImageSources(t *testing.T) {
+	defer func(sources []imageSource) { imageSources = sources }(imageSources)
+	// a stub of another source, set by the annotation
+	imageSources = append(imageSources, imageSource{
+		name: "imagesFrom",
+		set: func(ilpJobSpec *appsv1beta1.ImageListPullJobTemplateSpec) bool {
+			_, ok := ilpJobSpec.Annotations["images-from"]
+			return ok
+		},
+	})
+
+	cases := []struct {
+		name        string
+		images      []string
+		imagesFrom  bool
+		expectedErr string
+	}{
+		{
+			name:        "both unset",
+			expectedErr: "spec.images: Required value: must set images or imagesFrom",
+		},
+		{
+			name:       "both set",
+			images:     []string{"busybox:latest"},
+			imagesFrom: true,
+		},
+		{
+			name:   "images set",
+			images: []string{"busybox:latest"},
+		},
+		{
+			name:       "imagesFrom set",
+			imagesFrom: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := createValidImageListPullJobTemplateSpec()
+			spec.Spec.Images = tc.images
+			if tc.imagesFrom {
+				spec.Annotations = map[string]string{"images-from": "configmap"}
+			}
+			errs := validateImageSources(spec, field.NewPath("spec"))
+			if len(tc.expectedErr) == 0 {
+				if len(errs) > 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Error() != tc.expectedErr {
+				t.Fatalf("expected single error %q, got %v", tc.expectedErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateFireDensity(t *testing.T) {
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name               string
+		max                int
+		schedule           string
+		scheduleExpression *string
+		expectErr          bool
+	}{
+		{
+			name:     "no limit",
+			schedule: "* * * * *",
+		},
+		{
+			name:     "within the limit",
+			max:      12,
+			schedule: "*/5 * * * *",
+		},
+		{
+			name:      "exceed the limit",
+			max:       12,
+			schedule:  "* 9 * * *",
+			expectErr: true,
+		},
+		{
+			name:               "exceed the limit by scheduleExpression",
+			max:                12,
+			scheduleExpression: pointer.String("* 9 * * * except lastday"),
+			expectErr:          true,
+		},
+		{
+			name:      "exceed the limit in the sampled window",
+			max:       12,
+			schedule:  "TZ=UTC * 9 15 10 *",
+			expectErr: true,
+		},
+		{
+			name:     "exceed the limit after the sampled window",
+			max:      12,
+			schedule: "TZ=UTC * 9 20 10 *",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule, ScheduleExpression: tc.scheduleExpression}
+			errs := validateFireDensity(spec, field.NewPath("spec"), now, tc.max)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+		})
+	}
+
+	// the AdvancedCronJobs admitted before the limit was set can be updated without changing the schedule
+	obj := &appsv1beta1.AdvancedCronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-acj", Namespace: "default"},
+		Spec: appsv1beta1.AdvancedCronJobSpec{
+			Schedule:          "*/2 * * * *",
+			ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+			Template: appsv1beta1.CronJobTemplate{
+				JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: createValidPodTemplateSpec()}},
+			},
+		},
+	}
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Clock:  clocktesting.NewFakePassiveClock(now),
+		Config: &ValidationConfig{MaxFiresPerHour: 12},
+	}
+	paused := obj.DeepCopy()
+	paused.Spec.Paused = pointer.Bool(true)
+	if _, errs := handler.validateAdvancedCronJobUpdate(paused, obj); len(errs) > 0 {
+		t.Errorf("expected no error for pausing an AdvancedCronJob exceeding the limit, got %v", errs)
+	}
+	rescheduled := obj.DeepCopy()
+	rescheduled.Spec.Schedule = "@every 10s"
+	if _, errs := handler.validateAdvancedCronJobUpdate(rescheduled, obj); len(errs) == 0 {
+		t.Errorf("expected error for changing the schedule exceeding the limit")
+	}
+}
+
+func TestValidateDailyFires(t *testing.T) {
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name               string
+		max                int
+		schedule           string
+		scheduleExpression *string
+		expectErr          bool
+	}{
+		{
+			name:     "no limit",
+			schedule: "* * * * *",
+		},
+		{
+			name:     "within the limit",
+			max:      24,
+			schedule: "0 * * * *",
+		},
+		{
+			name:      "exceed the limit",
+			max:       24,
+			schedule:  "*/30 * * * *",
+			expectErr: true,
+		},
+		{
+			name:               "exceed the limit by scheduleExpression",
+			max:                24,
+			scheduleExpression: pointer.String("*/30 * * * * except date 2020-01-01"),
+			expectErr:          true,
+		},
+		{
+			name:      "exceed the limit in the next 24 hours",
+			max:       24,
+			schedule:  "TZ=UTC */15 * 11 10 *",
+			expectErr: true,
+		},
+		{
+			name:     "exceed the limit after the next 24 hours",
+			max:      24,
+			schedule: "TZ=UTC */15 * 12 10 *",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule, ScheduleExpression: tc.scheduleExpression}
+			errs := validateDailyFires(spec, field.NewPath("spec"), now, tc.max)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+		})
+	}
+
+	// the AdvancedCronJobs admitted before the limit was set can be updated without changing the schedule
+	obj := &appsv1beta1.AdvancedCronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-acj", Namespace: "default"},
+		Spec: appsv1beta1.AdvancedCronJobSpec{
+			Schedule:          "*/30 * * * *",
+			ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+			Template: appsv1beta1.CronJobTemplate{
+				JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: createValidPodTemplateSpec()}},
+			},
+		},
+	}
+	handler := &AdvancedCronJobCreateUpdateHandler{
+		Clock:  clocktesting.NewFakePassiveClock(now),
+		Config: &ValidationConfig{MaxFiresPerDay: 24},
+	}
+	paused := obj.DeepCopy()
+	paused.Spec.Paused = pointer.Bool(true)
+	if _, errs := handler.validateAdvancedCronJobUpdate(paused, obj); len(errs) > 0 {
+		t.Errorf("expected no error for pausing an AdvancedCronJob exceeding the limit, got %v", errs)
+	}
+	rescheduled := obj.DeepCopy()
+	rescheduled.Spec.Schedule = "@every 1h"
+	if _, errs := handler.validateAdvancedCronJobUpdate(rescheduled, obj); len(errs) > 0 {
+		t.Errorf("expected no error for changing the schedule within the limit, got %v", errs)
+	}
+	rescheduled.Spec.Schedule = "@every 20m"
+	if _, errs := handler.validateAdvancedCronJobUpdate(rescheduled, obj); len(errs) == 0 {
+		t.Errorf("expected error for changing the schedule exceeding the limit")
+	}
+}
+
+func TestParseHoursWindow(t *testing.T) {
+	for _, value := range []string{"22:00-06:00", "01:30-05:00"} {
+		w, err := parseHoursWindow(value)
+		if err != nil || w.String() != value {
+			t.Errorf("expected %s, got %v, %v", value, w, err)
+		}
+	}
+	if w, err := parseHoursWindow(""); w != nil || err != nil {
+		t.Errorf("expected no window, got %v, %v", w, err)
+	}
+	for _, value := range []string{"22:00", "25:00-06:00", "22:00-6pm", "22:00-22:00"} {
+		if _, err := parseHoursWindow(value); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
+
+func TestValidateAllowedHours(t *testing.T) {
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		window    string
+		schedule  string
+		timeZone  *string
+		expectErr bool
+	}{
+		{
+			name:     "no window",
+			schedule: "0 12 * * *",
+			timeZone: pointer.String("UTC"),
+		},
+		{
+			name:     "within the window across midnight",
+			window:   "22:00-06:00",
+			schedule: "0 22-23,0-5 * * *",
+			timeZone: pointer.String("UTC"),
+		},
+		{
+			name:      "at the end of the window",
+			window:    "22:00-06:00",
+			schedule:  "0 6 * * *",
+			timeZone:  pointer.String("UTC"),
+			expectErr: true,
+		},
+		{
+			name:      "out of the window",
+			window:    "22:00-06:00",
+			schedule:  "*/30 * * * *",
+			timeZone:  pointer.String("UTC"),
+			expectErr: true,
+		},
+		{
+			name:     "within the window in the time zone",
+			window:   "22:00-06:00",
+			schedule: "0 23 * * *",
+			timeZone: pointer.String("Asia/Shanghai"),
+		},
+		{
+			name:      "out of the window in the embedded time zone",
+			window:    "22:00-06:00",
+			schedule:  "TZ=Asia/Shanghai 0 12 * * *",
+			expectErr: true,
+		},
+		{
+			name:     "within the window during the day",
+			window:   "01:00-05:00",
+			schedule: "0 3 * * *",
+			timeZone: pointer.String("UTC"),
+		},
+		{
+			name:      "out of the window during the day",
+			window:    "01:00-05:00",
+			schedule:  "0 23 * * *",
+			timeZone:  pointer.String("UTC"),
+			expectErr: true,
+		},
+		{
+			name:      "out of the window in the next 24 hours",
+			window:    "22:00-06:00",
+			schedule:  "0 11 11 10 *",
+			timeZone:  pointer.String("UTC"),
+			expectErr: true,
+		},
+		{
+			name:     "out of the window after the next 24 hours",
+			window:   "22:00-06:00",
+			schedule: "0 13 11 10 *",
+			timeZone: pointer.String("UTC"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			allowedHours, err := parseHoursWindow(tc.window)
+			if err != nil {
+				t.Fatal(err)
+			}
+			spec := &appsv1beta1.AdvancedCronJobSpec{Schedule: tc.schedule, TimeZone: tc.timeZone}
+			errs := validateAllowedHours(spec, field.NewPath("spec"), now, allowedHours)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+		})
+	}
+
+	// the handler checks the fire times after the time of its clock
+	allowedHours, _ := parseHoursWindow("22:00-06:00")
+	config := &ValidationConfig{AllowedHours: allowedHours}
+	obj := &appsv1beta1.AdvancedCronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-acj", Namespace: "default"},
+		Spec: appsv1beta1.AdvancedCronJobSpec{
+			Schedule:          "0 11 11 10 *",
+			TimeZone:          pointer.String("UTC"),
+			ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
+			Template: appsv1beta1.CronJobTemplate{
+				JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: createValidPodTemplateSpec()}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		now       time.Time
+		expectErr bool
+	}{
+		{now: now, expectErr: true},
+		{now: now.AddDate(0, 0, 2)},
+	} {
+		handler := &AdvancedCronJobCreateUpdateHandler{Clock: clocktesting.NewFakePassiveClock(tc.now), Config: config}
+		_, errs := handler.validateAdvancedCronJob(obj)
+		found := false
+		for _, err := range errs {
+			if err.Field == "spec.schedule" && err.Type == field.ErrorTypeForbidden {
+				found = true
+			}
+		}
+		if tc.expectErr != found {
+			t.Errorf("at %s: expected allowed hours error %v, got %v", tc.now, tc.expectErr, errs)
+		}
+	}
+
+	// the AdvancedCronJobs admitted before the window was set can be updated without changing the schedule
+	handler := &AdvancedCronJobCreateUpdateHandler{Clock: clocktesting.NewFakePassiveClock(now), Config: config}
+	paused := obj.DeepCopy()
+	paused.Spec.Paused = pointer.Bool(true)
+	if _, errs := handler.validateAdvancedCronJobUpdate(paused, obj); len(errs) > 0 {
+		t.Errorf("expected no error for pausing an AdvancedCronJob out of the allowed hours, got %v", errs)
+	}
+	rescheduled := obj.DeepCopy()
+	rescheduled.Spec.Schedule = "0 12 11 10 *"
+	if _, errs := handler.validateAdvancedCronJobUpdate(rescheduled, obj); len(errs) == 0 {
+		t.Errorf("expected error for changing the schedule out of the allowed hours")
+	}
+}
+
+func TestValidateTerminationGracePeriod(t *testing.T) {
+	cases := []struct {
+		name        string
+		max         int
+		gracePeriod *int64
+		expectErr   bool
+	}{
+		{name: "unset", max: 300},
+		{name: "within the limit", max: 300, gracePeriod: pointer.Int64(300)},
+		{name: "exceed the limit", max: 300, gracePeriod: pointer.Int64(301), expectErr: true},
+		{name: "no limit", gracePeriod: pointer.Int64(86400)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateTerminationGracePeriod(&core.PodSpec{TerminationGracePeriodSeconds: tc.gracePeriod}, field.NewPath("spec"), tc.max)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+		})
+	}
+
+	// the Job and BroadcastJob templates are both bounded
+	config := &ValidationConfig{MaxTerminationGracePeriodSeconds: 300}
+	podTemplate := createValidPodTemplateSpec()
+	podTemplate.Spec.TerminationGracePeriodSeconds = pointer.Int64(3600)
+	if _, errs := validateJobTemplateSpec(&batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: podTemplate}}, 0, field.NewPath("spec"), config); !hasErrorOn(errs, "spec.template.jobTemplate.spec.template.spec.terminationGracePeriodSeconds") {
+		t.Errorf("expected error on the Job template terminationGracePeriodSeconds, got %v", errs)
+	}
+	if _, errs := validateBroadcastJobTemplateSpec(&appsv1beta1.BroadcastJobTemplateSpec{Spec: appsv1beta1.BroadcastJobSpec{Template: podTemplate}}, 0, field.NewPath("spec"), config); !hasErrorOn(errs, "spec.template.broadcastJobTemplate.spec.template.spec.terminationGracePeriodSeconds") {
+		t.Errorf("expected error on the BroadcastJob template terminationGracePeriodSeconds, got %v", errs)
+	}
+}
+
+func hasErrorOn(errs field.ErrorList, fieldPath string) bool {
+	for _, err := range errs {
+		if err.Field == fieldPath {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateTemplateKindFields(t *testing.T) {
+	newJobTemplate := func(restartPolicy v1.RestartPolicy, selector *metav1.LabelSelector, manualSelector *bool) appsv1beta1.CronJobTemplate {
+		template := createValidPodTemplateSpec()
+		template.Spec.RestartPolicy = restartPolicy
+		return appsv1beta1.CronJobTemplate{JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{
+			Template:       template,
+			Selector:       selector,
+			ManualSelector: manualSelector,
+		}}}
+	}
+	newBroadcastJobTemplate := func(restartPolicy v1.RestartPolicy, completionPolicy appsv1beta1.CompletionPolicy) appsv1beta1.CronJobTemplate {
+		template := createValidPodTemplateSpec()
+		template.Spec.RestartPolicy = restartPolicy
+		return appsv1beta1.CronJobTemplate{BroadcastJobTemplate: &appsv1beta1.BroadcastJobTemplateSpec{Spec: appsv1beta1.BroadcastJobSpec{
+			Template:         template,
+			CompletionPolicy: completionPolicy,
+		}}}
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}}
+
+	cases := []struct {
+		name         string
+		template     appsv1beta1.CronJobTemplate
+		expectFields []string
+	}{
+		{name: "job", template: newJobTemplate(v1.RestartPolicyNever, nil, nil)},
+		{name: "job with manual selector", template: newJobTemplate(v1.RestartPolicyOnFailure, selector, pointer.Bool(true))},
+		{
+			name:         "job restarting always",
+			template:     newJobTemplate(v1.RestartPolicyAlways, nil, nil),
+			expectFields: []string{"spec.template.jobTemplate.spec.template.spec.restartPolicy"},
+		},
+		{
+			name:         "job with generated selector",
+			template:     newJobTemplate(v1.RestartPolicyNever, selector, pointer.Bool(false)),
+			expectFields: []string{"spec.template.jobTemplate.spec.selector"},
+		},
+		{
+			name:     "broadcast job",
+			template: newBroadcastJobTemplate(v1.RestartPolicyOnFailure, appsv1beta1.CompletionPolicy{Type: appsv1beta1.Always, TTLSecondsAfterFinished: pointer.Int32(60)}),
+		},
+		{
+			name:         "broadcast job restarting always",
+			template:     newBroadcastJobTemplate(v1.RestartPolicyAlways, appsv1beta1.CompletionPolicy{Type: appsv1beta1.Always}),
+			expectFields: []string{"spec.template.broadcastJobTemplate.spec.template.spec.restartPolicy"},
+		},
+		{
+			name: "broadcast job never completing with a deadline",
+			template: newBroadcastJobTemplate(v1.RestartPolicyNever, appsv1beta1.CompletionPolicy{
+				Type: appsv1beta1.Never, TTLSecondsAfterFinished: pointer.Int32(60), ActiveDeadlineSeconds: pointer.Int64(600)}),
+			expectFields: []string{
+				"spec.template.broadcastJobTemplate.spec.completionPolicy.ttlSecondsAfterFinished",
+				"spec.template.broadcastJobTemplate.spec.completionPolicy.activeDeadlineSeconds",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fields []string
+			for _, err := range validateTemplateKindFields(&tc.template, field.NewPath("spec", "template")) {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.expectFields) {
+				t.Fatalf("expected error fields %v, got %v", tc.expectFields, fields)
+			}
+		})
+	}
+}
+
+func TestValidateBroadcastJobFailurePolicy(t *testing.T) {
+	cases := []struct {
+		name          string
+		max           int
+		failurePolicy appsv1beta1.FailurePolicy
+		expectFields  []string
+	}{
+		{name: "unset", max: 10},
+		{name: "fail fast", max: 10, failurePolicy: appsv1beta1.FailurePolicy{Type: appsv1beta1.FailurePolicyTypeFailFast}},
+		{name: "continue within the limit", max: 10, failurePolicy: appsv1beta1.FailurePolicy{Type: appsv1beta1.FailurePolicyTypeContinue, RestartLimit: 10}},
+		{name: "unknown type", max: 10, failurePolicy: appsv1beta1.FailurePolicy{Type: "Retry"}, expectFields: []string{"spec.template.broadcastJobTemplate.spec.failurePolicy.type"}},
+		{name: "negative restart limit", max: 10, failurePolicy: appsv1beta1.FailurePolicy{Type: appsv1beta1.FailurePolicyTypePause, RestartLimit: -1},
+			expectFields: []string{"spec.template.broadcastJobTemplate.spec.failurePolicy.restartLimit"}},
+		{name: "exceed the limit", max: 10, failurePolicy: appsv1beta1.FailurePolicy{Type: appsv1beta1.FailurePolicyTypeContinue, RestartLimit: 11},
+			expectFields: []string{"spec.template.broadcastJobTemplate.spec.failurePolicy.restartLimit"}},
+		{name: "no limit", failurePolicy: appsv1beta1.FailurePolicy{Type: appsv1beta1.FailurePolicyTypeContinue, RestartLimit: 1000}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateBroadcastJobFailurePolicy(&tc.failurePolicy, field.NewPath("spec", "template", "broadcastJobTemplate", "spec", "failurePolicy"), tc.max)
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.expectFields) {
+				t.Fatalf("expected error fields %v, got %v", tc.expectFields, errs)
+			}
+		})
+	}
+
+	// the BroadcastJob template is validated
+	brJobTemplate := &appsv1beta1.BroadcastJobTemplateSpec{Spec: appsv1beta1.BroadcastJobSpec{
+		Template:      createValidPodTemplateSpec(),
+		FailurePolicy: appsv1beta1.FailurePolicy{Type: appsv1beta1.FailurePolicyTypeContinue, RestartLimit: 100},
+	}}
+	_, errs := validateBroadcastJobTemplateSpec(brJobTemplate, 0, field.NewPath("spec"), &ValidationConfig{MaxBroadcastJobRestartLimit: 10})
+	if len(errs) != 1 || errs[0].Field != "spec.template.broadcastJobTemplate.spec.failurePolicy.restartLimit" {
+		t.Errorf("expected error on spec.template.broadcastJobTemplate.spec.failurePolicy.restartLimit, got %v", errs)
+	}
+}
+
+func TestValidateJobScale(t *testing.T) {
+	cases := []struct {
+		name         string
+		completions  *int32
+		parallelism  *int32
+		expectFields []string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:        "within the limits",
+			completions: pointer.Int32(100),
+			parallelism: pointer.Int32(10),
+		},
+		{
+			name:         "exceed the limits",
+			completions:  pointer.Int32(101),
+			parallelism:  pointer.Int32(11),
+			expectFields: []string{"spec.template.jobTemplate.spec.completions", "spec.template.jobTemplate.spec.parallelism"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateJobScale(&batchv1.JobSpec{Completions: tc.completions, Parallelism: tc.parallelism},
+				field.NewPath("spec", "template", "jobTemplate", "spec"), 100, 10)
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(tc.expectFields, fields) {
+				t.Fatalf("expected errors on %v, got %v", tc.expectFields, errs)
+			}
+		})
+	}
+
+	// the Job template is validated, and the errors land on its spec
+	jobTemplate := &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{
+		Template:    createValidPodTemplateSpec(),
+		Completions: pointer.Int32(101),
+		Parallelism: pointer.Int32(11),
+	}}
+	_, errs := validateJobTemplateSpec(jobTemplate, 0, field.NewPath("spec"), &ValidationConfig{MaxJobCompletions: 100, MaxJobParallelism: 10})
+	fields := sets.New[string]()
+	for _, err := range errs {
+		fields.Insert(err.Field)
+	}
+	if !fields.HasAll("spec.template.jobTemplate.spec.completions", "spec.template.jobTemplate.spec.parallelism") {
+		t.Errorf("expected errors on the Job template spec, got %v", errs)
+	}
+}
+
+func TestValidateShortJobParallelism(t *testing.T) {
+	cases := []struct {
+		name                  string
+		max                   int
+		concurrencyPolicy     appsv1beta1.ConcurrencyPolicy
+		parallelism           int32
+		activeDeadlineSeconds *int64
+		expectErr             bool
+	}{
+		{
+			name:                  "no limit",
+			concurrencyPolicy:     appsv1beta1.ForbidConcurrent,
+			parallelism:           100,
+			activeDeadlineSeconds: pointer.Int64(60),
+		},
+		{
+			name:                  "within the limit",
+			max:                   10,
+			concurrencyPolicy:     appsv1beta1.ForbidConcurrent,
+			parallelism:           10,
+			activeDeadlineSeconds: pointer.Int64(60),
+		},
+		{
+			name:                  "exceed the limit with short jobs",
+			max:                   10,
+			concurrencyPolicy:     appsv1beta1.ForbidConcurrent,
+			parallelism:           100,
+			activeDeadlineSeconds: pointer.Int64(60),
+			expectErr:             true,
+		},
+		{
+			name:                  "jobs longer than the interval",
+			max:                   10,
+			concurrencyPolicy:     appsv1beta1.ForbidConcurrent,
+			parallelism:           100,
+			activeDeadlineSeconds: pointer.Int64(600),
+		},
+		{
+			name:              "jobs without activeDeadlineSeconds",
+			max:               10,
+			concurrencyPolicy: appsv1beta1.ForbidConcurrent,
+			parallelism:       100,
+		},
+		{
+			name:                  "concurrencyPolicy Replace",
+			max:                   10,
+			concurrencyPolicy:     appsv1beta1.ReplaceConcurrent,
+			parallelism:           100,
+			activeDeadlineSeconds: pointer.Int64(60),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &appsv1beta1.AdvancedCronJobSpec{
+				Schedule:          "*/5 * * * *",
+				ConcurrencyPolicy: tc.concurrencyPolicy,
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{
+						Parallelism:           pointer.Int32(tc.parallelism),
+						ActiveDeadlineSeconds: tc.activeDeadlineSeconds,
+					}},
+				},
+			}
+			errs := validateShortJobParallelism(spec, field.NewPath("spec", "template", "jobTemplate", "spec"), time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC), tc.max)
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+			if tc.expectErr && errs[0].Field != "spec.template.jobTemplate.spec.parallelism" {
+				t.Fatalf("expected error on spec.template.jobTemplate.spec.parallelism, got %v", errs[0].Field)
+			}
+		})
+	}
+
+	// the AdvancedCronJob template is validated, and the error lands on the Job template spec
+	spec := &appsv1beta1.AdvancedCronJobSpec{
+		Schedule:          "*/5 * * * *",
+		ConcurrencyPolicy: appsv1beta1.ForbidConcurrent,
+		Template: appsv1beta1.CronJobTemplate{
+			JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{
+				Template:              createValidPodTemplateSpec(),
+				Parallelism:           pointer.Int32(100),
+				ActiveDeadlineSeconds: pointer.Int64(60),
+			}},
+		},
+	}
+	_, errs := validateAdvancedCronJobSpecTemplate(spec, field.NewPath("spec"), time.Now(), DefaultImageRefNormalizer, &ValidationConfig{MaxShortJobParallelism: 10})
+	found := false
+	for _, err := range errs {
+		if err.Field == "spec.template.jobTemplate.spec.parallelism" && err.Type == field.ErrorTypeForbidden {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected error on spec.template.jobTemplate.spec.parallelism, got %v", errs)
+	}
+}
+
+func TestMissingDeadlineWarnings(t *testing.T) {
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name                  string
+		min                   time.Duration
+		schedule              string
+		concurrencyPolicy     appsv1beta1.ConcurrencyPolicy
+		activeDeadlineSeconds *int64
+		podDeadlineSeconds    *int64
+		expectedJobWarning    string
+		expectedBrJobWarning  string
+	}{
+		{
+			name:              "no threshold",
+			schedule:          "*/5 * * * *",
+			concurrencyPolicy: appsv1beta1.AllowConcurrent,
+		},
+		{
+			name:              "frequent schedule without deadline",
+			min:               10 * time.Minute,
+			schedule:          "*/5 * * * *",
+			concurrencyPolicy: appsv1beta1.AllowConcurrent,
+			expectedJobWarning: "spec.template.jobTemplate.spec.activeDeadlineSeconds: not set while the schedules fire every 5m0s under concurrencyPolicy Allow, " +
+				"the runs that do not finish may pile up, consider bounding their runtime",
+			expectedBrJobWarning: "spec.template.broadcastJobTemplate.spec.completionPolicy.activeDeadlineSeconds: not set while the schedules fire every 5m0s under concurrencyPolicy Allow, " +
+				"the runs that do not finish may pile up, consider bounding their runtime",
+		},
+		{
+			name:              "frequent schedule in the sampled window",
+			min:               10 * time.Minute,
+			schedule:          "TZ=UTC */5 * 15 10 *",
+			concurrencyPolicy: appsv1beta1.AllowConcurrent,
+			expectedJobWarning: "spec.template.jobTemplate.spec.activeDeadlineSeconds: not set while the schedules fire every 5m0s under concurrencyPolicy Allow, " +
+				"the runs that do not finish may pile up, consider bounding their runtime",
+			expectedBrJobWarning: "spec.template.broadcastJobTemplate.spec.completionPolicy.activeDeadlineSeconds: not set while the schedules fire every 5m0s under concurrencyPolicy Allow, " +
+				"the runs that do not finish may pile up, consider bounding their runtime",
+		},
+		{
+			name:              "frequent schedule after the sampled window",
+			min:               10 * time.Minute,
+			schedule:          "TZ=UTC */5 * 20 10 *",
+			concurrencyPolicy: appsv1beta1.AllowConcurrent,
+		},
+		{
+			name:              "infrequent schedule",
+			min:               10 * time.Minute,
+			schedule:          "0 * * * *",
+			concurrencyPolicy: appsv1beta1.AllowConcurrent,
+		},
+		{
+			name:                  "deadline of the job",
+			min:                   10 * time.Minute,
+			schedule:              "*/5 * * * *",
+			concurrencyPolicy:     appsv1beta1.AllowConcurrent,
+			activeDeadlineSeconds: pointer.Int64(600),
+		},
+		{
+			name:               "deadline of the pods",
+			min:                10 * time.Minute,
+			schedule:           "*/5 * * * *",
+			concurrencyPolicy:  appsv1beta1.AllowConcurrent,
+			podDeadlineSeconds: pointer.Int64(600),
+		},
+		{
+			name:              "concurrencyPolicy Forbid",
+			min:               10 * time.Minute,
+			schedule:          "*/5 * * * *",
+			concurrencyPolicy: appsv1beta1.ForbidConcurrent,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &ValidationConfig{MinIntervalWithoutDeadline: tc.min}
+			template := createValidPodTemplateSpec()
+			template.Spec.ActiveDeadlineSeconds = tc.podDeadlineSeconds
+			jobSpec := &appsv1beta1.AdvancedCronJobSpec{
+				Schedule:          tc.schedule,
+				ConcurrencyPolicy: tc.concurrencyPolicy,
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{
+						Template:              template,
+						ActiveDeadlineSeconds: tc.activeDeadlineSeconds,
+					}},
+				},
+			}
+			brJobSpec := &appsv1beta1.AdvancedCronJobSpec{
+				Schedule:          tc.schedule,
+				ConcurrencyPolicy: tc.concurrencyPolicy,
+				Template: appsv1beta1.CronJobTemplate{
+					BroadcastJobTemplate: &appsv1beta1.BroadcastJobTemplateSpec{Spec: appsv1beta1.BroadcastJobSpec{
+						Template:         template,
+						CompletionPolicy: appsv1beta1.CompletionPolicy{ActiveDeadlineSeconds: tc.activeDeadlineSeconds},
+					}},
+				},
+			}
+
+			for _, c := range []struct {
+				spec            *appsv1beta1.AdvancedCronJobSpec
+				expectedWarning string
+			}{
+				{spec: jobSpec, expectedWarning: tc.expectedJobWarning},
+				{spec: brJobSpec, expectedWarning: tc.expectedBrJobWarning},
+			} {
+				warnings, errs := validateAdvancedCronJobSpecTemplate(c.spec, field.NewPath("spec"), now, DefaultImageRefNormalizer, config)
+				if len(errs) > 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				var expectedWarnings []string
+				if c.expectedWarning != "" {
+					expectedWarnings = []string{c.expectedWarning}
+				}
+				if !reflect.DeepEqual(expectedWarnings, warnings) {
+					t.Errorf("expected warnings %v, got %v", expectedWarnings, warnings)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateImageRegistries(t *testing.T) {
+	config := &ValidationConfig{MaxImageRegistries: 2}
+	images := []string{
+		"busybox:latest",
+		"docker.io/library/alpine:latest",
+		"quay.io/coreos/etcd:v3.5.0",
+		"registry.k8s.io/pause:3.9",
+	}
+	// all the images are pulled from the mirror
+	mirror := ImageRefNormalizerFunc(func(ref string) (reference.Named, error) {
+		named, err := DefaultImageRefNormalizer.NormalizeImageRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		return reference.ParseNormalizedNamed("mirror.example.com/" + reference.Path(named) + ":" + named.(reference.Tagged).Tag())
+	})
+	cases := []struct {
+		name       string
+		enabled    bool
+		images     []string
+		normalizer ImageRefNormalizer
+		expectErr  bool
+	}{
+		{
+			name:    "gate disabled",
+			enabled: false,
+			images:  images,
+		},
+		{
+			name:    "within the maximum",
+			enabled: true,
+			images:  images[:3],
+		},
+		{
+			name:      "exceed the maximum",
+			enabled:   true,
+			images:    images,
+			expectErr: true,
+		},
+		{
+			name:       "within the maximum after rewritten to the mirror",
+			enabled:    true,
+			images:     images,
+			normalizer: mirror,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobLimitImageRegistries, tc.enabled)()
+			handler := &AdvancedCronJobCreateUpdateHandler{ImageRefNormalizer: tc.normalizer, Config: config}
+			spec := createValidImageListPullJobTemplateSpec()
+			spec.Spec.Images = tc.images
+			_, errs := validateImageListPullJobTemplateSpec(spec, field.NewPath("spec", "template", "imageListPullJobTemplate"), handler.imageRefNormalizer(), handler.config())
+			if tc.expectErr != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateMixedImageReferences(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	cases := []struct {
+		name         string
+		enabled      bool
+		images       []string
+		expectedErrs int
+	}{
+		{
+			name:    "gate disabled",
+			enabled: false,
+			images:  []string{"nginx:1.25", "nginx@" + digest},
+		},
+		{
+			name:    "tags of a repository",
+			enabled: true,
+			images:  []string{"nginx:1.25", "nginx:1.26"},
+		},
+		{
+			name:    "tag and digest of different repositories",
+			enabled: true,
+			images:  []string{"nginx:1.25", "busybox@" + digest},
+		},
+		{
+			name:         "tag and digest of a repository",
+			enabled:      true,
+			images:       []string{"nginx:1.25", "docker.io/library/nginx@" + digest},
+			expectedErrs: 1,
+		},
+		{
+			name:         "digest and implicit latest tag of a repository",
+			enabled:      true,
+			images:       []string{"nginx@" + digest, "nginx", "nginx:1.25"},
+			expectedErrs: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobRejectMixedImageRefs, tc.enabled)()
+			spec := createValidImageListPullJobTemplateSpec()
+			spec.Spec.Images = tc.images
+			_, errs := validateImageListPullJobTemplateSpec(spec, field.NewPath("spec", "template", "imageListPullJobTemplate"), DefaultImageRefNormalizer, DefaultValidationConfig())
+			if len(errs) != tc.expectedErrs {
+				t.Fatalf("expected %d errors, got %v", tc.expectedErrs, errs)
+			}
+		})
+	}
+}
+
+func TestEmptySelectorWarnings(t *testing.T) {
+	cases := []struct {
+		name             string
+		selector         *appsv1beta1.ImagePullJobNodeSelector
+		podSelector      *appsv1beta1.ImagePullJobPodSelector
+		expectedWarnings int
+	}{
+		{
+			name: "no selector",
+		},
+		{
+			name:     "selector with names",
+			selector: &appsv1beta1.ImagePullJobNodeSelector{Names: []string{"node-1"}},
+		},
+		{
+			name: "selector with matchLabels",
+			selector: &appsv1beta1.ImagePullJobNodeSelector{LabelSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "gpu"},
+			}},
+		},
+		{
+			name:             "empty selector",
+			selector:         &appsv1beta1.ImagePullJobNodeSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{}}},
+			expectedWarnings: 1,
+		},
+		{
+			name: "podSelector with matchExpressions",
+			podSelector: &appsv1beta1.ImagePullJobPodSelector{LabelSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpExists}},
+			}},
+		},
+		{
+			name:             "empty podSelector",
+			podSelector:      &appsv1beta1.ImagePullJobPodSelector{},
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ilpJobSpec := &appsv1beta1.ImageListPullJobTemplateSpec{}
+			ilpJobSpec.Spec.Selector = tc.selector
+			ilpJobSpec.Spec.PodSelector = tc.podSelector
+			warnings := emptySelectorWarnings(ilpJobSpec, field.NewPath("spec", "template", "imageListPullJobTemplate"))
+			if len(warnings) != tc.expectedWarnings {
+				t.Fatalf("expected %d warnings, got %v", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestClusterWidePullWarnings(t *testing.T) {
+	images := []string{"nginx:1.25", "busybox:1.36", "alpine:3.20"}
+
+	cases := []struct {
+		name            string
+		images          []string
+		selector        *appsv1beta1.ImagePullJobNodeSelector
+		podSelector     *appsv1beta1.ImagePullJobPodSelector
+		expectedWarning bool
+	}{
+		{
+			name:   "few images",
+			images: images[:2],
 		},
 		{
-			name: "update v1beta1 AdvancedCronJob ImageListPullJobTemplate with forbidden field",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Update,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:            "test-acj-update",
-								Namespace:       "default",
-								ResourceVersion: "xxxx",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.AllowConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type: appsv1beta1.Never,
-												},
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-					OldObject: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								ResourceVersion: "xxxx",
-								Name:            "test-acj-update",
-								Namespace:       "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                    appsv1beta1.Always,
-													TTLSecondsAfterFinished: int32Ptr(100),
-												},
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: true,
-			expectedError:  true,
+			name:            "many images without selector",
+			images:          images,
+			expectedWarning: true,
 		},
 		{
-			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate conflict Selector",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-v1beta1",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												PullSecrets: nil,
-												Selector: &appsv1beta1.ImagePullJobNodeSelector{
-													Names: []string{
-														"node1",
-													},
-													LabelSelector: metav1.LabelSelector{
-														MatchLabels: map[string]string{
-															"key": "value",
-														},
-													},
-												},
-												PodSelector: nil,
-												Parallelism: nil,
-												PullPolicy:  nil,
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                  appsv1beta1.Always,
-													ActiveDeadlineSeconds: int64Ptr(100),
-												},
-												SandboxConfig:   nil,
-												ImagePullPolicy: "",
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: true,
-			expectedError:  true,
+			name:            "many images with an empty selector",
+			images:          images,
+			selector:        &appsv1beta1.ImagePullJobNodeSelector{},
+			expectedWarning: true,
 		},
 		{
-			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate conflict Selector 2",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-v1beta1",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												PullSecrets: nil,
-												Selector: &appsv1beta1.ImagePullJobNodeSelector{
-													LabelSelector: metav1.LabelSelector{
-														MatchLabels: map[string]string{
-															"key": "value",
-														},
-														MatchExpressions: []metav1.LabelSelectorRequirement{
-															{Key: "xxx"},
-														},
-													},
-												},
-												PodSelector: nil,
-												Parallelism: nil,
-												PullPolicy:  nil,
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                  appsv1beta1.Always,
-													ActiveDeadlineSeconds: int64Ptr(100),
-												},
-												SandboxConfig:   nil,
-												ImagePullPolicy: "",
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: true,
-			expectedError:  true,
+			name:     "many images on the named nodes",
+			images:   images,
+			selector: &appsv1beta1.ImagePullJobNodeSelector{Names: []string{"node-1"}},
 		},
 		{
-			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate conflict PodSelector",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-v1beta1",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												PullSecrets: nil,
-												Selector: &appsv1beta1.ImagePullJobNodeSelector{
-													Names: []string{
-														"node1",
-													},
-												},
-												PodSelector: &appsv1beta1.ImagePullJobPodSelector{
-													LabelSelector: metav1.LabelSelector{
-														MatchLabels: map[string]string{
-															"key": "value",
-														},
-													},
-												},
-												Parallelism: nil,
-												PullPolicy:  nil,
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                  appsv1beta1.Always,
-													ActiveDeadlineSeconds: int64Ptr(100),
-												},
-												SandboxConfig:   nil,
-												ImagePullPolicy: "",
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: true,
-			expectedError:  true,
+			name:        "many images on the nodes of the pods",
+			images:      images,
+			podSelector: &appsv1beta1.ImagePullJobPodSelector{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ilpJobSpec := &appsv1beta1.ImageListPullJobTemplateSpec{}
+			ilpJobSpec.Spec.Images = tc.images
+			ilpJobSpec.Spec.Selector = tc.selector
+			ilpJobSpec.Spec.PodSelector = tc.podSelector
+			warnings := clusterWidePullWarnings(ilpJobSpec, field.NewPath("spec", "template", "imageListPullJobTemplate"), 2)
+			if tc.expectedWarning != (len(warnings) > 0) {
+				t.Fatalf("expected warning %v, got %v", tc.expectedWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestNodeOSWarnings(t *testing.T) {
+	cases := []struct {
+		name            string
+		gate            bool
+		selector        *appsv1beta1.ImagePullJobNodeSelector
+		podSelector     *appsv1beta1.ImagePullJobPodSelector
+		expectedWarning bool
+	}{
+		{
+			name: "feature-gate disabled",
 		},
 		{
-			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate conflict PodSelector 2",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-v1beta1",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												PullSecrets: nil,
-												PodSelector: &appsv1beta1.ImagePullJobPodSelector{
-													LabelSelector: metav1.LabelSelector{
-														MatchLabels: map[string]string{
-															"key": "value",
-														},
-														MatchExpressions: []metav1.LabelSelectorRequirement{
-															{Key: "xxx"},
-														},
-													},
-												},
-												Parallelism: nil,
-												PullPolicy:  nil,
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                  appsv1beta1.Always,
-													ActiveDeadlineSeconds: int64Ptr(100),
-												},
-												SandboxConfig:   nil,
-												ImagePullPolicy: "",
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: true,
-			expectedError:  true,
+			name:            "without selector",
+			gate:            true,
+			expectedWarning: true,
 		},
 		{
-			name: "create v1beta1 AdvancedCronJob ImageListPullJobTemplate bad completionPolicy",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-v1beta1",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule:          "0 0 * * *",
-								ConcurrencyPolicy: appsv1beta1.ReplaceConcurrent,
-								Template: appsv1beta1.CronJobTemplate{
-									ImageListPullJobTemplate: &appsv1beta1.ImageListPullJobTemplateSpec{
-										Spec: appsv1beta1.ImageListPullJobSpec{
-											Images: []string{
-												"busybox:latest",
-												"alpine:latest",
-											},
-											ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
-												PullSecrets: nil,
-												Selector: &appsv1beta1.ImagePullJobNodeSelector{
-													Names: []string{
-														"node1",
-													},
-												},
-												Parallelism: nil,
-												PullPolicy:  nil,
-												CompletionPolicy: appsv1beta1.CompletionPolicy{
-													Type:                    appsv1beta1.Always,
-													ActiveDeadlineSeconds:   int64Ptr(MaxActiveDeadLineSeconds + 1),
-													TTLSecondsAfterFinished: int32Ptr(100),
-												},
-												SandboxConfig:   nil,
-												ImagePullPolicy: "",
-											},
-										},
-									},
-								},
-							},
-						}),
-					},
-				},
-			},
-			expectedResult: true,
-			expectedError:  true,
+			name:            "with an empty selector",
+			gate:            true,
+			selector:        &appsv1beta1.ImagePullJobNodeSelector{},
+			expectedWarning: true,
 		},
 		{
-			name: "invalid JSON should return error",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Create,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: []byte("invalid json"),
-					},
-				},
-			},
-			expectedResult: false,
-			expectedError:  true,
+			name: "on the linux nodes",
+			gate: true,
+			selector: &appsv1beta1.ImagePullJobNodeSelector{LabelSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{v1.LabelOSStable: "linux"},
+			}},
 		},
 		{
-			name: "update with invalid OldObject JSON should return error",
-			request: admission.Request{
-				AdmissionRequest: admissionv1.AdmissionRequest{
-					Operation: admissionv1.Update,
-					Resource: metav1.GroupVersionResource{
-						Group:    appsv1beta1.GroupVersion.Group,
-						Version:  appsv1beta1.GroupVersion.Version,
-						Resource: "advancedcronjobs",
-					},
-					Object: runtime.RawExtension{
-						Raw: createAdvancedCronJobV1Beta1JSON(t, &appsv1beta1.AdvancedCronJob{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "test-acj-update",
-								Namespace: "default",
-							},
-							Spec: appsv1beta1.AdvancedCronJobSpec{
-								Schedule: "0 0 * * *",
-								Template: appsv1beta1.CronJobTemplate{
-									JobTemplate: &batchv1.JobTemplateSpec{
-										Spec: batchv1.JobSpec{
-											Template: createValidPodTemplateSpec(),
-										},
-									},
-								},
-							},
-						}),
-					},
-					OldObject: runtime.RawExtension{
-						Raw: []byte("invalid old object json"),
-					},
-				},
-			},
-			expectedResult: false,
-			expectedError:  true,
+			name:     "on the named nodes",
+			gate:     true,
+			selector: &appsv1beta1.ImagePullJobNodeSelector{Names: []string{"node-1"}},
+		},
+		{
+			name:        "on the nodes of the pods",
+			gate:        true,
+			podSelector: &appsv1beta1.ImagePullJobPodSelector{},
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			decoder := admission.NewDecoder(scheme.Scheme)
-			handler := AdvancedCronJobCreateUpdateHandler{
-				Decoder: decoder,
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobNodeOSWarning, tc.gate)()
+			ilpJobSpec := &appsv1beta1.ImageListPullJobTemplateSpec{}
+			ilpJobSpec.Spec.Images = []string{"nginx:1.25"}
+			ilpJobSpec.Spec.Selector = tc.selector
+			ilpJobSpec.Spec.PodSelector = tc.podSelector
+			warnings := nodeOSWarnings(ilpJobSpec, field.NewPath("spec", "template", "imageListPullJobTemplate"), DefaultImageRefNormalizer)
+			if tc.expectedWarning != (len(warnings) > 0) {
+				t.Fatalf("expected warning %v, got %v", tc.expectedWarning, warnings)
 			}
+		})
+	}
+}
 
-			response := handler.Handle(context.TODO(), tt.request)
+func TestValidateConcurrencyForKind(t *testing.T) {
+	cases := []struct {
+		name           string
+		policy         appsv1beta1.ConcurrencyPolicy
+		kind           appsv1beta1.TemplateKind
+		expectedDetail string
+	}{
+		{
+			name:   "job allows concurrent runs",
+			policy: appsv1beta1.AllowConcurrent,
+			kind:   appsv1beta1.JobTemplate,
+		},
+		{
+			name:   "broadcastJob forbids concurrent runs",
+			policy: appsv1beta1.ForbidConcurrent,
+			kind:   appsv1beta1.BroadcastJobTemplate,
+		},
+		{
+			name:   "broadcastJob allows concurrent runs unless restricted in the cluster",
+			policy: appsv1beta1.AllowConcurrent,
+			kind:   appsv1beta1.BroadcastJobTemplate,
+		},
+		{
+			name:   "imageListPullJob replaces concurrent runs",
+			policy: appsv1beta1.ReplaceConcurrent,
+			kind:   appsv1beta1.ImageListPullJobTemplate,
+		},
+		{
+			name:           "imageListPullJob allows concurrent runs",
+			policy:         appsv1beta1.AllowConcurrent,
+			kind:           appsv1beta1.ImageListPullJobTemplate,
+			expectedDetail: "ImageListPullJob does not support Allow because cluster-wide pulls must not overlap",
+		},
+		{
+			name:           "imageListPullJob without policy",
+			kind:           appsv1beta1.ImageListPullJobTemplate,
+			expectedDetail: `ImageListPullJob supports only Replace or Forbid, but current value is: ""`,
+		},
+	}
 
-			if tt.expectedError {
-				if response.Allowed {
-					t.Errorf("expected error but got allowed response")
-				}
-			} else {
-				if !response.Allowed && !tt.expectedError {
-					t.Errorf("expected allowed response but got error: %s", response.Result.Message)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateConcurrencyForKind(tc.policy, tc.kind)
+			if tc.expectedDetail == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
 				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Field != "spec.concurrencyPolicy" || errs[0].Detail != tc.expectedDetail {
+				t.Fatalf("expected %q on spec.concurrencyPolicy, got %v", tc.expectedDetail, errs)
+			}
+		})
+	}
+}
+
+func TestValidateCompletionPolicyBounds(t *testing.T) {
+	cases := []struct {
+		name           string
+		cp             appsv1beta1.CompletionPolicy
+		pullPolicy     *appsv1beta1.PullPolicy
+		expectedDetail []string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:       "within the bounds",
+			cp:         appsv1beta1.CompletionPolicy{ActiveDeadlineSeconds: pointer.Int64(600)},
+			pullPolicy: &appsv1beta1.PullPolicy{TimeoutSeconds: pointer.Int32(600)},
+		},
+		{
+			name:           "deadline exceeds the max",
+			cp:             appsv1beta1.CompletionPolicy{ActiveDeadlineSeconds: pointer.Int64(MaxActiveDeadLineSeconds + 1)},
+			expectedDetail: []string{fmt.Sprintf("activeDeadlineSeconds must be less than %d, current value is: %d", MaxActiveDeadLineSeconds, MaxActiveDeadLineSeconds+1)},
+		},
+		{
+			name:           "deadline shorter than the pull timeout",
+			cp:             appsv1beta1.CompletionPolicy{ActiveDeadlineSeconds: pointer.Int64(60)},
+			pullPolicy:     &appsv1beta1.PullPolicy{TimeoutSeconds: pointer.Int32(600)},
+			expectedDetail: []string{"completionPolicy.activeDeadlineSeconds must be greater than pullPolicy.timeoutSeconds(600)"},
+		},
+		{
+			name:           "ttl",
+			cp:             appsv1beta1.CompletionPolicy{TTLSecondsAfterFinished: pointer.Int32(60)},
+			expectedDetail: []string{"ttlSecondsAfterFinished is not supported in advancedCronJob"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateCompletionPolicyBounds(&tc.cp, tc.pullPolicy, field.NewPath("spec", "completionPolicy"))
+			var details []string
+			for _, err := range errs {
+				details = append(details, err.Detail)
+			}
+			if !reflect.DeepEqual(tc.expectedDetail, details) {
+				t.Fatalf("expected %v, got %v", tc.expectedDetail, errs)
+			}
+		})
+	}
+}
+
+func TestValidatePullPolicyTimeout(t *testing.T) {
+	cases := []struct {
+		name        string
+		pullPolicy  *appsv1beta1.PullPolicy
+		expectError bool
+	}{
+		{name: "unset"},
+		{name: "no timeout", pullPolicy: &appsv1beta1.PullPolicy{}},
+		{name: "at the max", pullPolicy: &appsv1beta1.PullPolicy{TimeoutSeconds: pointer.Int32(MaxActiveDeadLineSeconds)}},
+		{name: "exceeds the max", pullPolicy: &appsv1beta1.PullPolicy{TimeoutSeconds: pointer.Int32(MaxActiveDeadLineSeconds + 1)}, expectError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validatePullPolicyTimeout(tc.pullPolicy, field.NewPath("spec", "pullPolicy"))
+			if tc.expectError != (len(errs) > 0) {
+				t.Fatalf("expected error %v, got %v", tc.expectError, errs)
+			}
+			if tc.expectError && errs[0].Field != "spec.pullPolicy.timeoutSeconds" {
+				t.Errorf("expected error on spec.pullPolicy.timeoutSeconds, got %v", errs[0].Field)
+			}
+		})
+	}
+
+	// the timeout is bounded without a deadline and whatever the completion policy type is
+	template := createValidImageListPullJobTemplateSpec()
+	template.Spec.CompletionPolicy = appsv1beta1.CompletionPolicy{Type: appsv1beta1.Never}
+	template.Spec.PullPolicy = &appsv1beta1.PullPolicy{TimeoutSeconds: pointer.Int32(MaxActiveDeadLineSeconds + 1)}
+	_, errs := validateImageListPullJobTemplateSpec(template, field.NewPath("spec", "template", "imageListPullJobTemplate"), DefaultImageRefNormalizer, DefaultValidationConfig())
+	if len(errs) != 1 || errs[0].Field != "spec.template.imageListPullJobTemplate.spec.pullPolicy.timeoutSeconds" {
+		t.Errorf("expected error on the pullPolicy.timeoutSeconds of the ImageListPullJob template, got %v", errs)
+	}
+}
+
+func TestImagePullBudgetWarnings(t *testing.T) {
+	config := &ValidationConfig{MinImagePullBudgetSeconds: 10}
+
+	cases := []struct {
+		name          string
+		enabled       bool
+		deadline      *int64
+		expectWarning bool
+	}{
+		{
+			name:     "gate disabled",
+			deadline: int64Ptr(10),
+		},
+		{
+			name:    "no deadline",
+			enabled: true,
+		},
+		{
+			name:     "enough budget",
+			enabled:  true,
+			deadline: int64Ptr(20),
+		},
+		{
+			name:          "too little budget",
+			enabled:       true,
+			deadline:      int64Ptr(19),
+			expectWarning: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobImagePullBudgetWarning, tc.enabled)()
+			spec := createValidImageListPullJobTemplateSpec()
+			spec.Spec.CompletionPolicy.ActiveDeadlineSeconds = tc.deadline
+			warnings, _ := validateImageListPullJobTemplateSpec(spec, field.NewPath("spec", "template", "imageListPullJobTemplate"), DefaultImageRefNormalizer, config)
+			if tc.expectWarning != (len(warnings) > 0) {
+				t.Fatalf("expected warning %v, got %v", tc.expectWarning, warnings)
 			}
 		})
 	}
 }
 
+func createValidImageListPullJobTemplateSpec() *appsv1beta1.ImageListPullJobTemplateSpec {
+	return &appsv1beta1.ImageListPullJobTemplateSpec{
+		Spec: appsv1beta1.ImageListPullJobSpec{
+			Images: []string{
+				"busybox:latest",
+				"alpine:latest",
+			},
+			ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+				Selector: &appsv1beta1.ImagePullJobNodeSelector{
+					Names: []string{
+						"node1",
+					},
+				},
+				CompletionPolicy: appsv1beta1.CompletionPolicy{
+					Type:                  appsv1beta1.Always,
+					ActiveDeadlineSeconds: int64Ptr(100),
+				},
+			},
+		},
+	}
+}
+
 func createValidPodTemplateSpec() v1.PodTemplateSpec {
 	return v1.PodTemplateSpec{
 		Spec: v1.PodSpec{
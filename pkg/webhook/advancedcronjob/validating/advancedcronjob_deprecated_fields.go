@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kubernetes/pkg/apis/core"
+
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+const (
+	// DeprecatedFieldSeverityWarning returns the use of deprecated fields as admission warnings.
+	DeprecatedFieldSeverityWarning = "Warning"
+	// DeprecatedFieldSeverityError rejects the use of deprecated fields.
+	DeprecatedFieldSeverityError = "Error"
+)
+
+// deprecatedPodTemplateField is a deprecated field of the pod template with the migration message.
+type deprecatedPodTemplateField struct {
+	// path returns the path of the field in the pod template at fldPath.
+	path    func(fldPath *field.Path) *field.Path
+	message string
+	// used reports whether the pod template sets the field.
+	used func(template *core.PodTemplateSpec) bool
+}
+
+// deprecatedPodTemplateFields is the registry of the deprecated fields flagged in Job and BroadcastJob templates.
+var deprecatedPodTemplateFields = []deprecatedPodTemplateField{
+	{
+		path: func(fldPath *field.Path) *field.Path {
+			return fldPath.Child("spec", "serviceAccount")
+		},
+		message: "serviceAccount is deprecated, use serviceAccountName instead",
+		used: func(template *core.PodTemplateSpec) bool {
+			return len(template.Spec.DeprecatedServiceAccount) > 0
+		},
+	},
+	{
+		path: func(fldPath *field.Path) *field.Path {
+			return fldPath.Child("metadata", "annotations").Key(core.SeccompPodAnnotationKey)
+		},
+		message: "seccomp annotation is deprecated, use securityContext.seccompProfile instead",
+		used: func(template *core.PodTemplateSpec) bool {
+			_, ok := template.Annotations[core.SeccompPodAnnotationKey]
+			return ok
+		},
+	},
+}
+
+// validateDeprecatedFields flags the deprecated fields used by the pod template, as warnings or errors
+// by the severity, any value other than DeprecatedFieldSeverityError is taken as DeprecatedFieldSeverityWarning.
+func validateDeprecatedFields(template *core.PodTemplateSpec, fldPath *field.Path, severity string) ([]string, field.ErrorList) {
+	var warnings []string
+	allErrs := field.ErrorList{}
+	if !utilfeature.DefaultFeatureGate.Enabled(features.AdvancedCronJobDeprecatedFieldValidation) {
+		return warnings, allErrs
+	}
+	for _, deprecated := range deprecatedPodTemplateFields {
+		if !deprecated.used(template) {
+			continue
+		}
+		if severity == DeprecatedFieldSeverityError {
+			allErrs = append(allErrs, field.Forbidden(deprecated.path(fldPath), deprecated.message))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", deprecated.path(fldPath), deprecated.message))
+		}
+	}
+	return warnings, allErrs
+}
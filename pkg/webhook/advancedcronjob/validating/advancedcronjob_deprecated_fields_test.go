@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+func TestValidateDeprecatedFields(t *testing.T) {
+	deprecatedTemplate := createValidPodTemplateSpec()
+	deprecatedTemplate.Annotations = map[string]string{v1.SeccompPodAnnotationKey: v1.SeccompProfileRuntimeDefault}
+	deprecatedTemplate.Spec.DeprecatedServiceAccount = "default"
+	deprecatedTemplate.Spec.ServiceAccountName = "default"
+
+	cases := []struct {
+		name             string
+		enabled          bool
+		severity         string
+		template         v1.PodTemplateSpec
+		expectedWarnings int
+		expectedErrs     int
+	}{
+		{
+			name:     "gate disabled",
+			severity: DeprecatedFieldSeverityError,
+			template: deprecatedTemplate,
+		},
+		{
+			name:     "no deprecated fields",
+			enabled:  true,
+			severity: DeprecatedFieldSeverityError,
+			template: createValidPodTemplateSpec(),
+		},
+		{
+			name:             "deprecated fields as warnings",
+			enabled:          true,
+			severity:         DeprecatedFieldSeverityWarning,
+			template:         deprecatedTemplate,
+			expectedWarnings: 2,
+		},
+		{
+			name:         "deprecated fields as errors",
+			enabled:      true,
+			severity:     DeprecatedFieldSeverityError,
+			template:     deprecatedTemplate,
+			expectedErrs: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.AdvancedCronJobDeprecatedFieldValidation, tc.enabled)()
+			config := &ValidationConfig{DeprecatedFieldSeverity: tc.severity}
+			warnings, errs := validateJobTemplateSpec(&batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: tc.template}}, 0, field.NewPath("spec"), config)
+			if len(warnings) != tc.expectedWarnings {
+				t.Errorf("expected %d warnings, got %v", tc.expectedWarnings, warnings)
+			}
+			if len(errs) != tc.expectedErrs {
+				t.Errorf("expected %d errors, got %v", tc.expectedErrs, errs)
+			}
+			for _, warning := range warnings {
+				if !strings.HasPrefix(warning, "spec.template.jobTemplate.spec.template.") {
+					t.Errorf("expected warning on the job template, got %v", warning)
+				}
+			}
+			for _, err := range errs {
+				if !strings.HasPrefix(err.Field, "spec.template.jobTemplate.spec.template.") {
+					t.Errorf("expected error on the job template, got %v", err.Field)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"github.com/docker/distribution/reference"
+
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+)
+
+// ImageRefNormalizer normalizes the image references of ImageListPullJob templates before they are validated,
+// e.g. to rewrite them to the registry mirror the images are actually pulled from.
+type ImageRefNormalizer interface {
+	NormalizeImageRef(ref string) (reference.Named, error)
+}
+
+// ImageRefNormalizerFunc is a function implementing ImageRefNormalizer.
+type ImageRefNormalizerFunc func(ref string) (reference.Named, error)
+
+// NormalizeImageRef calls f(ref).
+func (f ImageRefNormalizerFunc) NormalizeImageRef(ref string) (reference.Named, error) {
+	return f(ref)
+}
+
+// DefaultImageRefNormalizer normalizes the image references without rewriting the registries.
+var DefaultImageRefNormalizer ImageRefNormalizer = ImageRefNormalizerFunc(daemonutil.NormalizeImageRef)
+
+// imageRefNormalizer returns the ImageRefNormalizer of the handler, DefaultImageRefNormalizer if it is not set.
+func (h *AdvancedCronJobCreateUpdateHandler) imageRefNormalizer() ImageRefNormalizer {
+	if h.ImageRefNormalizer == nil {
+		return DefaultImageRefNormalizer
+	}
+	return h.ImageRefNormalizer
+}
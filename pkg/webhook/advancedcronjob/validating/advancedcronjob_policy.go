@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// AdvancedCronJobPolicy is an organization-specific rule, which validates AdvancedCronJobs
+// after the built-in validation on create and update.
+type AdvancedCronJobPolicy interface {
+	Validate(obj *appsv1beta1.AdvancedCronJob) field.ErrorList
+}
+
+var registeredPolicies []AdvancedCronJobPolicy
+
+// RegisterPolicy registers a policy for the AdvancedCronJob webhook. It should be called at startup
+// before the webhook is set up, e.g. in an init function of the package defining the policy.
+func RegisterPolicy(policy AdvancedCronJobPolicy) {
+	registeredPolicies = append(registeredPolicies, policy)
+}
+
+// enabledPolicies returns the registered policies and the built-in example policies enabled by the config.
+func enabledPolicies(config *ValidationConfig) []AdvancedCronJobPolicy {
+	policies := append([]AdvancedCronJobPolicy{}, registeredPolicies...)
+	if config.ForbidAllowConcurrent {
+		policies = append(policies, ForbidAllowConcurrentPolicy{})
+	}
+	if len(config.RequiredLabels) > 0 {
+		policies = append(policies, RequiredLabelsPolicy{Keys: config.RequiredLabels})
+	}
+	return policies
+}
+
+// ForbidAllowConcurrentPolicy forbids concurrencyPolicy Allow, so that the runs of an AdvancedCronJob
+// never pile up when they take longer than the schedule interval.
+type ForbidAllowConcurrentPolicy struct{}
+
+func (ForbidAllowConcurrentPolicy) Validate(obj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if obj.Spec.ConcurrencyPolicy == appsv1beta1.AllowConcurrent {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "concurrencyPolicy"), obj.Spec.ConcurrencyPolicy,
+			[]string{string(appsv1beta1.ForbidConcurrent), string(appsv1beta1.ReplaceConcurrent)}))
+	}
+	return allErrs
+}
+
+// RequiredLabelsPolicy requires the AdvancedCronJob to have the labels, e.g. cost-center and team mandated
+// for the governance of the workloads.
+type RequiredLabelsPolicy struct {
+	Keys []string
+}
+
+func (p RequiredLabelsPolicy) Validate(obj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+	allErrs := field.ErrorList{}
+	var missing []string
+	for _, key := range p.Keys {
+		if _, ok := obj.Labels[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("metadata", "labels"),
+			fmt.Sprintf("missing required labels: %s", strings.Join(missing, ", "))))
+	}
+	return allErrs
+}
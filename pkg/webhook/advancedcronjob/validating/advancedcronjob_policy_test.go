@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+type requireLabelPolicy struct {
+	key string
+}
+
+func (p requireLabelPolicy) Validate(obj *appsv1beta1.AdvancedCronJob) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, ok := obj.Labels[p.key]; !ok {
+		allErrs = append(allErrs, field.Required(field.NewPath("metadata", "labels").Key(p.key), "required by policy"))
+	}
+	return allErrs
+}
+
+func TestAdvancedCronJobPolicies(t *testing.T) {
+	defer func(policies []AdvancedCronJobPolicy) { registeredPolicies = policies }(registeredPolicies)
+	registeredPolicies = nil
+
+	RegisterPolicy(requireLabelPolicy{key: "team"})
+	handler := &AdvancedCronJobCreateUpdateHandler{Policies: enabledPolicies(&ValidationConfig{ForbidAllowConcurrent: true})}
+	if len(handler.Policies) != 2 {
+		t.Fatalf("expected 2 policies, got %d", len(handler.Policies))
+	}
+
+	newObj := func(labels map[string]string, concurrencyPolicy appsv1beta1.ConcurrencyPolicy) *appsv1beta1.AdvancedCronJob {
+		return &appsv1beta1.AdvancedCronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-acj",
+				Namespace: "default",
+				Labels:    labels,
+			},
+			Spec: appsv1beta1.AdvancedCronJobSpec{
+				Schedule:          "0 * * * *",
+				ConcurrencyPolicy: concurrencyPolicy,
+				Template: appsv1beta1.CronJobTemplate{
+					JobTemplate: &batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: createValidPodTemplateSpec(),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name           string
+		obj            *appsv1beta1.AdvancedCronJob
+		expectedFields []string
+	}{
+		{
+			name: "satisfy all policies",
+			obj:  newObj(map[string]string{"team": "infra"}, appsv1beta1.ForbidConcurrent),
+		},
+		{
+			name:           "violate the registered policy",
+			obj:            newObj(nil, appsv1beta1.ReplaceConcurrent),
+			expectedFields: []string{"metadata.labels[team]"},
+		},
+		{
+			name:           "violate all policies",
+			obj:            newObj(nil, appsv1beta1.AllowConcurrent),
+			expectedFields: []string{"metadata.labels[team]", "spec.concurrencyPolicy"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errs := handler.validateAdvancedCronJob(tc.obj)
+			if len(errs) != len(tc.expectedFields) {
+				t.Fatalf("expected errors of %v, got %v", tc.expectedFields, errs)
+			}
+			for i, err := range errs {
+				if err.Field != tc.expectedFields[i] {
+					t.Errorf("expected error of %s, got %v", tc.expectedFields[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestRequiredLabelsPolicy(t *testing.T) {
+	policy := RequiredLabelsPolicy{Keys: []string{"cost-center", "team"}}
+	cases := []struct {
+		name           string
+		labels         map[string]string
+		expectedDetail string
+	}{
+		{
+			name:   "all labels present",
+			labels: map[string]string{"cost-center": "1234", "team": "infra", "app": "backup"},
+		},
+		{
+			name:           "one label missing",
+			labels:         map[string]string{"team": "infra"},
+			expectedDetail: "missing required labels: cost-center",
+		},
+		{
+			name:           "all labels missing",
+			expectedDetail: "missing required labels: cost-center, team",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := policy.Validate(&appsv1beta1.AdvancedCronJob{ObjectMeta: metav1.ObjectMeta{Labels: tc.labels}})
+			if tc.expectedDetail == "" {
+				if len(errs) > 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Field != "metadata.labels" || errs[0].Detail != tc.expectedDetail {
+				t.Fatalf("expected %q on metadata.labels, got %v", tc.expectedDetail, errs)
+			}
+		})
+	}
+}
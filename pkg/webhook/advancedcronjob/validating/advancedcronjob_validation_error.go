@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidationError is a validation error in a structure mirroring its protobuf message, for the surfaces
+// of the validation other than the admission webhook, e.g. gRPC APIs.
+type ValidationError struct {
+	// Field is the path of the invalid field, e.g. spec.template.jobTemplate.
+	Field string `json:"field"`
+	// BadValue is the invalid value formatted as a string, which is empty if the error has no value.
+	BadValue string `json:"badValue,omitempty"`
+	// Detail is the human-readable reason of the error.
+	Detail string `json:"detail,omitempty"`
+	// Type is the field.ErrorType of the error, e.g. FieldValueInvalid.
+	Type string `json:"type"`
+}
+
+// FieldErrorsToProto converts the validation errors to ValidationErrors, in the same order.
+func FieldErrorsToProto(errs field.ErrorList) []*ValidationError {
+	validationErrs := make([]*ValidationError, 0, len(errs))
+	for _, err := range errs {
+		validationErr := &ValidationError{
+			Field:  err.Field,
+			Detail: err.Detail,
+			Type:   string(err.Type),
+		}
+		if err.BadValue != nil {
+			validationErr.BadValue = fmt.Sprintf("%v", err.BadValue)
+		}
+		validationErrs = append(validationErrs, validationErr)
+	}
+	return validationErrs
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestFieldErrorsToProto(t *testing.T) {
+	errs := field.ErrorList{
+		field.Invalid(field.NewPath("spec", "schedule"), "0 24 * * *", "invalid cron schedule"),
+		field.Required(field.NewPath("spec", "timeZone"), "timeZone is required when solarSchedule is set"),
+		field.Duplicate(field.NewPath("spec", "skipDates").Index(1), "2025-12-25"),
+		field.Invalid(field.NewPath("spec", "startingDeadlineSeconds"), int64(-1), "must be greater than or equal to 0"),
+	}
+	expected := []*ValidationError{
+		{Field: "spec.schedule", BadValue: "0 24 * * *", Detail: "invalid cron schedule", Type: "FieldValueInvalid"},
+		{Field: "spec.timeZone", Detail: "timeZone is required when solarSchedule is set", Type: "FieldValueRequired"},
+		{Field: "spec.skipDates[1]", BadValue: "2025-12-25", Type: "FieldValueDuplicate"},
+		{Field: "spec.startingDeadlineSeconds", BadValue: "-1", Detail: "must be greater than or equal to 0", Type: "FieldValueInvalid"},
+	}
+
+	if validationErrs := FieldErrorsToProto(errs); !reflect.DeepEqual(expected, validationErrs) {
+		t.Errorf("expected %+v, got %+v", expected, validationErrs)
+	}
+	if validationErrs := FieldErrorsToProto(nil); len(validationErrs) != 0 {
+		t.Errorf("expected no validation errors, got %+v", validationErrs)
+	}
+}
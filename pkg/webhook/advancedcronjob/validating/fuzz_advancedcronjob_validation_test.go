@@ -18,6 +18,7 @@ package validating
 
 import (
 	"testing"
+	"time"
 
 	fuzz "github.com/AdaLogics/go-fuzz-headers"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -39,7 +40,7 @@ func FuzzValidateAdvancedCronJob(f *testing.F) {
 
 		// Validate AdvancedCronJob
 		handler := &AdvancedCronJobCreateUpdateHandler{}
-		allErrs := handler.validateAdvancedCronJob(acj)
+		_, allErrs := handler.validateAdvancedCronJob(acj)
 		if len(allErrs) != 0 {
 			t.Logf("Validation errors: %v", allErrs)
 		}
@@ -58,7 +59,7 @@ func FuzzValidateAdvancedCronJobSpec(f *testing.F) {
 		}
 
 		// Validate AdvancedCronJob Spec
-		allErrs := validateAdvancedCronJobSpec(&acj.Spec, field.NewPath("spec"))
+		_, allErrs := validateAdvancedCronJobSpec(&acj.Spec, field.NewPath("spec"), time.Now(), DefaultImageRefNormalizer, DefaultValidationConfig())
 		if len(allErrs) != 0 {
 			t.Logf("Spec validation errors: %v", allErrs)
 		}
@@ -77,7 +78,7 @@ func FuzzValidateAdvancedCronJobSpecSchedule(f *testing.F) {
 		}
 
 		// Validate Schedule
-		allErrs := validateAdvancedCronJobSpecSchedule(&acj.Spec, field.NewPath("spec"))
+		allErrs := validateAdvancedCronJobSpecSchedule(&acj.Spec, field.NewPath("spec"), time.Now())
 		if len(allErrs) != 0 {
 			t.Logf("Schedule validation errors: %v", allErrs)
 		}
@@ -96,7 +97,7 @@ func FuzzValidateAdvancedCronJobSpecTemplate(f *testing.F) {
 		}
 
 		// Validate Template
-		allErrs := validateAdvancedCronJobSpecTemplate(&acj.Spec, field.NewPath("spec"))
+		_, allErrs := validateAdvancedCronJobSpecTemplate(&acj.Spec, field.NewPath("spec"), time.Now(), DefaultImageRefNormalizer, DefaultValidationConfig())
 		if len(allErrs) != 0 {
 			t.Logf("Template validation errors: %v", allErrs)
 		}
@@ -17,19 +17,103 @@ limitations under the License.
 package validating
 
 import (
+	"flag"
+	"strings"
+
+	"k8s.io/client-go/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	kruiseclient "github.com/openkruise/kruise/pkg/client"
+	"github.com/openkruise/kruise/pkg/controller/advancedcronjob"
 	"github.com/openkruise/kruise/pkg/webhook/types"
 )
 
 // +kubebuilder:webhook:path=/validate-apps-kruise-io-advancedcronjob,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1;v1beta1,groups=apps.kruise.io,resources=advancedcronjobs,verbs=create;update,versions=v1alpha1;v1beta1,name=vadvancedcronjob.kb.io
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+func init() {
+	flag.StringVar(&flagConfig.EnforcedTimeZone, "advancedcronjob-enforced-timezone", "", "If set, AdvancedCronJobs can only use this time zone in spec.timeZone or an embedded TZ/CRON_TZ of spec.schedule, e.g. UTC.")
+	flag.IntVar(&flagConfig.MaxImageRegistries, "advancedcronjob-max-image-registries", flagConfig.MaxImageRegistries, "The max number of distinct registries the images of an ImageListPullJob template can be pulled from, works with AdvancedCronJobLimitImageRegistries feature-gate.")
+	flag.IntVar(&flagConfig.MaxImagesAndSelectorNames, "advancedcronjob-max-images-and-selector-names", flagConfig.MaxImagesAndSelectorNames, "The max number of images and selector names of an ImageListPullJob template combined, 0 means no limit.")
+	flag.IntVar(&flagConfig.MaxClusterWideImages, "advancedcronjob-max-cluster-wide-images", flagConfig.MaxClusterWideImages, "The max number of images of an ImageListPullJob template pulled on every node, above which a warning is returned, 0 means no warning.")
+	flag.IntVar(&flagConfig.MinImagePullBudgetSeconds, "advancedcronjob-min-image-pull-budget-seconds", flagConfig.MinImagePullBudgetSeconds, "The min seconds of activeDeadlineSeconds per image of an ImageListPullJob template, below which a warning is returned, works with AdvancedCronJobImagePullBudgetWarning feature-gate.")
+	flag.IntVar(&flagConfig.MaxFiresPerHour, "advancedcronjob-max-fires-per-hour", flagConfig.MaxFiresPerHour, "The max number of times the schedules of an AdvancedCronJob can fire in an hour combined, 0 means no limit.")
+	flag.IntVar(&flagConfig.MaxFiresPerDay, "advancedcronjob-max-fires-per-day", flagConfig.MaxFiresPerDay, "The max number of times the schedules of an AdvancedCronJob can fire in a day combined, 0 means no limit.")
+	flag.IntVar(&flagConfig.MaxSpecBytes, "advancedcronjob-max-spec-bytes", flagConfig.MaxSpecBytes, "The max size in bytes of the serialized spec of an AdvancedCronJob, e.g. 262144, 0 means no limit.")
+	flag.IntVar(&flagConfig.MaxJobCompletions, "advancedcronjob-max-job-completions", flagConfig.MaxJobCompletions, "The max completions of the Job template of an AdvancedCronJob, 0 means no limit.")
+	flag.IntVar(&flagConfig.MaxJobParallelism, "advancedcronjob-max-job-parallelism", flagConfig.MaxJobParallelism, "The max parallelism of the Job template of an AdvancedCronJob, 0 means no limit.")
+	flag.IntVar(&flagConfig.MaxTerminationGracePeriodSeconds, "advancedcronjob-max-termination-grace-period-seconds", flagConfig.MaxTerminationGracePeriodSeconds, "The max terminationGracePeriodSeconds of the pod template of the Job or BroadcastJob template of an AdvancedCronJob, 0 means no limit.")
+	flag.IntVar(&flagConfig.MaxBroadcastJobRestartLimit, "advancedcronjob-max-broadcastjob-restart-limit", flagConfig.MaxBroadcastJobRestartLimit, "The max failurePolicy.restartLimit of the BroadcastJob template of an AdvancedCronJob, 0 means no limit.")
+	flag.IntVar(&flagConfig.MaxShortJobParallelism, "advancedcronjob-max-short-job-parallelism", flagConfig.MaxShortJobParallelism, "The max parallelism of the Job template of an AdvancedCronJob under concurrencyPolicy Forbid, whose activeDeadlineSeconds is shorter than the schedule interval, 0 means no limit.")
+	flag.DurationVar(&flagConfig.MinIntervalWithoutDeadline, "advancedcronjob-min-interval-without-deadline", flagConfig.MinIntervalWithoutDeadline, "The min interval of the schedules of an AdvancedCronJob under concurrencyPolicy Allow whose Job or BroadcastJob template sets no activeDeadlineSeconds, below which a warning is returned, e.g. 10m, 0 means no warning.")
+	flag.DurationVar(&flagConfig.ScheduleEditCooldown, "advancedcronjob-schedule-edit-cooldown", flagConfig.ScheduleEditCooldown, "The min duration between two modifications of the schedule of an AdvancedCronJob, works with AdvancedCronJobScheduleEditCooldown feature-gate.")
+	flag.StringVar(&flagConfig.DeprecatedFieldSeverity, "advancedcronjob-deprecated-field-severity", flagConfig.DeprecatedFieldSeverity, "How the deprecated fields used by Job and BroadcastJob templates are flagged, Warning or Error, works with AdvancedCronJobDeprecatedFieldValidation feature-gate.")
+	flag.Func("advancedcronjob-allowed-timezones", "The comma-separated time zones allowed in spec.timeZone of AdvancedCronJobs, e.g. UTC,Asia/Shanghai, empty means any time zone is allowed.", func(value string) error {
+		flagConfig.AllowedTimeZones = nil
+		if len(value) > 0 {
+			flagConfig.AllowedTimeZones = strings.Split(value, ",")
+		}
+		return nil
+	})
+	flag.Func("advancedcronjob-max-run-resource-requests", "The comma-separated budget of the resource requests of a run of an AdvancedCronJob, e.g. cpu=8,memory=16Gi, works with AdvancedCronJobLimitRunResources feature-gate.", func(value string) (err error) {
+		flagConfig.MaxRunResourceRequests, err = parseResourceList(value)
+		return err
+	})
+	flag.Func("advancedcronjob-security-context-baseline", "The comma-separated rules of the security context the containers of the Job and BroadcastJob templates of AdvancedCronJobs must meet, RunAsNonRoot, NoPrivilegeEscalation or ReadOnlyRootFilesystem, defaults to RunAsNonRoot, works with AdvancedCronJobSecurityContextBaseline feature-gate.", func(value string) (err error) {
+		flagConfig.SecurityContextBaseline, err = parseSecurityContextBaseline(value)
+		return err
+	})
+	flag.Func("advancedcronjob-allowed-hours", "The window of the hours of a day the schedules of AdvancedCronJobs can fire in, in the time zones they are scheduled in, e.g. 22:00-06:00, empty means any hour is allowed.", func(value string) (err error) {
+		flagConfig.AllowedHours, err = parseHoursWindow(value)
+		return err
+	})
+	flag.BoolVar(&flagConfig.ForbidAllowConcurrent, "advancedcronjob-forbid-allow-concurrency", false, "If true, AdvancedCronJobs can not use concurrencyPolicy Allow.")
+	flag.Func("advancedcronjob-required-labels", "The comma-separated label keys AdvancedCronJobs are required to have, e.g. cost-center,team, empty means no label is required.", func(value string) error {
+		flagConfig.RequiredLabels = nil
+		if len(value) > 0 {
+			flagConfig.RequiredLabels = strings.Split(value, ",")
+		}
+		return nil
+	})
+	flag.IntVar(&advancedcronjob.BroadcastJobAllowConcurrentMaxNodes, "advancedcronjob-broadcastjob-allow-concurrency-max-nodes", advancedcronjob.BroadcastJobAllowConcurrentMaxNodes, "The max number of nodes of the cluster in which the BroadcastJob templates of AdvancedCronJobs can use concurrencyPolicy Allow, 0 means Allow is restricted in any cluster, works with AdvancedCronJobLimitBroadcastConcurrency feature-gate.")
+	flag.StringVar(&flagConfig.GroupLabel, "advancedcronjob-group-label", "", "If set, an AdvancedCronJob can not be created with the same schedule and template kind as another one in the namespace with the same value of this label, e.g. app.kubernetes.io/part-of.")
+}
 
 var (
 	// HandlerGetterMap contains admission webhook handlers
 	HandlerGetterMap = map[string]types.HandlerGetter{
 		"validate-apps-kruise-io-advancedcronjob": func(mgr manager.Manager) admission.Handler {
-			return &AdvancedCronJobCreateUpdateHandler{Decoder: admission.NewDecoder(mgr.GetScheme())}
+			config := configFromFlags()
+			return &AdvancedCronJobCreateUpdateHandler{
+				Client:          mgr.GetClient(),
+				Decoder:         admission.NewDecoder(mgr.GetScheme()),
+				Policies:        enabledPolicies(config),
+				Config:          config,
+				AuditHooks:      registeredAuditHooks,
+				DiscoveryClient: discoveryClient(),
+			}
+		},
+		// validate-only path for the policy preview, which reports the validation errors one by one
+		"validate-apps-kruise-io-advancedcronjob-report": func(mgr manager.Manager) admission.Handler {
+			config := configFromFlags()
+			return &AdvancedCronJobCreateUpdateHandler{
+				Client:           mgr.GetClient(),
+				Decoder:          admission.NewDecoder(mgr.GetScheme()),
+				Policies:         enabledPolicies(config),
+				Config:           config,
+				StructuredReport: true,
+				DiscoveryClient:  discoveryClient(),
+			}
 		},
 	}
 )
+
+// discoveryClient returns the discovery client of the generic client, or nil if it is not initialized.
+func discoveryClient() discovery.DiscoveryInterface {
+	if genericClient := kruiseclient.GetGenericClient(); genericClient != nil {
+		return genericClient.DiscoveryClient
+	}
+	return nil
+}
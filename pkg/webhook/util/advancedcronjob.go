@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// ResolveLocation returns the location in which the AdvancedCronJob is scheduled.
+// A TZ= or CRON_TZ= prefix of the schedule, or of the scheduleExpression if it is set, takes precedence
+// over spec.timeZone, and the location of the kruise-controller-manager process is used if neither is set.
+func ResolveLocation(acj *appsv1beta1.AdvancedCronJob) (*time.Location, error) {
+	schedule := acj.Spec.Schedule
+	if acj.Spec.ScheduleExpression != nil {
+		schedule = strings.TrimSpace(*acj.Spec.ScheduleExpression)
+	}
+	if tz, ok := ParseEmbeddedTimeZone(schedule); ok {
+		return LoadLocation(tz)
+	}
+
+	timeZone := acj.Spec.TimeZone
+	if timeZone == nil {
+		return time.Local, nil
+	}
+	if len(*timeZone) == 0 {
+		return nil, fmt.Errorf("timeZone must be nil or non-empty string")
+	}
+	if strings.EqualFold(*timeZone, "Local") {
+		return nil, fmt.Errorf("timeZone must be an explicit time zone as defined in https://www.iana.org/time-zones")
+	}
+	return LoadLocation(*timeZone)
+}
+
+// locations caches the locations loaded by LoadLocation by name.
+var locations sync.Map
+
+// LoadLocation is time.LoadLocation with the loaded locations cached, so that the zoneinfo is read and parsed
+// once per time zone instead of once per AdvancedCronJob. The failures are not cached.
+func LoadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// ScheduleChanged reports whether the update of the AdvancedCronJob changes when it runs, by any of the
+// schedule, scheduleExpression, solarSchedule, runAt and timeZone.
+func ScheduleChanged(obj, oldObj *appsv1beta1.AdvancedCronJob) bool {
+	return obj.Spec.Schedule != oldObj.Spec.Schedule ||
+		!apiequality.Semantic.DeepEqual(obj.Spec.ScheduleExpression, oldObj.Spec.ScheduleExpression) ||
+		!apiequality.Semantic.DeepEqual(obj.Spec.SolarSchedule, oldObj.Spec.SolarSchedule) ||
+		!apiequality.Semantic.DeepEqual(obj.Spec.RunAt, oldObj.Spec.RunAt) ||
+		!apiequality.Semantic.DeepEqual(obj.Spec.TimeZone, oldObj.Spec.TimeZone)
+}
+
+// ParseEmbeddedTimeZone returns the time zone of a TZ= or CRON_TZ= prefix in the schedule, the same
+// way as the cron parser does.
+func ParseEmbeddedTimeZone(schedule string) (string, bool) {
+	if !strings.HasPrefix(schedule, "TZ=") && !strings.HasPrefix(schedule, "CRON_TZ=") {
+		return "", false
+	}
+	i := strings.Index(schedule, " ")
+	if i < 0 {
+		i = len(schedule)
+	}
+	eq := strings.Index(schedule, "=")
+	return schedule[eq+1 : i], true
+}
+
+// EmbeddedTimeZones returns the time zones of all the TZ= and CRON_TZ= tokens in the schedule. The cron parser
+// takes only the first one as the prefix, so a schedule with more than one is ambiguous.
+func EmbeddedTimeZones(schedule string) []string {
+	var timeZones []string
+	for _, token := range strings.Fields(schedule) {
+		if tz, ok := ParseEmbeddedTimeZone(token); ok {
+			timeZones = append(timeZones, tz)
+		}
+	}
+	return timeZones
+}
+
+// zoneinfoDirs are the directories searched for the system time zone database, the same as the time package.
+var zoneinfoDirs = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+	"/etc/zoneinfo/",
+}
+
+// tzdataVersionRegex matches the versions of the time zone database, the year followed by letters, e.g. 2025b.
+var tzdataVersionRegex = regexp.MustCompile(`^[0-9]{4}[a-z]+$`)
+
+// IsTZDataVersion reports whether the value is a version of the time zone database, e.g. 2025b.
+func IsTZDataVersion(value string) bool {
+	return tzdataVersionRegex.MatchString(value)
+}
+
+var tzdataVersion struct {
+	sync.Once
+	version string
+}
+
+// TZDataVersion returns the version of the time zone database loaded by the process, e.g. 2025b.
+// The directory in $ZONEINFO takes precedence over the system directories. It returns an empty string
+// if the version is unknown, e.g. the database is embedded by time/tzdata or does not record its version in
+// the IANA format.
+func TZDataVersion() string {
+	tzdataVersion.Do(func() {
+		dirs := zoneinfoDirs
+		if zoneinfo := os.Getenv("ZONEINFO"); zoneinfo != "" {
+			dirs = append([]string{zoneinfo}, dirs...)
+		}
+		for _, dir := range dirs {
+			if version := readTZDataVersion(dir); IsTZDataVersion(version) {
+				tzdataVersion.version = version
+				return
+			}
+		}
+	})
+	return tzdataVersion.version
+}
+
+// readTZDataVersion reads the version of the time zone database in the directory, from the header of
+// tzdata.zi or the +VERSION file.
+func readTZDataVersion(dir string) string {
+	if f, err := os.Open(filepath.Join(dir, "tzdata.zi")); err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		if scanner.Scan() {
+			if version, ok := strings.CutPrefix(scanner.Text(), "# version "); ok {
+				return strings.TrimSpace(version)
+			}
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "+VERSION")); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestResolveLocation(t *testing.T) {
+	cases := []struct {
+		name               string
+		schedule           string
+		scheduleExpression *string
+		timeZone           *string
+		expected           string
+		expectErr          bool
+	}{
+		{
+			name:     "default to the local time zone",
+			schedule: "0 10 * * *",
+			expected: time.Local.String(),
+		},
+		{
+			name:     "timeZone",
+			schedule: "0 10 * * *",
+			timeZone: ptr.To("Asia/Shanghai"),
+			expected: "Asia/Shanghai",
+		},
+		{
+			name:     "embedded TZ takes precedence over timeZone",
+			schedule: "TZ=America/New_York 0 10 * * *",
+			timeZone: ptr.To("Asia/Shanghai"),
+			expected: "America/New_York",
+		},
+		{
+			name:               "embedded CRON_TZ in scheduleExpression",
+			scheduleExpression: ptr.To(" CRON_TZ=Europe/Berlin 0 10 * * * except lastday"),
+			expected:           "Europe/Berlin",
+		},
+		{
+			name:      "empty timeZone",
+			schedule:  "0 10 * * *",
+			timeZone:  ptr.To(""),
+			expectErr: true,
+		},
+		{
+			name:      "implicit local timeZone",
+			schedule:  "0 10 * * *",
+			timeZone:  ptr.To("Local"),
+			expectErr: true,
+		},
+		{
+			name:      "unknown timeZone",
+			schedule:  "0 10 * * *",
+			timeZone:  ptr.To("broken"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			acj := &appsv1beta1.AdvancedCronJob{Spec: appsv1beta1.AdvancedCronJobSpec{
+				Schedule:           tc.schedule,
+				ScheduleExpression: tc.scheduleExpression,
+				TimeZone:           tc.timeZone,
+			}}
+			loc, err := ResolveLocation(acj)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected error, got location %v", loc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if loc.String() != tc.expected {
+				t.Errorf("expected location %s, got %s", tc.expected, loc)
+			}
+		})
+	}
+}
+
+func TestEmbeddedTimeZones(t *testing.T) {
+	cases := []struct {
+		schedule string
+		expected []string
+	}{
+		{schedule: "0 * * * *"},
+		{schedule: "TZ=Asia/Shanghai 0 * * * *", expected: []string{"Asia/Shanghai"}},
+		{schedule: "TZ=Asia/Shanghai CRON_TZ=UTC 0 * * * *", expected: []string{"Asia/Shanghai", "UTC"}},
+		{schedule: "0 * * * * TZ=UTC", expected: []string{"UTC"}},
+	}
+	for _, tc := range cases {
+		if got := EmbeddedTimeZones(tc.schedule); !reflect.DeepEqual(tc.expected, got) {
+			t.Errorf("%q: expected %v, got %v", tc.schedule, tc.expected, got)
+		}
+	}
+}
+
+func TestReadTZDataVersion(t *testing.T) {
+	cases := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{
+			name:     "tzdata.zi",
+			files:    map[string]string{"tzdata.zi": "# version 2025b\n# This zic input file is in the public domain.\n"},
+			expected: "2025b",
+		},
+		{
+			name:     "+VERSION",
+			files:    map[string]string{"+VERSION": "2024a\n"},
+			expected: "2024a",
+		},
+		{
+			name:     "tzdata.zi without version",
+			files:    map[string]string{"tzdata.zi": "R d 1916 o - Jun 14 23s 1 S\n", "+VERSION": "2024a"},
+			expected: "2024a",
+		},
+		{
+			name: "no version",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if version := readTZDataVersion(dir); version != tc.expected {
+				t.Errorf("expected version %q, got %q", tc.expected, version)
+			}
+		})
+	}
+}